@@ -0,0 +1,31 @@
+package matrix
+
+/*
+GetUnsafe returns the element of a Matf64 at the passed row and column,
+without checking that they are within bounds. It is meant for hot inner
+loops, where the caller already knows that the indices are valid:
+
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			sum += m.GetUnsafe(i, j) * w.GetUnsafe(j, 0)
+		}
+	}
+
+A column outside of bounds silently reads an element of another row, and
+only an index outside of the storage of the Matf64 panics, with the usual
+runtime error of Go rather than an error of the package. It is small enough
+to be inlined by the compiler.
+*/
+func (m *Matf64) GetUnsafe(r, c int) float64 {
+	return m.vals[r*m.c+c]
+}
+
+/*
+SetUnsafe sets the element of a Matf64 at the passed row and column to the
+passed value, without checking that they are within bounds, in the same
+manner as GetUnsafe. Unlike Set, it does not return the receiver, so that it
+can be inlined.
+*/
+func (m *Matf64) SetUnsafe(r, c int, val float64) {
+	m.vals[r*m.c+c] = val
+}
@@ -0,0 +1,18 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUnsafef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.Equal(t, 1.0, m.GetUnsafe(0, 0), "should be equal")
+	assert.Equal(t, 6.0, m.GetUnsafe(1, 2), "should be equal")
+	assert.Equal(t, m.Get(1, 1), m.GetUnsafe(1, 1), "should be equal")
+
+	m.SetUnsafe(1, 0, 10)
+	assert.Equal(t, []float64{1, 2, 3, 10, 5, 6}, m.vals, "should be equal")
+}
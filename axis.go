@@ -0,0 +1,103 @@
+package matrix
+
+import "fmt"
+
+/*
+ApplyAxis calls the passed function on a copy of each row (axis 0) or each
+column (axis 1) of the receiver, as returned by Row and Col, and assembles the
+vectors it returns into a new Matf64. For example, to scale each row by its
+largest element:
+
+	n := m.ApplyAxis(0, func(row *matrix.Matf64) *matrix.Matf64 {
+		_, max := row.Max()
+		return row.Div(max)
+	})
+
+The function must return a row or column vector, of the same length for
+every row or column. With axis 0 each result becomes a row of the returned
+Matf64, and with axis 1 a column, so the length of the rows or columns may
+change. The receiver is not changed.
+*/
+func (m *Matf64) ApplyAxis(axis int, f func(*Matf64) *Matf64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.ApplyAxis(axis, f) })
+	}
+	count, _, _, _ := imputeLayout("ApplyAxis()", m, axis)
+	var o *Matf64
+	for k := 0; k < count; k++ {
+		var v *Matf64
+		if axis == 0 {
+			v = f(m.Row(k))
+		} else {
+			v = f(m.Col(k))
+		}
+		if !v.isRowVector() && !v.isColVector() {
+			s := "\nIn %s, the passed function must return a row or column\n"
+			s += "vector, however it returned a %d by %d Matf64 for index %d."
+			s = fmt.Sprintf(s, "ApplyAxis()", v.r, v.c, k)
+			printErr(ErrShape, s)
+		}
+		n := len(v.vals)
+		if o == nil {
+			if axis == 0 {
+				o = Newf64(count, n)
+			} else {
+				o = Newf64(n, count)
+			}
+		}
+		if axis == 0 && n != o.c || axis == 1 && n != o.r {
+			s := "\nIn %s, the passed function returned a vector of length %d\n"
+			s += "for index %d, which is different from the previous ones."
+			s = fmt.Sprintf(s, "ApplyAxis()", n, k)
+			printErr(ErrShape, s)
+		}
+		if axis == 0 {
+			copy(o.vals[k*n:(k+1)*n], v.vals)
+		} else {
+			for i, x := range v.vals {
+				o.vals[i*count+k] = x
+			}
+		}
+	}
+	if o == nil {
+		return Newf64()
+	}
+	return o
+}
+
+/*
+ReduceAxis reduces each row (axis 0) or each column (axis 1) of the receiver
+to a single value, by starting from init and calling the passed function with
+the value so far and each element in turn. The results are returned as a
+column vector with one element per row for axis 0, or a row vector with one
+element per column for axis 1. For example, the harmonic mean of each column
+is:
+
+	r, _ := m.Shape()
+	h := m.ReduceAxis(1, 0.0, func(acc, v float64) float64 {
+		return acc + 1.0/v
+	})
+	h.Map(func(x *float64) { *x = float64(r) / *x })
+
+which generalizes Sum, Prd, Min and Max.
+*/
+func (m *Matf64) ReduceAxis(axis int, init float64, f func(acc, v float64) float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.ReduceAxis(axis, init, f) })
+	}
+	count, n, step, stride := imputeLayout("ReduceAxis()", m, axis)
+	var o *Matf64
+	if axis == 0 {
+		o = Newf64(count, 1)
+	} else {
+		o = Newf64(1, count)
+	}
+	for k := range o.vals {
+		acc := init
+		for i := 0; i < n; i++ {
+			acc = f(acc, m.vals[k*stride+i*step])
+		}
+		o.vals[k] = acc
+	}
+	return o
+}
@@ -0,0 +1,50 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyAxisf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 2, 4,
+		3, 6, 3,
+	}, 2, 3)
+	n := m.ApplyAxis(0, func(row *Matf64) *Matf64 {
+		_, max := row.Max()
+		return row.Div(max)
+	})
+	assert.Equal(t, []float64{0.25, 0.5, 1, 0.5, 1, 0.5}, n.ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{1, 2, 4, 3, 6, 3}, m.ToSlice1D(), "should not change m")
+	c := m.ApplyAxis(1, func(col *Matf64) *Matf64 {
+		return Matf64FromData([]float64{col.Sum(), col.Get(0, 0), col.Get(1, 0)}, 1, 3)
+	})
+	r, cols := c.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 3, cols, "should be equal")
+	assert.Equal(t, []float64{
+		4, 8, 7,
+		1, 2, 4,
+		3, 6, 3,
+	}, c.ToSlice1D(), "should be equal")
+}
+
+func TestReduceAxisf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 2, 4,
+		3, 6, 3,
+	}, 2, 3)
+	rows := m.ReduceAxis(0, 0.0, func(acc, v float64) float64 { return acc + v })
+	r, c := rows.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 1, c, "should be equal")
+	assert.Equal(t, []float64{m.Sum(0, 0), m.Sum(0, 1)}, rows.ToSlice1D(), "should match Sum")
+	cols := m.ReduceAxis(1, 1.0, func(acc, v float64) float64 { return acc * v })
+	r, c = cols.Shape()
+	assert.Equal(t, 1, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	assert.Equal(t, []float64{3, 12, 12}, cols.ToSlice1D(), "should be equal")
+}
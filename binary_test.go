@@ -0,0 +1,45 @@
+package matrix
+
+import (
+	"log"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveLoadf64(t *testing.T) {
+	t.Helper()
+	filename := "binary_test.bin"
+	m := Matf64FromData([]float64{1.0 / 3.0, -2, math.Inf(1), 4e-300, 5, 6}, 2, 3)
+	m.Save(filename)
+	defer func() {
+		if err := os.Remove(filename); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	assert.True(t, Loadf64(filename).Equals(m), "should be equal")
+	assert.True(t, LoadUncheckedf64(filename).Equals(m), "should be equal")
+}
+
+func TestDecodeBinaryf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	b := m.encodeBinary()
+	n, err := decodeBinary(b, true)
+	assert.NoError(t, err, "should decode")
+	assert.True(t, n.Equals(m), "should be equal")
+
+	corrupt := append([]byte(nil), b...)
+	corrupt[binaryHeaderLen+3] ^= 0x10
+	_, err = decodeBinary(corrupt, true)
+	assert.Error(t, err, "should detect the corruption")
+	_, err = decodeBinary(corrupt, false)
+	assert.NoError(t, err, "should not verify the checksum")
+
+	_, err = decodeBinary(b[:len(b)-9], true)
+	assert.Error(t, err, "should detect the truncation")
+	_, err = decodeBinary([]byte("not a matrix at all"), true)
+	assert.Error(t, err, "should reject other files")
+}
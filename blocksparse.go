@@ -0,0 +1,292 @@
+package matrix
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+BlockSparsef64 is a sparse matrix made of dense br by bc blocks, placed on a
+sparse grid of blocks. This is the block compressed sparse row (BSR) format,
+which is a natural fit for the matrices of finite element and multi-physics
+problems, where every node couples several unknowns to those of its
+neighbors. Compared to a Sparsef64, only one column index is stored per
+block instead of per element, and the multiplication works on dense blocks:
+
+	b := matrix.NewBlockSparsef64(6, 6, 3, 3,
+		[]int{0, 1, 1}, []int{0, 0, 1},
+		[]*matrix.Matf64{k00, k10, k11})
+	y := b.Dot(x)
+
+The fields of this struct are not directly accessible.
+*/
+type BlockSparsef64 struct {
+	r, c   int
+	br, bc int
+	// ptr[i] to ptr[i+1] are the positions in idx of the blocks in block row
+	// i, and idx holds their block columns. Block k occupies
+	// vals[k*br*bc:(k+1)*br*bc], in row major order.
+	ptr  []int
+	idx  []int
+	vals []float64
+}
+
+func checkBlockShape(fn string, r, c, br, bc int) {
+	if br < 1 || bc < 1 || r < 0 || c < 0 || r%br != 0 || c%bc != 0 {
+		s := "\nIn matrix.%s, a %d by %d matrix cannot be divided into %d by %d\n"
+		s += "blocks. The block sizes must be positive, and divide the number\n"
+		s += "of rows and columns."
+		s = fmt.Sprintf(s, fn, r, c, br, bc)
+		printHelperErr(ErrShape, s)
+	}
+}
+
+/*
+NewBlockSparsef64 creates an r by c BlockSparsef64 with br by bc blocks. The
+block at row rows[k] and column cols[k] of the grid of blocks is blocks[k],
+which is copied. Blocks which are placed at the same position are summed,
+which is how contributions from neighboring elements are assembled. The
+block sizes must divide the number of rows and columns.
+*/
+func NewBlockSparsef64(r, c, br, bc int, rows, cols []int, blocks []*Matf64) *BlockSparsef64 {
+	checkBlockShape("NewBlockSparsef64()", r, c, br, bc)
+	if len(rows) != len(blocks) || len(cols) != len(blocks) {
+		s := "\nIn matrix.%s, the number of rows, columns and blocks must be\n"
+		s += "equal, however %d, %d and %d were received."
+		s = fmt.Sprintf(s, "NewBlockSparsef64()", len(rows), len(cols), len(blocks))
+		printErr(ErrShape, s)
+	}
+	for k := range blocks {
+		if rows[k] < 0 || rows[k] >= r/br || cols[k] < 0 || cols[k] >= c/bc {
+			s := "\nIn matrix.%s, block %d is at row %d and column %d, which is\n"
+			s += "outside of the %d by %d grid of blocks."
+			s = fmt.Sprintf(s, "NewBlockSparsef64()", k, rows[k], cols[k], r/br, c/bc)
+			printErr(ErrBounds, s)
+		}
+		if blocks[k].r != br || blocks[k].c != bc {
+			s := "\nIn matrix.%s, block %d is %d by %d, however the blocks must\n"
+			s += "be %d by %d."
+			s = fmt.Sprintf(s, "NewBlockSparsef64()", k, blocks[k].r, blocks[k].c, br, bc)
+			printErr(ErrShape, s)
+		}
+	}
+	return newBlockSparsef64(r, c, br, bc, rows, cols, blocks)
+}
+
+// newBlockSparsef64 compresses a list of blocks and their positions in the
+// grid of blocks into a BlockSparsef64, summing the duplicates.
+func newBlockSparsef64(r, c, br, bc int, rows, cols []int, blocks []*Matf64) *BlockSparsef64 {
+	nr := r / br
+	b := &BlockSparsef64{r: r, c: c, br: br, bc: bc, ptr: make([]int, nr+1)}
+	order := make([]int, len(blocks))
+	for k := range order {
+		order[k] = k
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if rows[order[i]] != rows[order[j]] {
+			return rows[order[i]] < rows[order[j]]
+		}
+		return cols[order[i]] < cols[order[j]]
+	})
+	size := br * bc
+	for n, k := range order {
+		if n > 0 && rows[k] == rows[order[n-1]] && cols[k] == cols[order[n-1]] {
+			last := b.vals[len(b.vals)-size:]
+			for i, v := range blocks[k].vals[:size] {
+				last[i] += v
+			}
+			continue
+		}
+		b.ptr[rows[k]+1]++
+		b.idx = append(b.idx, cols[k])
+		b.vals = append(b.vals, blocks[k].vals[:size]...)
+	}
+	for i := 0; i < nr; i++ {
+		b.ptr[i+1] += b.ptr[i]
+	}
+	return b
+}
+
+/*
+BlockSparsef64FromMatf64 converts the passed Matf64 to a BlockSparsef64 with
+br by bc blocks, keeping only the blocks which have at least one non-zero
+element.
+*/
+func BlockSparsef64FromMatf64(m *Matf64, br, bc int) *BlockSparsef64 {
+	checkBlockShape("BlockSparsef64FromMatf64()", m.r, m.c, br, bc)
+	var rows, cols []int
+	var blocks []*Matf64
+	for bi := 0; bi < m.r/br; bi++ {
+		for bj := 0; bj < m.c/bc; bj++ {
+			blk := Newf64(br, bc)
+			nonZero := false
+			for i := 0; i < br; i++ {
+				for j := 0; j < bc; j++ {
+					v := m.vals[(bi*br+i)*m.c+bj*bc+j]
+					blk.vals[i*bc+j] = v
+					nonZero = nonZero || v != 0.0
+				}
+			}
+			if nonZero {
+				rows = append(rows, bi)
+				cols = append(cols, bj)
+				blocks = append(blocks, blk)
+			}
+		}
+	}
+	return newBlockSparsef64(m.r, m.c, br, bc, rows, cols, blocks)
+}
+
+/*
+Shape returns the number of rows and columns of a BlockSparsef64.
+*/
+func (b *BlockSparsef64) Shape() (int, int) {
+	return b.r, b.c
+}
+
+/*
+BlockSize returns the number of rows and columns of each block of a
+BlockSparsef64.
+*/
+func (b *BlockSparsef64) BlockSize() (int, int) {
+	return b.br, b.bc
+}
+
+/*
+NNZB returns the number of blocks which are stored in a BlockSparsef64.
+*/
+func (b *BlockSparsef64) NNZB() int {
+	return len(b.idx)
+}
+
+// find returns the position of the block at row bi and column bj of the grid
+// of blocks, or -1 if it is not stored.
+func (b *BlockSparsef64) find(bi, bj int) int {
+	start, end := b.ptr[bi], b.ptr[bi+1]
+	k := start + sort.SearchInts(b.idx[start:end], bj)
+	if k < end && b.idx[k] == bj {
+		return k
+	}
+	return -1
+}
+
+/*
+Get returns the element of a BlockSparsef64 at the given row and column.
+*/
+func (b *BlockSparsef64) Get(r, c int) float64 {
+	if r < 0 || r >= b.r || c < 0 || c >= b.c {
+		s := "\nIn %s, row %d and column %d are outside of the bounds of\n"
+		s += "a %d by %d matrix."
+		s = fmt.Sprintf(s, "Get()", r, c, b.r, b.c)
+		printErr(ErrBounds, s)
+	}
+	k := b.find(r/b.br, c/b.bc)
+	if k < 0 {
+		return 0.0
+	}
+	return b.vals[k*b.br*b.bc+(r%b.br)*b.bc+c%b.bc]
+}
+
+/*
+Block returns a copy of the block at row bi and column bj of the grid of
+blocks of a BlockSparsef64, which is all zeros if the block is not stored.
+*/
+func (b *BlockSparsef64) Block(bi, bj int) *Matf64 {
+	if bi < 0 || bi >= b.r/b.br || bj < 0 || bj >= b.c/b.bc {
+		s := "\nIn %s, block row %d and block column %d are outside of the\n"
+		s += "%d by %d grid of blocks."
+		s = fmt.Sprintf(s, "Block()", bi, bj, b.r/b.br, b.c/b.bc)
+		printErr(ErrBounds, s)
+	}
+	blk := Newf64(b.br, b.bc)
+	if k := b.find(bi, bj); k >= 0 {
+		copy(blk.vals, b.vals[k*b.br*b.bc:(k+1)*b.br*b.bc])
+	}
+	return blk
+}
+
+/*
+ToMatf64 returns a dense Matf64 with the elements of a BlockSparsef64.
+*/
+func (b *BlockSparsef64) ToMatf64() *Matf64 {
+	m := Newf64(b.r, b.c)
+	size := b.br * b.bc
+	for bi := 0; bi < b.r/b.br; bi++ {
+		for k := b.ptr[bi]; k < b.ptr[bi+1]; k++ {
+			blk := b.vals[k*size : (k+1)*size]
+			for i := 0; i < b.br; i++ {
+				copy(m.vals[(bi*b.br+i)*m.c+b.idx[k]*b.bc:], blk[i*b.bc:(i+1)*b.bc])
+			}
+		}
+	}
+	return m
+}
+
+/*
+ToCSR returns a Sparsef64 in the CSR format with the elements of a
+BlockSparsef64. Elements of the stored blocks which are 0.0 are not stored.
+*/
+func (b *BlockSparsef64) ToCSR() *Sparsef64 {
+	var rows, cols []int
+	var vals []float64
+	size := b.br * b.bc
+	for bi := 0; bi < b.r/b.br; bi++ {
+		for k := b.ptr[bi]; k < b.ptr[bi+1]; k++ {
+			for e, v := range b.vals[k*size : (k+1)*size] {
+				if v != 0.0 {
+					rows = append(rows, bi*b.br+e/b.bc)
+					cols = append(cols, b.idx[k]*b.bc+e%b.bc)
+					vals = append(vals, v)
+				}
+			}
+		}
+	}
+	return newSparsef64(b.r, b.c, CSR, rows, cols, vals)
+}
+
+/*
+Mul multiplies each element of a BlockSparsef64 by the passed float64.
+*/
+func (b *BlockSparsef64) Mul(v float64) *BlockSparsef64 {
+	for i := range b.vals {
+		b.vals[i] *= v
+	}
+	return b
+}
+
+/*
+Dot returns the product of a BlockSparsef64 and the passed dense Matf64, which
+must have as many rows as the BlockSparsef64 has columns. Each stored block is
+multiplied as a small dense matrix.
+*/
+func (b *BlockSparsef64) Dot(m *Matf64) *Matf64 {
+	if b.c != m.r {
+		s := "\nIn %s the number of columns of the BlockSparsef64 is %d\n"
+		s += "which is not equal to the number of rows of the Matf64, which\n"
+		s += "is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Dot()", b.c, m.r)
+		printErr(ErrShape, s)
+	}
+	o := Newf64(b.r, m.c)
+	size := b.br * b.bc
+	for bi := 0; bi < b.r/b.br; bi++ {
+		for k := b.ptr[bi]; k < b.ptr[bi+1]; k++ {
+			blk := b.vals[k*size : (k+1)*size]
+			for i := 0; i < b.br; i++ {
+				oi := o.vals[(bi*b.br+i)*o.c : (bi*b.br+i+1)*o.c]
+				for j := 0; j < b.bc; j++ {
+					v := blk[i*b.bc+j]
+					if v == 0.0 {
+						continue
+					}
+					row := b.idx[k]*b.bc + j
+					mj := m.vals[row*m.c : (row+1)*m.c]
+					for l := range oi {
+						oi[l] += v * mj[l]
+					}
+				}
+			}
+		}
+	}
+	return o
+}
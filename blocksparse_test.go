@@ -0,0 +1,70 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBlockSparsef64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	c := Matf64FromData([]float64{5, 6, 7, 8}, 2, 2)
+	b := NewBlockSparsef64(4, 6, 2, 2,
+		[]int{1, 0, 1}, []int{2, 0, 2},
+		[]*Matf64{a, c, a})
+	r, cols := b.Shape()
+	assert.Equal(t, 4, r, "should be equal")
+	assert.Equal(t, 6, cols, "should be equal")
+	br, bc := b.BlockSize()
+	assert.Equal(t, 2, br, "should be equal")
+	assert.Equal(t, 2, bc, "should be equal")
+	assert.Equal(t, 2, b.NNZB(), "should sum duplicates")
+	want := []float64{
+		5, 6, 0, 0, 0, 0,
+		7, 8, 0, 0, 0, 0,
+		0, 0, 0, 0, 2, 4,
+		0, 0, 0, 0, 6, 8,
+	}
+	assert.Equal(t, want, b.ToMatf64().ToSlice1D(), "should be equal")
+	assert.Equal(t, 6.0, b.Get(3, 4), "should be equal")
+	assert.Equal(t, 0.0, b.Get(3, 1), "should be equal")
+	assert.Equal(t, []float64{2, 4, 6, 8}, b.Block(1, 2).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{0, 0, 0, 0}, b.Block(0, 1).ToSlice1D(), "should be zero")
+	a.Set(0, 0, 100.0)
+	assert.Equal(t, 2.0, b.Get(2, 4), "should copy the blocks")
+}
+
+func TestBlockSparsef64FromMatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 0, 0, 0,
+		0, 2, 0, 0,
+		0, 0, 0, 0,
+		3, 0, 0, 4,
+	}, 4, 4)
+	b := BlockSparsef64FromMatf64(m, 2, 2)
+	assert.Equal(t, 3, b.NNZB(), "should drop zero blocks")
+	assert.True(t, b.ToMatf64().Equals(m), "should be equal")
+	assert.True(t, b.ToCSR().ToMatf64().Equals(m), "should be equal")
+	assert.Equal(t, 4, b.ToCSR().NNZ(), "should drop zero elements")
+}
+
+func TestBlockSparsef64Dot(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(6, 9)
+	for i := 0; i < 3; i++ {
+		for j := 3; j < 6; j++ {
+			m.Set(i, j, 0.0)
+		}
+	}
+	b := BlockSparsef64FromMatf64(m, 3, 3)
+	assert.Equal(t, 5, b.NNZB(), "should be equal")
+	x := RandMatf64(9, 2)
+	got := b.Dot(x).ToSlice1D()
+	for i, v := range m.Dot(x).ToSlice1D() {
+		assert.InDelta(t, v, got[i], 1e-12, "should be equal")
+	}
+	want := m.Copy().Mul(2.0)
+	assert.True(t, b.Mul(2.0).ToMatf64().Equals(want), "should be equal")
+}
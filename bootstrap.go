@@ -0,0 +1,84 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+/*
+Bootstrap draws n rows of the receiver at random, with replacement, and
+returns them as the rows of a new n by c Matf64, along with the index of the
+row that each was drawn from:
+
+	src := rand.NewSource(42)
+	r, _ := m.Shape()
+	sample, idx := m.Bootstrap(r, nil, src)
+
+If weights is nil, every row is equally likely to be drawn. Otherwise it
+must have one element per row, and each row is drawn with a probability
+proportional to its weight, so the weights must not be negative, and at
+least one of them must be positive. The rows are drawn from the passed
+source, so the sample is reproducible for a given seed. The receiver is not
+changed.
+*/
+func (m *Matf64) Bootstrap(n int, weights []float64, src rand.Source) (*Matf64, []int) {
+	if m.deferErr {
+		var o *Matf64
+		var rows []int
+		if !m.deferredDo(func() { o, rows = m.Bootstrap(n, weights, src) }) {
+			return m, nil
+		}
+		o.deferErr = true
+		return o, rows
+	}
+	if n < 0 || (n > 0 && m.r == 0) {
+		s := "\nIn %s, cannot draw %d rows from a Matf64 with %d rows."
+		s = fmt.Sprintf(s, "Bootstrap()", n, m.r)
+		printErr(ErrArgument, s)
+	}
+	var cum []float64
+	if weights != nil {
+		if len(weights) != m.r {
+			s := "\nIn %s, there must be one weight per row, which is %d\n"
+			s += "weights, however %d weights were received."
+			s = fmt.Sprintf(s, "Bootstrap()", m.r, len(weights))
+			printErr(ErrShape, s)
+		}
+		cum = make([]float64, m.r)
+		total := 0.0
+		for i, w := range weights {
+			if w < 0 || math.IsNaN(w) || math.IsInf(w, 1) {
+				s := "\nIn %s, the weights must be finite and not negative,\n"
+				s += "however the weight at index %d is %v."
+				s = fmt.Sprintf(s, "Bootstrap()", i, w)
+				printErr(ErrArgument, s)
+			}
+			total += w
+			cum[i] = total
+		}
+		if !(total > 0) && n > 0 {
+			s := "\nIn %s, at least one of the weights must be positive."
+			s = fmt.Sprintf(s, "Bootstrap()")
+			printErr(ErrArgument, s)
+		}
+	}
+	rng := rand.New(src)
+	o := Newf64(n, m.c)
+	idx := make([]int, n)
+	for k := range idx {
+		var i int
+		if cum == nil {
+			i = rng.Intn(m.r)
+		} else {
+			// The search skips rows of zero weight, as their cumulative
+			// weight is equal to that of the row before them.
+			u := rng.Float64() * cum[m.r-1]
+			i = sort.Search(m.r, func(j int) bool { return cum[j] > u })
+		}
+		idx[k] = i
+		copy(o.vals[k*m.c:(k+1)*m.c], m.vals[i*m.c:(i+1)*m.c])
+	}
+	return o, idx
+}
@@ -0,0 +1,40 @@
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{0, 1}, {2, 3}, {4, 5}, {6, 7}})
+	o, idx := m.Bootstrap(50, nil, rand.NewSource(1))
+	assert.Equal(t, 50, len(idx), "should be equal")
+	r, c := o.Shape()
+	assert.Equal(t, 50, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	for k, i := range idx {
+		assert.Equal(t, m.Row(i).vals, o.Row(k).vals, "should be equal")
+	}
+
+	o2, idx2 := m.Bootstrap(50, nil, rand.NewSource(1))
+	assert.Equal(t, idx, idx2, "should be reproducible")
+	assert.True(t, o.Equals(o2), "should be reproducible")
+
+	_, idx = m.Bootstrap(1000, []float64{0, 3, 0, 1}, rand.NewSource(2))
+	counts := make([]int, 4)
+	for _, i := range idx {
+		counts[i]++
+	}
+	assert.Equal(t, 0, counts[0], "should never draw a zero weight")
+	assert.Equal(t, 0, counts[2], "should never draw a zero weight")
+	assert.InDelta(t, 750, counts[1], 60, "should follow the weights")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { m.Bootstrap(5, []float64{1, 1}, nil) }, "should panic")
+	assert.Panics(t, func() { m.Bootstrap(5, []float64{1, -1, 1, 1}, nil) }, "should panic")
+	assert.Panics(t, func() { m.Bootstrap(5, []float64{0, 0, 0, 0}, nil) }, "should panic")
+	assert.Panics(t, func() { Newf64(0, 2).Bootstrap(1, nil, nil) }, "should panic")
+}
@@ -0,0 +1,110 @@
+package matrix
+
+import "fmt"
+
+/*
+Builderf64 constructs a Matf64 row by row, such as from a stream of records,
+without copying the rows which were already added on each new one, as
+repeated calls to AppendRow on a Matf64 do. The storage grows by doubling,
+and the finished Matf64 is returned by Build:
+
+	b := matrix.NewBuilderf64(3)
+	for rec := range records {
+		b.AppendRow(rec)
+	}
+	m := b.Build()
+
+All rows must have the same number of elements. It is not safe to use from
+several goroutines at once.
+*/
+type Builderf64 struct {
+	r, c int
+	vals []float64
+	// hint is the number of rows to allocate room for when the number of
+	// columns is set by the first row.
+	hint int
+}
+
+/*
+NewBuilderf64 returns a Builderf64 of Matf64 with the passed number of
+columns. If it is 0, the number of columns is set by the first row. An
+optional second argument is the number of rows to allocate room for, when it
+is known in advance, or can be estimated:
+
+	b := matrix.NewBuilderf64(3, 10000)
+*/
+func NewBuilderf64(cols int, rows ...int) *Builderf64 {
+	if cols < 0 || len(rows) > 1 || (len(rows) == 1 && rows[0] < 0) {
+		s := "\nIn matrix.%s, the number of columns, and of rows if passed,\n"
+		s += "must not be negative, and at most 2 arguments can be passed,\n"
+		s += "however %d and %v were received."
+		s = fmt.Sprintf(s, "NewBuilderf64()", cols, rows)
+		printErr(ErrArgument, s)
+	}
+	b := &Builderf64{c: cols}
+	if len(rows) == 1 {
+		b.hint = rows[0]
+		b.vals = make([]float64, 0, rows[0]*cols)
+	}
+	return b
+}
+
+/*
+AppendRow adds a copy of the passed row to the bottom of the Matf64 being
+built.
+*/
+func (b *Builderf64) AppendRow(row []float64) *Builderf64 {
+	if b.c == 0 && b.r == 0 {
+		b.c = len(row)
+		b.Grow(b.hint)
+	}
+	if len(row) != b.c || b.c == 0 {
+		s := "\nIn %s, the rows must have %d elements, however a row with %d\n"
+		s += "elements was received."
+		s = fmt.Sprintf(s, "AppendRow()", b.c, len(row))
+		printErr(ErrShape, s)
+	}
+	b.vals = append(b.vals, row...)
+	b.r++
+	return b
+}
+
+/*
+Grow makes room for at least the passed number of further rows, so that
+they can be added without allocating.
+*/
+func (b *Builderf64) Grow(rows int) *Builderf64 {
+	if rows < 0 {
+		s := "\nIn %s, the number of rows must not be negative, however %d\n"
+		s += "was received."
+		s = fmt.Sprintf(s, "Grow()", rows)
+		printErr(ErrArgument, s)
+	}
+	if n := len(b.vals) + rows*b.c; n > cap(b.vals) {
+		vals := make([]float64, len(b.vals), n)
+		copy(vals, b.vals)
+		b.vals = vals
+	}
+	return b
+}
+
+/*
+Rows returns the number of rows added so far.
+*/
+func (b *Builderf64) Rows() int {
+	return b.r
+}
+
+/*
+Build returns the Matf64 of the rows added so far, and resets the
+Builderf64, keeping its number of columns, so that it can build another. The
+returned Matf64 takes over the storage of the Builderf64, without copying it.
+*/
+func (b *Builderf64) Build() *Matf64 {
+	m := &Matf64{r: b.r, c: b.c, vals: b.vals}
+	if m.vals == nil {
+		m.vals = make([]float64, 0)
+	}
+	b.r, b.vals = 0, nil
+	return m
+}
@@ -0,0 +1,42 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderf64(t *testing.T) {
+	t.Helper()
+	b := NewBuilderf64(0)
+	b.AppendRow([]float64{1, 2, 3}).AppendRow([]float64{4, 5, 6})
+	assert.Equal(t, 2, b.Rows(), "should be equal")
+	m := b.Build()
+	assert.True(t, m.Equals(Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})), "should be equal")
+	assert.Equal(t, 0, b.Rows(), "should be reset")
+
+	b = NewBuilderf64(2, 100)
+	row := []float64{0, 0}
+	for i := 0; i < 100; i++ {
+		row[0], row[1] = float64(i), float64(2*i)
+		b.AppendRow(row)
+	}
+	m = b.Build()
+	r, c := m.Shape()
+	assert.Equal(t, 100, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	assert.Equal(t, []float64{99, 198}, m.Row(99).vals, "should be equal")
+
+	b.Grow(10)
+	assert.Equal(t, 20, cap(b.vals), "should make room")
+	m = b.Build()
+	r, c = m.Shape()
+	assert.Equal(t, 0, r, "should be empty")
+	assert.Equal(t, 2, c, "should keep the columns")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { NewBuilderf64(2).AppendRow([]float64{1}) }, "should panic")
+	assert.Panics(t, func() { NewBuilderf64(-1) }, "should panic")
+	assert.Panics(t, func() { NewBuilderf64(0).AppendRow(nil) }, "should panic")
+	assert.Panics(t, func() { NewBuilderf64(2).Grow(-1) }, "should panic")
+}
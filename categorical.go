@@ -0,0 +1,166 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+/*
+CategoricalMode selects how Matf64FromCSVCategorical handles columns which
+contain values that cannot be converted to a float64.
+*/
+type CategoricalMode int
+
+const (
+	// DropCategorical removes non-numeric columns from the result.
+	DropCategorical CategoricalMode = iota
+	// LabelEncode replaces each value of a non-numeric column with the index
+	// of that value in the levels of the column.
+	LabelEncode
+	// OneHotEncode replaces a non-numeric column with one column per level,
+	// which is 1.0 in the rows holding that level, and 0.0 elsewhere.
+	OneHotEncode
+)
+
+/*
+CategoricalColumn describes how a non-numeric column of a CSV file was
+encoded by Matf64FromCSVCategorical.
+
+Col is the index of the column in the file, and OutCol is the index of the
+(first) column it was encoded into in the resulting Matf64, or -1 if it was
+dropped. Levels holds the distinct values of the column, in the order in which
+they first appear in the file. With LabelEncode, a value is encoded as its
+index in Levels, and with OneHotEncode, the column OutCol+i corresponds to
+Levels[i].
+*/
+type CategoricalColumn struct {
+	Col    int
+	OutCol int
+	Levels []string
+}
+
+/*
+Matf64FromCSVCategorical creates a Matf64 from a CSV file which may contain
+non-numeric (categorical) columns. Unlike Matf64FromCSV, which stops at the
+first value that is not a number, this function detects the columns which
+contain such values, and encodes them according to the passed mode. For
+example, a file containing:
+
+	1.5,red
+	2.0,blue
+	0.5,red
+
+is read as follows with each of the modes:
+
+	m, cats := matrix.Matf64FromCSVCategorical("f.csv", matrix.DropCategorical)
+	// m is [[1.5], [2.0], [0.5]]
+	m, cats = matrix.Matf64FromCSVCategorical("f.csv", matrix.LabelEncode)
+	// m is [[1.5, 0.0], [2.0, 1.0], [0.5, 0.0]]
+	m, cats = matrix.Matf64FromCSVCategorical("f.csv", matrix.OneHotEncode)
+	// m is [[1.5, 1.0, 0.0], [2.0, 0.0, 1.0], [0.5, 1.0, 0.0]]
+
+In all three cases, cats describes the encoding of the second column, with
+the levels "red" and "blue". The returned encodings are ordered by the index
+of the column in the file. Since the type of every column must be known before
+it is encoded, the whole file is read into memory first.
+*/
+func Matf64FromCSVCategorical(filename string, mode CategoricalMode) (*Matf64, []CategoricalColumn) {
+	if mode < DropCategorical || mode > OneHotEncode {
+		s := "\nIn matrix.%s, %d is not a valid CategoricalMode."
+		s = fmt.Sprintf(s, "Matf64FromCSVCategorical()", mode)
+		printErr(ErrArgument, s)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromCSVCategorical()", filename, err)
+		printErr(ErrIO, s)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil || len(records) == 0 {
+		if err == nil {
+			err = fmt.Errorf("the file is empty")
+		}
+		s := "\nIn matrix.%s, cannot read from %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromCSVCategorical()", filename, err)
+		printErr(ErrIO, s)
+	}
+	nCols := len(records[0])
+	// Parse every value, and find the columns which are not numeric.
+	vals := make([][]float64, len(records))
+	numeric := make([]bool, nCols)
+	for j := range numeric {
+		numeric[j] = true
+	}
+	for i := range records {
+		vals[i] = make([]float64, nCols)
+		for j := range records[i] {
+			if !numeric[j] {
+				continue
+			}
+			vals[i][j], err = strconv.ParseFloat(records[i][j], 64)
+			if err != nil {
+				numeric[j] = false
+			}
+		}
+	}
+	// Collect the levels of each categorical column, and lay out the columns
+	// of the result.
+	var cats []CategoricalColumn
+	width := 0
+	for j := 0; j < nCols; j++ {
+		if numeric[j] {
+			width++
+			continue
+		}
+		cat := CategoricalColumn{Col: j, OutCol: -1}
+		seen := make(map[string]bool)
+		for i := range records {
+			if !seen[records[i][j]] {
+				seen[records[i][j]] = true
+				cat.Levels = append(cat.Levels, records[i][j])
+			}
+		}
+		switch mode {
+		case LabelEncode:
+			cat.OutCol = width
+			width++
+		case OneHotEncode:
+			cat.OutCol = width
+			width += len(cat.Levels)
+		}
+		cats = append(cats, cat)
+	}
+	codes := make([]map[string]int, len(cats))
+	for k := range cats {
+		codes[k] = make(map[string]int, len(cats[k].Levels))
+		for l, level := range cats[k].Levels {
+			codes[k][level] = l
+		}
+	}
+	m := Newf64(len(records), width)
+	for i := range records {
+		out, k := i*width, 0
+		for j := 0; j < nCols; j++ {
+			if numeric[j] {
+				m.vals[out] = vals[i][j]
+				out++
+				continue
+			}
+			code := codes[k][records[i][j]]
+			switch mode {
+			case LabelEncode:
+				m.vals[out] = float64(code)
+				out++
+			case OneHotEncode:
+				m.vals[out+code] = 1.0
+				out += len(cats[k].Levels)
+			}
+			k++
+		}
+	}
+	return m, cats
+}
@@ -0,0 +1,48 @@
+package matrix
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64FromCSVCategorical(t *testing.T) {
+	t.Helper()
+	filename := "categorical_test.csv"
+	str := "1.5,red,10,x\n2.0,blue,20,y\n0.5,red,30,x"
+	if err := os.WriteFile(filename, []byte(str), 0644); err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		if err := os.Remove(filename); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	m, cats := Matf64FromCSVCategorical(filename, DropCategorical)
+	assert.True(t, m.Equals(Matf64FromData([]float64{1.5, 10, 2.0, 20, 0.5, 30}, 3, 2)), "should be equal")
+	assert.Equal(t, []CategoricalColumn{
+		{Col: 1, OutCol: -1, Levels: []string{"red", "blue"}},
+		{Col: 3, OutCol: -1, Levels: []string{"x", "y"}},
+	}, cats, "should be equal")
+
+	m, cats = Matf64FromCSVCategorical(filename, LabelEncode)
+	assert.True(t, m.Equals(Matf64FromData([]float64{
+		1.5, 0, 10, 0,
+		2.0, 1, 20, 1,
+		0.5, 0, 30, 0,
+	}, 3, 4)), "should be equal")
+	assert.Equal(t, 1, cats[0].OutCol, "should be equal")
+	assert.Equal(t, 3, cats[1].OutCol, "should be equal")
+
+	m, cats = Matf64FromCSVCategorical(filename, OneHotEncode)
+	assert.True(t, m.Equals(Matf64FromData([]float64{
+		1.5, 1, 0, 10, 1, 0,
+		2.0, 0, 1, 20, 0, 1,
+		0.5, 1, 0, 30, 1, 0,
+	}, 3, 6)), "should be equal")
+	assert.Equal(t, 1, cats[0].OutCol, "should be equal")
+	assert.Equal(t, 4, cats[1].OutCol, "should be equal")
+}
@@ -0,0 +1,330 @@
+package matrix
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/*
+Chainf64 is a fluent builder over a Matf64, which checks each operation
+before doing it. Instead of exiting the program on an invalid operation, as
+the methods of Matf64 do, it records the error and skips all the operations
+which follow. The error is returned, along with the Matf64, by Result:
+
+	m, err := x.Chain().Sub(mean).Div(std).Dot(w).Add(b).Result()
+	if err != nil {
+		// handle the error, for example a shape mismatch in Dot
+	}
+
+The errors of shapes and indices are an *ErrDimMismatch or an *ErrIndexOOB,
+and the error of a singular matrix in Solve or Inv is an *ErrSingular, which
+can be inspected with errors.As.
+
+As with the methods of Matf64, the operations change the Matf64 on which Chain
+was called, so Copy should be called first to keep the original intact.
+*/
+type Chainf64 struct {
+	m   *Matf64
+	err error
+}
+
+/*
+Chain returns a Chainf64 over the receiver.
+*/
+func (m *Matf64) Chain() *Chainf64 {
+	return &Chainf64{m: m}
+}
+
+/*
+Result returns the Matf64 of a Chainf64, and the first error which occurred in
+the chain, if any. If there was an error, the Matf64 holds the result of the
+operations before it.
+*/
+func (ch *Chainf64) Result() (*Matf64, error) {
+	return ch.m, ch.err
+}
+
+/*
+Err returns the first error which occurred in a Chainf64, or nil.
+*/
+func (ch *Chainf64) Err() error {
+	return ch.err
+}
+
+/*
+Do applies the passed function to the Matf64 of a Chainf64, and continues the
+chain with the Matf64 it returns. This allows any operation, including user
+defined ones, to take part in a chain. If the function returns an error, the
+rest of the chain is skipped.
+*/
+func (ch *Chainf64) Do(f func(*Matf64) (*Matf64, error)) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	m, err := f(ch.m)
+	if err != nil {
+		ch.err = err
+		return ch
+	}
+	ch.m = m
+	return ch
+}
+
+func (ch *Chainf64) checkOperand(fn string, float64OrMatf64 interface{}) bool {
+	switch v := float64OrMatf64.(type) {
+	case float64:
+	case *Matf64:
+		if v.r != ch.m.r || v.c != ch.m.c {
+			ch.err = &ErrDimMismatch{fn, ch.m.r, ch.m.c, v.r, v.c}
+		}
+	default:
+		s := "In %s, the passed value must be a float64 or *Matf64.\n"
+		s += "However, value of type \"%v\" was received."
+		ch.err = fmt.Errorf(s, fn, reflect.TypeOf(v))
+	}
+	return ch.err == nil
+}
+
+/*
+Add does the same as the Add method of Matf64, as part of a chain.
+*/
+func (ch *Chainf64) Add(float64OrMatf64 interface{}) *Chainf64 {
+	if ch.err == nil && ch.checkOperand("Add()", float64OrMatf64) {
+		ch.m.Add(float64OrMatf64)
+	}
+	return ch
+}
+
+/*
+Sub does the same as the Sub method of Matf64, as part of a chain.
+*/
+func (ch *Chainf64) Sub(float64OrMatf64 interface{}) *Chainf64 {
+	if ch.err == nil && ch.checkOperand("Sub()", float64OrMatf64) {
+		ch.m.Sub(float64OrMatf64)
+	}
+	return ch
+}
+
+/*
+Mul does the same as the Mul method of Matf64, as part of a chain.
+*/
+func (ch *Chainf64) Mul(float64OrMatf64 interface{}) *Chainf64 {
+	if ch.err == nil && ch.checkOperand("Mul()", float64OrMatf64) {
+		ch.m.Mul(float64OrMatf64)
+	}
+	return ch
+}
+
+/*
+Div does the same as the Div method of Matf64, as part of a chain.
+*/
+func (ch *Chainf64) Div(float64OrMatf64 interface{}) *Chainf64 {
+	if ch.err == nil && ch.checkOperand("Div()", float64OrMatf64) {
+		ch.m.Div(float64OrMatf64)
+	}
+	return ch
+}
+
+/*
+Dot continues the chain with the matrix product of the current Matf64 and the
+passed Matf64, in the same manner as the Dot method of Matf64.
+*/
+func (ch *Chainf64) Dot(n *Matf64) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if ch.m.c != n.r {
+		ch.err = &ErrDimMismatch{"Dot()", ch.m.r, ch.m.c, n.r, n.c}
+		return ch
+	}
+	ch.m = ch.m.Dot(n)
+	return ch
+}
+
+/*
+T continues the chain with the transpose of the current Matf64.
+*/
+func (ch *Chainf64) T() *Chainf64 {
+	if ch.err == nil {
+		ch.m = ch.m.T()
+	}
+	return ch
+}
+
+/*
+Reshape does the same as the Reshape method of Matf64, as part of a chain.
+*/
+func (ch *Chainf64) Reshape(rows, cols int) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if rows < 0 || cols < 0 || rows*cols != ch.m.r*ch.m.c {
+		ch.err = &ErrDimMismatch{"Reshape()", ch.m.r, ch.m.c, rows, cols}
+		return ch
+	}
+	ch.m.Reshape(rows, cols)
+	return ch
+}
+
+/*
+Map does the same as the Map method of Matf64, as part of a chain.
+*/
+func (ch *Chainf64) Map(f func(*float64)) *Chainf64 {
+	if ch.err == nil {
+		ch.m.Map(f)
+	}
+	return ch
+}
+
+/*
+SetAll does the same as the SetAll method of Matf64, as part of a chain.
+*/
+func (ch *Chainf64) SetAll(val float64) *Chainf64 {
+	if ch.err == nil {
+		ch.m.SetAll(val)
+	}
+	return ch
+}
+
+/*
+Set does the same as the Set method of Matf64, as part of a chain, and checks
+that the row and column are within bounds.
+*/
+func (ch *Chainf64) Set(r, c int, val float64) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if r < 0 || r >= ch.m.r {
+		ch.err = &ErrIndexOOB{"Set()", 0, r, ch.m.r}
+		return ch
+	}
+	if c < 0 || c >= ch.m.c {
+		ch.err = &ErrIndexOOB{"Set()", 1, c, ch.m.c}
+		return ch
+	}
+	ch.m.Set(r, c, val)
+	return ch
+}
+
+/*
+Row continues the chain with a copy of a row of the current Matf64, in the
+same manner as the Row method of Matf64.
+*/
+func (ch *Chainf64) Row(x int) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if x >= ch.m.r || x < -ch.m.r {
+		ch.err = &ErrIndexOOB{"Row()", 0, x, ch.m.r}
+		return ch
+	}
+	ch.m = ch.m.Row(x)
+	return ch
+}
+
+/*
+Col continues the chain with a copy of a column of the current Matf64, in the
+same manner as the Col method of Matf64.
+*/
+func (ch *Chainf64) Col(x int) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if x >= ch.m.c || x < -ch.m.c {
+		ch.err = &ErrIndexOOB{"Col()", 1, x, ch.m.c}
+		return ch
+	}
+	ch.m = ch.m.Col(x)
+	return ch
+}
+
+/*
+AppendRow does the same as the AppendRow method of Matf64, as part of a
+chain.
+*/
+func (ch *Chainf64) AppendRow(v []float64) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if ch.m.c != len(v) {
+		ch.err = &ErrDimMismatch{"AppendRow()", ch.m.r, ch.m.c, 1, len(v)}
+		return ch
+	}
+	ch.m.AppendRow(v)
+	return ch
+}
+
+/*
+AppendCol does the same as the AppendCol method of Matf64, as part of a
+chain.
+*/
+func (ch *Chainf64) AppendCol(v []float64) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if ch.m.r != len(v) {
+		ch.err = &ErrDimMismatch{"AppendCol()", ch.m.r, ch.m.c, len(v), 1}
+		return ch
+	}
+	ch.m.AppendCol(v)
+	return ch
+}
+
+/*
+Concat does the same as the Concat method of Matf64, as part of a chain.
+*/
+func (ch *Chainf64) Concat(n *Matf64) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if ch.m.r != n.r {
+		ch.err = &ErrDimMismatch{"Concat()", ch.m.r, ch.m.c, n.r, n.c}
+		return ch
+	}
+	ch.m.Concat(n)
+	return ch
+}
+
+/*
+Solve continues the chain with the solution x of the system in which the
+current Matf64 is the matrix, as in the Solve method of Matf64. If the current
+Matf64 is singular, the error is an *ErrSingular.
+*/
+func (ch *Chainf64) Solve(b *Matf64) *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if ch.m.r != ch.m.c || b.r != ch.m.r {
+		ch.err = &ErrDimMismatch{"Solve()", ch.m.r, ch.m.c, b.r, b.c}
+		return ch
+	}
+	f, err := ch.m.lu("Solve()")
+	if err != nil {
+		ch.err = err
+		return ch
+	}
+	ch.m = f.solve(b)
+	return ch
+}
+
+/*
+Inv continues the chain with the inverse of the current Matf64, as in the Inv
+method of Matf64. If the current Matf64 is singular, the error is an
+*ErrSingular.
+*/
+func (ch *Chainf64) Inv() *Chainf64 {
+	if ch.err != nil {
+		return ch
+	}
+	if ch.m.r != ch.m.c {
+		ch.err = &ErrDimMismatch{"Inv()", ch.m.r, ch.m.c, ch.m.c, ch.m.r}
+		return ch
+	}
+	f, err := ch.m.lu("Inv()")
+	if err != nil {
+		ch.err = err
+		return ch
+	}
+	ch.m = f.solve(EyeSharedf64(ch.m.r))
+	return ch
+}
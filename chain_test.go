@@ -0,0 +1,96 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	n := Matf64FromData([]float64{1, 1, 1, 1}, 2, 2)
+	res, err := m.Copy().Chain().Add(1.0).Mul(n).Sub(n).Div(2.0).Dot(n).T().Result()
+	assert.Nil(t, err, "should not fail")
+	want := m.Copy().Add(1.0).Mul(n).Sub(n).Div(2.0).Dot(n).T()
+	assert.True(t, res.Equals(want), "should be equal")
+
+	res, err = m.Copy().Chain().Reshape(1, 4).AppendCol([]float64{5}).Col(-1).SetAll(2.0).Result()
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, []float64{2}, res.ToSlice1D(), "should be equal")
+
+	res, err = m.Copy().Chain().Row(1).Concat(Newf64(1, 1)).AppendRow([]float64{1, 2, 3}).Set(1, 2, 9.0).Result()
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, []float64{3, 4, 0, 1, 2, 9}, res.ToSlice1D(), "should be equal")
+}
+
+func TestChainf64Errors(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	calls := 0
+	res, err := m.Chain().Add(1.0).Dot(Newf64(3, 1)).Map(func(v *float64) { calls++ }).Result()
+	assert.Error(t, err, "should fail")
+	assert.Contains(t, err.Error(), "Dot()", "should name the failing operation")
+	assert.Equal(t, 0, calls, "should skip the rest of the chain")
+	assert.Equal(t, []float64{2, 3, 4, 5}, res.ToSlice1D(), "should keep the result before the error")
+
+	cases := []*Chainf64{
+		m.Copy().Chain().Add(Newf64(1, 2)),
+		m.Copy().Chain().Sub("1"),
+		m.Copy().Chain().Reshape(3, 1),
+		m.Copy().Chain().Set(2, 0, 1.0),
+		m.Copy().Chain().Row(2),
+		m.Copy().Chain().Col(-3),
+		m.Copy().Chain().AppendRow([]float64{1}),
+		m.Copy().Chain().AppendCol([]float64{1}),
+		m.Copy().Chain().Concat(Newf64(3, 1)),
+	}
+	for _, ch := range cases {
+		assert.Error(t, ch.Err(), "should fail")
+	}
+}
+
+func TestChainf64Do(t *testing.T) {
+	t.Helper()
+	errNeg := errors.New("negative")
+	check := func(m *Matf64) (*Matf64, error) {
+		if !m.All(func(v *float64) bool { return *v >= 0 }) {
+			return nil, errNeg
+		}
+		return m, nil
+	}
+	m := Matf64FromData([]float64{1, 2}, 1, 2)
+	_, err := m.Chain().Do(check).Sub(5.0).Do(check).Add(5.0).Result()
+	assert.Equal(t, errNeg, err, "should return the error of the function")
+	assert.Equal(t, []float64{-4, -3}, m.ToSlice1D(), "should skip the rest of the chain")
+}
+
+func TestChainf64TypedErrors(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	_, err := m.Copy().Chain().Dot(Newf64(3, 1)).Result()
+	var dim *ErrDimMismatch
+	assert.True(t, errors.As(err, &dim), "should be an ErrDimMismatch")
+	assert.Equal(t, ErrDimMismatch{"Dot()", 2, 2, 3, 1}, *dim, "should hold the shapes")
+	assert.True(t, errors.Is(err, &ErrDimMismatch{}), "should match any ErrDimMismatch")
+	assert.False(t, errors.Is(err, &ErrIndexOOB{}), "should not match ErrIndexOOB")
+
+	_, err = m.Copy().Chain().Col(-3).Result()
+	var oob *ErrIndexOOB
+	assert.True(t, errors.As(err, &oob), "should be an ErrIndexOOB")
+	assert.Equal(t, ErrIndexOOB{"Col()", 1, -3, 2}, *oob, "should hold the index")
+	assert.Equal(t, "In Col(), column -3 is outside of the bounds [-2, 2).", err.Error(), "should be equal")
+
+	_, err = Matf64FromData([]float64{1, 2, 2, 4}, 2, 2).Chain().Inv().Result()
+	assert.True(t, errors.Is(err, &ErrSingular{}), "should be singular")
+	_, err = Matf64FromData([]float64{1, 2, 2, 4}, 2, 2).Chain().Solve(Newf64(2, 1)).Result()
+	assert.True(t, errors.Is(err, &ErrSingular{}), "should be singular")
+
+	res, err := m.Copy().Chain().Inv().Result()
+	assert.NoError(t, err, "should not fail")
+	assertMatInDelta(t, m.Inv(), res, 1e-12)
+	res, err = m.Copy().Chain().Solve(Matf64FromData([]float64{5, 11}, 2, 1)).Result()
+	assert.NoError(t, err, "should not fail")
+	assertMatInDelta(t, Matf64FromData([]float64{1, 2}, 2, 1), res, 1e-12)
+}
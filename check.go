@@ -0,0 +1,23 @@
+//go:build !matnocheck
+// +build !matnocheck
+
+package matrix
+
+/*
+checks is whether the hot methods of a Matf64, which are Mul, Add, Sub and
+Div of two Matf64, Dot and DotThreads, and Col and Row, validate their
+arguments. It is true, unless the package is built with the matnocheck tag:
+
+	go build -tags matnocheck ./...
+
+in which case these checks are removed by the compiler, as a constant false
+condition is dead code. This is meant for programs which validate the shapes
+of their data once, at the start of a pipeline, and need the most throughput
+from the inner loops which follow. Invalid arguments are then not reported by
+the package, and either panic with a runtime error of Go, such as an index
+out of range, or silently give a wrong result. The tests which expect the
+checks are built only without the tag, so that the rest can be run with it:
+
+	go test -tags matnocheck ./...
+*/
+const checks = true
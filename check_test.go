@@ -0,0 +1,21 @@
+//go:build !matnocheck
+// +build !matnocheck
+
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksf64(t *testing.T) {
+	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
+	assert.True(t, checks, "should be enabled without the matnocheck tag")
+	m := Newf64(2, 3)
+	assert.Panics(t, func() { m.Add(Newf64(3, 2)) }, "should panic")
+	assert.Panics(t, func() { m.Dot(Newf64(2, 3)) }, "should panic")
+	assert.Panics(t, func() { m.Col(3) }, "should panic")
+	assert.Panics(t, func() { m.Row(-3) }, "should panic")
+}
@@ -0,0 +1,112 @@
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+ColMeta is the metadata of a column of a Labeledf64: the unit of its
+quantities, such as "m/s", and the scale of its elements, which is the
+quantity in the unit that an element of 1 stands for. For example, a column
+of currents stored in milliamperes has the unit "A" and the scale 0.001.
+A scale of 0 is taken to be 1, so the zero ColMeta means no unit, and no
+scale.
+*/
+type ColMeta struct {
+	Unit  string
+	Scale float64
+}
+
+// scale returns the scale of a ColMeta, with 0 taken to be 1.
+func (c ColMeta) scale() float64 {
+	if c.Scale == 0 {
+		return 1.0
+	}
+	return c.Scale
+}
+
+/*
+SetColMeta sets the metadata of a column of a Labeledf64, which can be given
+by its name or its index:
+
+	l.SetColMeta("current", matrix.ColMeta{Unit: "A", Scale: 0.001})
+
+The metadata follows the column through Row, Col, Slice and Concat, and is
+written to the header by ToCSV.
+*/
+func (l *Labeledf64) SetColMeta(intOrString interface{}, meta ColMeta) *Labeledf64 {
+	j := resolveLabel("SetColMeta()", "column", intOrString, l.colIdx, l.m.c)
+	if l.colMeta == nil {
+		l.colMeta = make([]ColMeta, l.m.c)
+	}
+	l.colMeta[j] = meta
+	return l
+}
+
+/*
+ColMeta returns the metadata of a column of a Labeledf64, which can be given
+by its name or its index. It is the zero ColMeta if none was set.
+*/
+func (l *Labeledf64) ColMeta(intOrString interface{}) ColMeta {
+	j := resolveLabel("ColMeta()", "column", intOrString, l.colIdx, l.m.c)
+	if l.colMeta == nil {
+		return ColMeta{}
+	}
+	return l.colMeta[j]
+}
+
+/*
+ColInUnit returns a copy of a column of a Labeledf64, which can be given by
+its name or its index, as a column vector of quantities in the unit of the
+column, that is, multiplied by its scale.
+*/
+func (l *Labeledf64) ColInUnit(intOrString interface{}) *Matf64 {
+	j := resolveLabel("ColInUnit()", "column", intOrString, l.colIdx, l.m.c)
+	return l.m.Col(j).Scale(l.ColMeta(j).scale())
+}
+
+// headerName returns the entry of the header of a CSV file for the column j,
+// which is its name, followed by its unit and scale in brackets, if set, as
+// in "current [0.001 A]".
+func (l *Labeledf64) headerName(j int) string {
+	name := l.colNames[j]
+	if l.colMeta == nil || l.colMeta[j] == (ColMeta{}) {
+		return name
+	}
+	meta := l.colMeta[j]
+	if meta.scale() == 1 {
+		return name + " [" + meta.Unit + "]"
+	}
+	inner := strconv.FormatFloat(meta.Scale, 'g', -1, 64)
+	if meta.Unit != "" {
+		inner += " " + meta.Unit
+	}
+	return name + " [" + inner + "]"
+}
+
+// parseHeaderName splits an entry of the header of a CSV file, as written by
+// headerName, into the name of the column and its metadata. An entry which
+// does not end with a unit in brackets is a name without metadata.
+func parseHeaderName(fn, s string) (string, ColMeta) {
+	open := strings.LastIndex(s, " [")
+	if open < 0 || !strings.HasSuffix(s, "]") {
+		return s, ColMeta{}
+	}
+	name, inner := s[:open], s[open+2:len(s)-1]
+	meta := ColMeta{Unit: inner}
+	if i := strings.IndexByte(inner, ' '); i >= 0 {
+		if scale, err := strconv.ParseFloat(inner[:i], 64); err == nil {
+			if scale == 0 {
+				s := "\nIn matrix.%s, the scale of the column \"%s\" must not be 0."
+				s = fmt.Sprintf(s, fn, name)
+				printHelperErr(ErrArgument, s)
+			}
+			meta = ColMeta{Unit: inner[i+1:], Scale: scale}
+		}
+	} else if scale, err := strconv.ParseFloat(inner, 64); err == nil {
+		meta = ColMeta{Scale: scale}
+	}
+	return name, meta
+}
@@ -0,0 +1,49 @@
+package matrix
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColMetaf64(t *testing.T) {
+	t.Helper()
+	l := NewLabeledf64(Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}}), []string{"t", "i", "n"})
+	assert.Equal(t, ColMeta{}, l.ColMeta("t"), "should be the zero ColMeta")
+	l.SetColMeta("t", ColMeta{Unit: "s"})
+	l.SetColMeta(1, ColMeta{Unit: "A", Scale: 0.001})
+	assert.Equal(t, ColMeta{Unit: "A", Scale: 0.001}, l.ColMeta("i"), "should be equal")
+	assert.Equal(t, []float64{0.002, 0.005}, l.ColInUnit("i").vals, "should be equal")
+	assert.Equal(t, []float64{1, 4}, l.ColInUnit("t").vals, "should be equal")
+
+	s := l.Slice(0, 1, 1, 3)
+	assert.Equal(t, ColMeta{Unit: "A", Scale: 0.001}, s.ColMeta("i"), "should follow the column")
+	assert.Equal(t, ColMeta{}, s.ColMeta("n"), "should be equal")
+	assert.Equal(t, ColMeta{Unit: "s"}, l.Col("t").ColMeta(0), "should follow the column")
+
+	other := NewLabeledf64(Matf64FromData([][]float64{{7}, {8}}), []string{"v"})
+	other.SetColMeta("v", ColMeta{Unit: "V"})
+	c := NewLabeledf64(Matf64FromData([][]float64{{0}, {0}}), []string{"z"})
+	c.Concat(other)
+	assert.Equal(t, ColMeta{}, c.ColMeta("z"), "should be equal")
+	assert.Equal(t, ColMeta{Unit: "V"}, c.ColMeta("v"), "should be carried by Concat")
+
+	filename := "colmeta_test.csv"
+	l.SetColMeta("n", ColMeta{Scale: 1000})
+	l.ToCSV(filename)
+	defer func() {
+		if err := os.Remove(filename); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	b, err := os.ReadFile(filename)
+	assert.NoError(t, err, "should not fail")
+	assert.Contains(t, string(b), "t [s],i [0.001 A],n [1000]\n", "should write the units")
+	r := Matf64FromCSVWithHeader(filename)
+	assert.Equal(t, []string{"t", "i", "n"}, r.ColNames(), "should be equal")
+	for _, name := range r.ColNames() {
+		assert.Equal(t, l.ColMeta(name), r.ColMeta(name), "should survive the round trip")
+	}
+}
@@ -0,0 +1,151 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+COOf64 is a builder for sparse matrices, which stores its elements as a list
+of (row, column, value) coordinates. Unlike a Sparsef64, elements can be
+inserted efficiently in any order, which makes a COOf64 the natural way to
+assemble a sparse matrix, for example from the stencils of a finite element
+mesh. Once assembled, it can be converted to a Sparsef64 for computation:
+
+	b := matrix.NewCOOf64(n, n)
+	for i := 0; i < n; i++ {
+		b.Add(i, i, 2.0)
+		if i > 0 {
+			b.Add(i, i-1, -1.0)
+			b.Add(i-1, i, -1.0)
+		}
+	}
+	s := b.ToCSR()
+*/
+type COOf64 struct {
+	r, c       int
+	rows, cols []int
+	vals       []float64
+	// pos maps row*c+col to the index of that element in rows, cols and vals.
+	pos map[int]int
+}
+
+/*
+NewCOOf64 creates an empty r by c COOf64, in which all elements are zero.
+*/
+func NewCOOf64(r, c int) *COOf64 {
+	if r < 0 || c < 0 {
+		s := "\nIn matrix.%s, the number of rows and columns cannot be\n"
+		s += "negative, however %d and %d were received."
+		s = fmt.Sprintf(s, "NewCOOf64()", r, c)
+		printErr(ErrArgument, s)
+	}
+	return &COOf64{r: r, c: c, pos: make(map[int]int)}
+}
+
+func (b *COOf64) checkBounds(fn string, r, c int) {
+	if r < 0 || r >= b.r || c < 0 || c >= b.c {
+		s := "\nIn %s, row %d and column %d are outside of the bounds of\n"
+		s += "a %d by %d matrix."
+		s = fmt.Sprintf(s, fn, r, c, b.r, b.c)
+		printHelperErr(ErrBounds, s)
+	}
+}
+
+/*
+Set sets the element at the given row and column of a COOf64 to the passed
+value, replacing any previous value.
+*/
+func (b *COOf64) Set(r, c int, val float64) *COOf64 {
+	b.checkBounds("Set()", r, c)
+	if k, ok := b.pos[r*b.c+c]; ok {
+		b.vals[k] = val
+		return b
+	}
+	b.pos[r*b.c+c] = len(b.vals)
+	b.rows = append(b.rows, r)
+	b.cols = append(b.cols, c)
+	b.vals = append(b.vals, val)
+	return b
+}
+
+/*
+Add adds the passed value to the element at the given row and column of a
+COOf64. This is how contributions from several sources to the same element,
+such as neighboring finite elements, are accumulated.
+*/
+func (b *COOf64) Add(r, c int, val float64) *COOf64 {
+	b.checkBounds("Add()", r, c)
+	if k, ok := b.pos[r*b.c+c]; ok {
+		b.vals[k] += val
+		return b
+	}
+	return b.Set(r, c, val)
+}
+
+/*
+Get returns the element at the given row and column of a COOf64.
+*/
+func (b *COOf64) Get(r, c int) float64 {
+	b.checkBounds("Get()", r, c)
+	if k, ok := b.pos[r*b.c+c]; ok {
+		return b.vals[k]
+	}
+	return 0.0
+}
+
+/*
+Shape returns the number of rows and columns of a COOf64.
+*/
+func (b *COOf64) Shape() (int, int) {
+	return b.r, b.c
+}
+
+/*
+NNZ returns the number of elements which have been set in a COOf64. Note that
+this includes elements which were explicitly set to 0.0.
+*/
+func (b *COOf64) NNZ() int {
+	return len(b.vals)
+}
+
+/*
+ToCSR returns a Sparsef64 in the CSR format with the elements of the COOf64.
+Elements which are 0.0 are not stored. The COOf64 is left intact, and can
+continue to be used.
+*/
+func (b *COOf64) ToCSR() *Sparsef64 {
+	rows, cols, vals := b.nonZero()
+	return newSparsef64(b.r, b.c, CSR, rows, cols, vals)
+}
+
+/*
+ToCSC returns a Sparsef64 in the CSC format with the elements of the COOf64.
+Elements which are 0.0 are not stored. The COOf64 is left intact, and can
+continue to be used.
+*/
+func (b *COOf64) ToCSC() *Sparsef64 {
+	rows, cols, vals := b.nonZero()
+	return newSparsef64(b.r, b.c, CSC, cols, rows, vals)
+}
+
+/*
+ToMatf64 returns a dense Matf64 with the elements of the COOf64.
+*/
+func (b *COOf64) ToMatf64() *Matf64 {
+	m := Newf64(b.r, b.c)
+	for k := range b.vals {
+		m.vals[b.rows[k]*m.c+b.cols[k]] = b.vals[k]
+	}
+	return m
+}
+
+func (b *COOf64) nonZero() (rows, cols []int, vals []float64) {
+	for k := range b.vals {
+		if b.vals[k] != 0.0 {
+			rows = append(rows, b.rows[k])
+			cols = append(cols, b.cols[k])
+			vals = append(vals, b.vals[k])
+		}
+	}
+	return rows, cols, vals
+}
@@ -0,0 +1,62 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCOOf64(t *testing.T) {
+	t.Helper()
+	b := NewCOOf64(3, 4)
+	r, c := b.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+	assert.Equal(t, 0, b.NNZ(), "should be empty")
+	assert.True(t, b.ToMatf64().Equals(Newf64(3, 4)), "should be all zeros")
+}
+
+func TestCOOSetf64(t *testing.T) {
+	t.Helper()
+	b := NewCOOf64(2, 2)
+	b.Set(0, 1, 3.0).Set(1, 0, 4.0).Set(0, 1, 5.0)
+	assert.Equal(t, 2, b.NNZ(), "should be equal")
+	assert.Equal(t, 5.0, b.Get(0, 1), "later values should replace earlier")
+	assert.Equal(t, 4.0, b.Get(1, 0), "should be equal")
+	assert.Equal(t, 0.0, b.Get(1, 1), "should be zero")
+}
+
+func TestCOOAddf64(t *testing.T) {
+	t.Helper()
+	n := 5
+	b := NewCOOf64(n, n)
+	for i := 0; i < n; i++ {
+		b.Add(i, i, 2.0)
+		if i > 0 {
+			b.Add(i, i-1, -1.0)
+			b.Add(i-1, i, -1.0)
+		}
+	}
+	b.Add(2, 2, 1.0)
+	assert.Equal(t, 3.0, b.Get(2, 2), "should accumulate")
+	assert.Equal(t, -1.0, b.Get(3, 2), "should be equal")
+	assert.Equal(t, 13, b.NNZ(), "should be equal")
+}
+
+func TestCOOConversionsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		0, 1, 0,
+		2, 0, 3,
+	}, 2, 3)
+	b := NewCOOf64(2, 3)
+	b.Set(1, 2, 3.0).Set(0, 1, 1.0).Set(1, 0, 2.0).Set(0, 0, 0.0)
+	assert.True(t, b.ToMatf64().Equals(m), "should be equal")
+	s := b.ToCSR()
+	assert.Equal(t, CSR, s.Format(), "should be equal")
+	assert.Equal(t, 3, s.NNZ(), "explicit zeros should not be stored")
+	assert.True(t, s.ToMatf64().Equals(m), "should be equal")
+	s = b.ToCSC()
+	assert.Equal(t, CSC, s.Format(), "should be equal")
+	assert.True(t, s.ToMatf64().Equals(m), "should be equal")
+}
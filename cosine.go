@@ -0,0 +1,51 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+CosineSimilarityf64 returns the cosine similarity between each row of a and
+each row of b, which must have the same number of columns. Element (i, j) of
+the returned Matf64 is the dot product of row i of a and row j of b, divided
+by the product of their norms:
+
+	sim := matrix.CosineSimilarityf64(queries, embeddings)
+	best := sim.ArgsortRow(0) // ascending, so the most similar is last
+
+The norms are computed once per row, and neither a nor b is changed. If
+either row is all zeros, the similarity is 0.
+*/
+func CosineSimilarityf64(a, b *Matf64) *Matf64 {
+	if a.c != b.c {
+		s := "\nIn matrix.%s, the first Matf64 has %d columns, while the\n"
+		s += "second has %d. They must be equal."
+		s = fmt.Sprintf(s, "CosineSimilarityf64()", a.c, b.c)
+		printErr(ErrShape, s)
+	}
+	na, nb := rowNorms(a), rowNorms(b)
+	o := DotTf64(a, b, false, true)
+	for i := 0; i < o.r; i++ {
+		for j := 0; j < o.c; j++ {
+			if d := na[i] * nb[j]; d != 0.0 {
+				o.vals[i*o.c+j] /= d
+			} else {
+				o.vals[i*o.c+j] = 0.0
+			}
+		}
+	}
+	return o
+}
+
+func rowNorms(m *Matf64) []float64 {
+	norms := make([]float64, m.r)
+	for i := range norms {
+		sum := 0.0
+		for _, v := range m.vals[i*m.c : (i+1)*m.c] {
+			sum += v * v
+		}
+		norms[i] = math.Sqrt(sum)
+	}
+	return norms
+}
@@ -0,0 +1,29 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCosineSimilarityf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{
+		1, 0,
+		1, 1,
+		0, 0,
+	}, 3, 2)
+	b := Matf64FromData([]float64{
+		2, 0,
+		0, 3,
+		-1, -1,
+	}, 3, 2)
+	want := Matf64FromData([]float64{
+		1, 0, -1 / math.Sqrt2,
+		1 / math.Sqrt2, 1 / math.Sqrt2, -1,
+		0, 0, 0,
+	}, 3, 3)
+	assertMatInDelta(t, want, CosineSimilarityf64(a, b), 1e-15)
+	assert.Equal(t, []float64{1, 0, 1, 1, 0, 0}, a.ToSlice1D(), "should not change a")
+}
@@ -0,0 +1,125 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+/*
+CSVChunkIterf64 reads a CSV file a fixed number of rows at a time. It is
+created by CSVChunksf64. See the documentation of that function for details.
+*/
+type CSVChunkIterf64 struct {
+	filename     string
+	f            *os.File
+	r            *csv.Reader
+	rowsPerChunk int
+	line         int
+	chunk        *Matf64
+	done         bool
+}
+
+/*
+CSVChunksf64 returns an iterator over the rows of a CSV file, which yields
+the rows of the file as Matf64 objects of at most rowsPerChunk rows each. This
+allows files which are too large to fit in memory to be processed one chunk
+at a time, unlike Matf64FromCSV which reads the whole file at once:
+
+	it := matrix.CSVChunksf64("huge.csv", 10000)
+	defer it.Close()
+	for it.Next() {
+		m := it.Chunk()
+		// use m...
+	}
+
+Every chunk holds exactly rowsPerChunk rows, except for the last one, which
+holds the remaining rows of the file. As with Matf64FromCSV, every line of the
+file must have the same number of entries. Note that rowsPerChunk must be
+greater than zero.
+*/
+func CSVChunksf64(filename string, rowsPerChunk int) *CSVChunkIterf64 {
+	if rowsPerChunk <= 0 {
+		s := "\nIn matrix.%s, the number of rows per chunk must be greater\n"
+		s += "than zero, however %d was received."
+		s = fmt.Sprintf(s, "CSVChunksf64()", rowsPerChunk)
+		printErr(ErrArgument, s)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "CSVChunksf64()", filename, err)
+		printErr(ErrIO, s)
+	}
+	return &CSVChunkIterf64{
+		filename:     filename,
+		f:            f,
+		r:            csv.NewReader(f),
+		rowsPerChunk: rowsPerChunk,
+	}
+}
+
+/*
+Next reads the next chunk of the file, which is then available through the
+Chunk method. It returns false when there are no more rows to read, at which
+point the file is closed.
+*/
+func (it *CSVChunkIterf64) Next() bool {
+	if it.done {
+		return false
+	}
+	m := Newf64()
+	for m.r < it.rowsPerChunk {
+		str, err := it.r.Read()
+		if err != nil {
+			if err == io.EOF {
+				it.Close()
+				break
+			}
+			s := "\nIn matrix.%s, cannot read from %s due to error: %v.\n"
+			s = fmt.Sprintf(s, "CSVChunkIterf64.Next()", it.filename, err)
+			printErr(ErrIO, s)
+		}
+		m.c = len(str)
+		for i := range str {
+			v, err := strconv.ParseFloat(str[i], 64)
+			if err != nil {
+				s := "\nIn matrix.%s, item %d in line %d is %s, which cannot\n"
+				s += "be converted to a float64 due to: %v"
+				s = fmt.Sprintf(s, "CSVChunkIterf64.Next()", i, it.line, str[i], err)
+				printErr(ErrParse, s)
+			}
+			m.vals = append(m.vals, v)
+		}
+		m.r++
+		it.line++
+	}
+	if m.r == 0 {
+		it.chunk = nil
+		return false
+	}
+	it.chunk = m
+	return true
+}
+
+/*
+Chunk returns the chunk read by the last call to Next. Each chunk is a new
+Matf64, and so it can be safely kept after Next is called again.
+*/
+func (it *CSVChunkIterf64) Chunk() *Matf64 {
+	return it.chunk
+}
+
+/*
+Close closes the underlying file. It is safe to call Close more than once, and
+it is not necessary to call it after Next has returned false.
+*/
+func (it *CSVChunkIterf64) Close() {
+	if it.done {
+		return
+	}
+	it.done = true
+	it.f.Close()
+}
@@ -0,0 +1,44 @@
+package matrix
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVChunksf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(7, 3)
+	for i := range m.vals {
+		m.vals[i] = float64(i)
+	}
+	filename := "chunks_test.csv"
+	m.ToCSV(filename)
+	defer func() {
+		if err := os.Remove(filename); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	it := CSVChunksf64(filename, 3)
+	defer it.Close()
+	var rows []int
+	var vals []float64
+	for it.Next() {
+		c := it.Chunk()
+		assert.Equal(t, 3, c.c, "should be equal")
+		rows = append(rows, c.r)
+		vals = append(vals, c.ToSlice1D()...)
+	}
+	assert.Equal(t, []int{3, 3, 1}, rows, "last chunk should hold the rest")
+	assert.True(t, Matf64FromData(vals, 7, 3).Equals(m), "chunks should make up the whole file")
+	assert.False(t, it.Next(), "should stay exhausted")
+
+	it = CSVChunksf64(filename, 7)
+	assert.True(t, it.Next(), "should read one chunk")
+	assert.True(t, it.Chunk().Equals(m), "should be equal")
+	assert.False(t, it.Next(), "should be exhausted")
+	assert.Nil(t, it.Chunk(), "should be nil after the last chunk")
+}
@@ -0,0 +1,67 @@
+package matrix
+
+/*
+DeferErrors puts the receiver in deferred error mode, and returns it. In this
+mode, an operation which fails does not exit the program. Instead, the error
+is kept in the Matf64, and every operation which follows it on the same
+chain does nothing, in the same manner as the sticky error of a
+bufio.Writer. The error is returned by Err at the end of the chain:
+
+	o := m.DeferErrors().Add(n).Dot(p).T()
+	if err := o.Err(); err != nil {
+		// for example, the shapes of m and p do not fit together
+	}
+
+The mode is passed on to the Matf64 returned by an operation, such as Dot or
+T, so it holds for the whole chain. After a failure, the chain continues with
+the Matf64 on which the failing operation was called, which may have been
+partially changed by it. The mode applies to all the methods of Matf64 which
+return a Matf64, such as Add, Dot, Reshape, T, Hypot, Inv or Solve. Those
+which also return other values, such as SolveWithStats, return the receiver
+in place of each Matf64 after a failure, and the zero value for the others.
+The error is an *Error, as for Catch.
+*/
+func (m *Matf64) DeferErrors() *Matf64 {
+	m.checkWritable("DeferErrors()")
+	m.deferErr = true
+	return m
+}
+
+/*
+Err returns the first error of an operation on a Matf64 in deferred error
+mode, or nil if there was none. See DeferErrors.
+*/
+func (m *Matf64) Err() error {
+	return m.err
+}
+
+// deferred runs op, which is an operation on m, with m out of deferred error
+// mode, and returns its result in deferred error mode. If m already has an
+// error, op is skipped, and if op fails, its error is kept in m, and m is
+// returned.
+func (m *Matf64) deferred(op func() *Matf64) *Matf64 {
+	var o *Matf64
+	if !m.deferredDo(func() { o = op() }) {
+		return m
+	}
+	o.deferErr = true
+	return o
+}
+
+// deferredDo is deferred for the methods which return more than a Matf64. It
+// returns false if op was skipped or failed, in which case the method returns
+// m in place of each of its Matf64 results, so that the chain can continue
+// with any of them. Otherwise the method puts its Matf64 results in deferred
+// error mode.
+func (m *Matf64) deferredDo(op func()) bool {
+	if m.err != nil {
+		return false
+	}
+	m.deferErr = false
+	defer func() { m.deferErr = true }()
+	if err := Catch(op); err != nil {
+		m.err = err
+		return false
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+//go:build !matnocheck
+// +build !matnocheck
+
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeferErrorsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	o := m.Copy().DeferErrors().Add(1.0).Dot(Newf64(2, 3).SetAll(1)).T()
+	assert.NoError(t, o.Err(), "should not fail")
+	r, c := o.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	assert.Equal(t, []float64{5, 9, 5, 9, 5, 9}, o.vals, "should be equal")
+
+	n := m.Copy().DeferErrors()
+	o = n.Add(1.0).Dot(Newf64(3, 3)).Add(100.0).T()
+	assert.True(t, o == n, "should continue with the receiver of the failure")
+	assert.Equal(t, []float64{2, 3, 4, 5}, o.vals, "should skip the rest of the chain")
+	var e *Error
+	assert.True(t, errors.As(o.Err(), &e), "should be an *Error")
+	assert.Equal(t, "Dot()", e.Op, "should be equal")
+	assert.True(t, errors.Is(o.Err(), ErrShape), "should be a shape error")
+
+	o = m.Copy().DeferErrors().Reshape(3, 3).Scale(2)
+	assert.Error(t, o.Err(), "should fail")
+	assert.Equal(t, []float64{1, 2, 3, 4}, o.vals, "should be unchanged")
+
+	assert.NoError(t, m.Err(), "should not be in deferred error mode")
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { m.Dot(Newf64(3, 3)) }, "should panic")
+}
+
+func TestDeferErrorsOtherMethodsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{3, 4}, {5, 12}})
+	bad := Newf64(3, 3)
+
+	// Hypot would exit the program if it ran after the failure of Add.
+	o := m.Copy().DeferErrors().Add(bad).Hypot(bad).Inv().Diag()
+	assert.True(t, errors.Is(o.Err(), ErrShape), "should keep the first error")
+	var e *Error
+	assert.True(t, errors.As(o.Err(), &e), "should be an *Error")
+	assert.Equal(t, "Add()", e.Op, "should be equal")
+	assert.Equal(t, []float64{3, 4, 5, 12}, o.vals, "should skip the rest of the chain")
+
+	o = m.Copy().DeferErrors().Hypot(m).Diag()
+	assert.NoError(t, o.Err(), "should not fail")
+	assert.True(t, o.deferErr, "should pass the mode on")
+	assert.InDelta(t, 3*math.Sqrt2, o.vals[0], 1e-12, "should be equal")
+	assert.InDelta(t, 12*math.Sqrt2, o.vals[1], 1e-12, "should be equal")
+
+	o = m.Copy().DeferErrors().Solve(bad).Scale(2)
+	assert.True(t, errors.Is(o.Err(), ErrShape), "should fail")
+	assert.Equal(t, []float64{3, 4, 5, 12}, o.vals, "should be unchanged")
+
+	n := m.Copy().DeferErrors()
+	x, stats := n.SolveWithStats(bad)
+	assert.True(t, x == n, "should continue with the receiver")
+	assert.Equal(t, SolveStats{}, stats, "should be the zero value")
+	assert.Error(t, x.Err(), "should fail")
+	vals, vecs := n.TopEigen(1)
+	assert.True(t, vals == n && vecs == n, "should skip after a failure")
+
+	x, _ = m.Copy().DeferErrors().SolveWithStats(Matf64FromData([]float64{1, 2}, 2, 1))
+	assert.NoError(t, x.Err(), "should not fail")
+	assert.True(t, x.deferErr, "should pass the mode on")
+}
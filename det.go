@@ -0,0 +1,140 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Det returns the determinant of a Matf64, which must be square. Matrices of up
+to 4 by 4 use closed form expressions, and larger ones an LU decomposition
+with partial pivoting of a copy of the receiver.
+*/
+func (m *Matf64) Det() float64 {
+	if m.r != m.c {
+		s := "\nIn %s, the receiver is %d by %d. It must be square."
+		s = fmt.Sprintf(s, "Det()", m.r, m.c)
+		printErr(&ErrDimMismatch{"Det()", m.r, m.c, m.c, m.r}, s)
+	}
+	switch {
+	case m.r == 0:
+		return 1.0
+	case m.r == 1:
+		return m.vals[0]
+	case m.tinySize():
+		return detTiny(m.r, m.vals)
+	}
+	a := m.Copy()
+	n := a.r
+	det := 1.0
+	for k := 0; k < n; k++ {
+		p := pivotRow(a, k)
+		if a.vals[p*n+k] == 0.0 {
+			return 0.0
+		}
+		if p != k {
+			swapRows(a, p, k)
+			det = -det
+		}
+		pivot := a.vals[k*n+k]
+		det *= pivot
+		for i := k + 1; i < n; i++ {
+			f := a.vals[i*n+k] / pivot
+			for j := k + 1; j < n; j++ {
+				a.vals[i*n+j] -= f * a.vals[k*n+j]
+			}
+		}
+	}
+	return det
+}
+
+/*
+Inv returns the inverse of a Matf64, which must be square and not singular,
+as a new Matf64. Matrices of up to 4 by 4 use closed form expressions, and
+larger ones Gauss-Jordan elimination with partial pivoting.
+*/
+func (m *Matf64) Inv() *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Inv() })
+	}
+	if m.r != m.c {
+		s := "\nIn %s, the receiver is %d by %d. It must be square."
+		s = fmt.Sprintf(s, "Inv()", m.r, m.c)
+		printErr(&ErrDimMismatch{"Inv()", m.r, m.c, m.c, m.r}, s)
+	}
+	n := m.r
+	o := Newf64(n)
+	if m.tinySize() {
+		if invTiny(n, o.vals, m.vals) == 0.0 {
+			invSingularErr(m.singularRow())
+		}
+		return o
+	}
+	a := m.Copy()
+	for i := 0; i < n; i++ {
+		o.vals[i*n+i] = 1.0
+	}
+	for k := 0; k < n; k++ {
+		p := pivotRow(a, k)
+		if a.vals[p*n+k] == 0.0 {
+			invSingularErr(k)
+		}
+		swapRows(a, p, k)
+		swapRows(o, p, k)
+		pivot := a.vals[k*n+k]
+		for j := 0; j < n; j++ {
+			a.vals[k*n+j] /= pivot
+			o.vals[k*n+j] /= pivot
+		}
+		for i := 0; i < n; i++ {
+			f := a.vals[i*n+k]
+			if i == k || f == 0.0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				a.vals[i*n+j] -= f * a.vals[k*n+j]
+				o.vals[i*n+j] -= f * o.vals[k*n+j]
+			}
+		}
+	}
+	return o
+}
+
+// pivotRow returns the row at or below row k of the square a with the
+// largest element in column k, in absolute value.
+func pivotRow(a *Matf64, k int) int {
+	p := k
+	for i := k + 1; i < a.r; i++ {
+		if math.Abs(a.vals[i*a.c+k]) > math.Abs(a.vals[p*a.c+k]) {
+			p = i
+		}
+	}
+	return p
+}
+
+func swapRows(a *Matf64, i, j int) {
+	if i == j {
+		return
+	}
+	ri, rj := a.vals[i*a.c:(i+1)*a.c], a.vals[j*a.c:(j+1)*a.c]
+	for k := range ri {
+		ri[k], rj[k] = rj[k], ri[k]
+	}
+}
+
+func invSingularErr(row int) {
+	s := "\nIn %s, the receiver is singular, and has no inverse."
+	s = fmt.Sprintf(s, "Inv()")
+	printHelperErr(&ErrSingular{"Inv()", row}, s)
+}
+
+// singularRow returns the row at which the elimination of the square m
+// finds a zero pivot, for the matrices whose inverse is computed in closed
+// form. It is the last row if the elimination does not find one, due to
+// rounding.
+func (m *Matf64) singularRow() int {
+	if _, err := m.lu("Inv()"); err != nil {
+		return err.(*ErrSingular).Row
+	}
+	return m.r - 1
+}
@@ -0,0 +1,101 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// padOne returns m with an extra row and column of an identity matrix, which
+// has the same determinant, but is too large for the closed form kernels.
+func padOne(m *Matf64) *Matf64 {
+	n := m.r + 1
+	o := Newf64(n)
+	for i := 0; i < m.r; i++ {
+		copy(o.vals[i*n:i*n+m.c], m.vals[i*m.c:(i+1)*m.c])
+	}
+	o.vals[n*n-1] = 1.0
+	return o
+}
+
+func identityf64(n int) *Matf64 {
+	o := Newf64(n)
+	for i := 0; i < n; i++ {
+		o.vals[i*n+i] = 1.0
+	}
+	return o
+}
+
+func tinyTestMats() []*Matf64 {
+	return []*Matf64{
+		Matf64FromData([]float64{
+			4, 7,
+			2, 6,
+		}, 2, 2),
+		Matf64FromData([]float64{
+			2, -1, 0,
+			1, 3, 2,
+			0, 5, -4,
+		}, 3, 3),
+		Matf64FromData([]float64{
+			1, 2, 0, 3,
+			0, 4, 1, 0,
+			2, 0, 5, 1,
+			1, 1, 0, 2,
+		}, 4, 4),
+	}
+}
+
+func TestDetf64(t *testing.T) {
+	t.Helper()
+	assert.InDelta(t, 10.0, tinyTestMats()[0].Det(), 1e-12, "should be equal")
+	assert.InDelta(t, -48.0, tinyTestMats()[1].Det(), 1e-12, "should be equal")
+	for _, m := range tinyTestMats() {
+		assert.InDelta(t, padOne(m).Det(), m.Det(), 1e-9, "should match the LU path")
+	}
+	s := Matf64FromData([]float64{
+		1, 2, 3, 4, 5,
+		2, 4, 6, 8, 10,
+		0, 1, 0, 1, 0,
+		3, 0, 1, 0, 2,
+		1, 1, 1, 1, 1,
+	}, 5, 5)
+	assert.Equal(t, 0.0, s.Det(), "should be singular")
+	assert.Equal(t, 7.0, Matf64FromData([]float64{7}, 1, 1).Det(), "should be equal")
+}
+
+func TestInvf64(t *testing.T) {
+	t.Helper()
+	for _, m := range tinyTestMats() {
+		inv := m.Inv()
+		assertMatInDelta(t, identityf64(m.r), inv.Dot(m), 1e-12)
+		big := padOne(m).Inv()
+		for i := 0; i < m.r; i++ {
+			for j := 0; j < m.c; j++ {
+				assert.InDelta(t, big.Get(i, j), inv.Get(i, j), 1e-12, "should match Gauss-Jordan")
+			}
+		}
+	}
+	m := Matf64FromData([]float64{
+		0, 1, 2, 0, 1,
+		1, 0, 0, 3, 0,
+		2, 1, 4, 0, 1,
+		0, 2, 1, 1, 0,
+		1, 0, 0, 0, 2,
+	}, 5, 5)
+	assertMatInDelta(t, identityf64(5), m.Dot(m.Inv()), 1e-12)
+}
+
+func TestDotTinyf64(t *testing.T) {
+	t.Helper()
+	for _, m := range tinyTestMats() {
+		n := m.Copy().T()
+		want := padOne(m).Dot(padOne(n))
+		got := m.Dot(n)
+		for i := 0; i < m.r; i++ {
+			for j := 0; j < m.c; j++ {
+				assert.Equal(t, want.Get(i, j), got.Get(i, j), "should be equal")
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package matrix
+
+import "fmt"
+
+/*
+Diagf64 returns a square Matf64 with the passed elements on its main
+diagonal, and zeros elsewhere:
+
+	d := matrix.Diagf64([]float64{1, 2, 3}) // 3 by 3
+
+An optional offset puts the elements on another diagonal instead, above the
+main one if it is positive, and below if negative, in which case the Matf64
+is larger by the absolute value of the offset:
+
+	matrix.Diagf64([]float64{1, 1}, 1) // 3 by 3, with ones above the diagonal
+
+For products with a diagonal matrix, which do not need the zeros to be
+stored, see DiagMatf64.
+*/
+func Diagf64(v []float64, offset ...int) *Matf64 {
+	k := diagOffset("matrix.Diagf64()", offset)
+	n := len(v) + abs(k)
+	m := Newf64(n)
+	i0, j0 := diagStart(k)
+	for i, x := range v {
+		m.vals[(i0+i)*n+j0+i] = x
+	}
+	return m
+}
+
+/*
+Diag returns a copy of the main diagonal of a Matf64 as a row vector. An
+optional offset selects another diagonal, above the main one if it is
+positive, and below if negative:
+
+	m.Diag()   // the main diagonal
+	m.Diag(1)  // the diagonal above it
+	m.Diag(-1) // the diagonal below it
+
+The Matf64 need not be square. The offset must select a diagonal with at
+least one element.
+*/
+func (m *Matf64) Diag(offset ...int) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Diag(offset...) })
+	}
+	k := diagOffset("Diag()", offset)
+	if k >= m.c || -k >= m.r {
+		s := "\nIn %s, the offset %d is outside of the bounds (-%d, %d) of a\n"
+		s += "%d by %d Matf64."
+		s = fmt.Sprintf(s, "Diag()", k, m.r, m.c, m.r, m.c)
+		printErr(ErrBounds, s)
+	}
+	i0, j0 := diagStart(k)
+	n := m.r - i0
+	if m.c-j0 < n {
+		n = m.c - j0
+	}
+	d := Newf64(1, n)
+	for i := range d.vals {
+		d.vals[i] = m.vals[(i0+i)*m.c+j0+i]
+	}
+	return d
+}
+
+func diagOffset(fn string, offset []int) int {
+	if len(offset) > 1 {
+		s := "\nIn %s, at most one offset can be passed, however %d were\n"
+		s += "received."
+		s = fmt.Sprintf(s, fn, len(offset))
+		printHelperErr(ErrArgument, s)
+	}
+	if len(offset) == 0 {
+		return 0
+	}
+	return offset[0]
+}
+
+// diagStart returns the row and the column of the first element of the
+// diagonal with the passed offset.
+func diagStart(k int) (int, int) {
+	if k < 0 {
+		return -k, 0
+	}
+	return 0, k
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,35 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagf64(t *testing.T) {
+	t.Helper()
+	assert.Equal(t, []float64{1, 0, 0, 2}, Diagf64([]float64{1, 2}).vals, "should be equal")
+	assert.Equal(t, []float64{0, 1, 0, 0, 0, 2, 0, 0, 0}, Diagf64([]float64{1, 2}, 1).vals, "should be equal")
+	assert.Equal(t, []float64{0, 0, 0, 1, 0, 0, 0, 2, 0}, Diagf64([]float64{1, 2}, -1).vals, "should be equal")
+	assert.True(t, Diagf64([]float64{1, 1, 1}).Equals(Eyef64(3)), "should be equal")
+
+	m := Matf64FromData([][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}})
+	assert.Equal(t, []float64{1, 6, 11}, m.Diag().vals, "should be equal")
+	assert.Equal(t, []float64{2, 7, 12}, m.Diag(1).vals, "should be equal")
+	assert.Equal(t, []float64{4}, m.Diag(3).vals, "should be equal")
+	assert.Equal(t, []float64{5, 10}, m.Diag(-1).vals, "should be equal")
+	assert.Equal(t, []float64{9}, m.Diag(-2).vals, "should be equal")
+	r, c := m.Diag().Shape()
+	assert.Equal(t, 1, r, "should be a row vector")
+	assert.Equal(t, 3, c, "should be equal")
+
+	v := []float64{3, 1, 4, 1}
+	for _, k := range []int{-2, 0, 2} {
+		assert.Equal(t, v, Diagf64(v, k).Diag(k).vals, "should round trip")
+	}
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { m.Diag(4) }, "should panic")
+	assert.Panics(t, func() { m.Diag(-3) }, "should panic")
+	assert.Panics(t, func() { m.Diag(0, 1) }, "should panic")
+}
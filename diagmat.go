@@ -0,0 +1,176 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+DiagMatf64 is an n by n diagonal matrix of float64s, which is stored as the n
+elements of its diagonal. Multiplying by a DiagMatf64 scales the rows or the
+columns of a Matf64, which takes O(n) operations per row or column, instead
+of the O(n^2) of a general matrix multiplication:
+
+	d := matrix.NewDiagMatf64([]float64{1.0, 2.0, 3.0})
+	a := d.Dot(m)    // scales row i of m by d.Get(i)
+	b := m.DotDiag(d) // scales column i of m by d.Get(i)
+
+As with Matf64, the fields of this struct are not directly accessible.
+*/
+type DiagMatf64 struct {
+	vals []float64
+}
+
+/*
+NewDiagMatf64 returns a DiagMatf64 whose diagonal holds a copy of the passed
+slice.
+*/
+func NewDiagMatf64(diag []float64) *DiagMatf64 {
+	d := &DiagMatf64{make([]float64, len(diag))}
+	copy(d.vals, diag)
+	return d
+}
+
+/*
+DiagMatf64FromMatf64 returns a DiagMatf64 holding the diagonal of the passed
+Matf64, which must be square. The elements off the diagonal are ignored.
+*/
+func DiagMatf64FromMatf64(m *Matf64) *DiagMatf64 {
+	if m.r != m.c {
+		s := "\nIn matrix.%s, the passed Matf64 is %d by %d. It must be square."
+		s = fmt.Sprintf(s, "DiagMatf64FromMatf64()", m.r, m.c)
+		printErr(ErrShape, s)
+	}
+	d := &DiagMatf64{make([]float64, m.r)}
+	for i := range d.vals {
+		d.vals[i] = m.vals[i*m.c+i]
+	}
+	return d
+}
+
+/*
+Size returns the number of rows, which is also the number of columns, of a
+DiagMatf64.
+*/
+func (d *DiagMatf64) Size() int {
+	return len(d.vals)
+}
+
+/*
+Get returns the i-th element of the diagonal of a DiagMatf64.
+*/
+func (d *DiagMatf64) Get(i int) float64 {
+	return d.vals[i]
+}
+
+/*
+Set sets the i-th element of the diagonal of a DiagMatf64.
+*/
+func (d *DiagMatf64) Set(i int, val float64) *DiagMatf64 {
+	d.vals[i] = val
+	return d
+}
+
+/*
+Diag returns a copy of the diagonal of a DiagMatf64.
+*/
+func (d *DiagMatf64) Diag() []float64 {
+	diag := make([]float64, len(d.vals))
+	copy(diag, d.vals)
+	return diag
+}
+
+/*
+ToMatf64 returns a Matf64 holding all the elements of a DiagMatf64, including
+the zeros off the diagonal.
+*/
+func (d *DiagMatf64) ToMatf64() *Matf64 {
+	n := len(d.vals)
+	m := Newf64(n, n)
+	for i, v := range d.vals {
+		m.vals[i*n+i] = v
+	}
+	return m
+}
+
+/*
+Copy returns a duplicate of a DiagMatf64, which shares no data with the
+original.
+*/
+func (d *DiagMatf64) Copy() *DiagMatf64 {
+	return NewDiagMatf64(d.vals)
+}
+
+/*
+Inv returns the inverse of a DiagMatf64, which is the DiagMatf64 holding the
+reciprocals of its diagonal. All the elements of the diagonal must be
+non-zero.
+*/
+func (d *DiagMatf64) Inv() *DiagMatf64 {
+	inv := &DiagMatf64{make([]float64, len(d.vals))}
+	for i, v := range d.vals {
+		if v == 0.0 {
+			s := "\nIn %s, element %d of the diagonal is zero, so the\n"
+			s += "DiagMatf64 is singular."
+			s = fmt.Sprintf(s, "Inv()", i)
+			printErr(&ErrSingular{"Inv()", i}, s)
+		}
+		inv.vals[i] = 1.0 / v
+	}
+	return inv
+}
+
+/*
+Dot returns the product of a DiagMatf64 and the passed Matf64, which is a copy
+of the Matf64 whose rows are scaled by the corresponding elements of the
+diagonal. The Matf64 must have as many rows as the DiagMatf64.
+*/
+func (d *DiagMatf64) Dot(m *Matf64) *Matf64 {
+	if len(d.vals) != m.r {
+		s := "\nIn %s the size of the DiagMatf64 is %d, which is not equal\n"
+		s += "to the number of rows of the Matf64, which is %d. They must be\n"
+		s += "equal.\n"
+		s = fmt.Sprintf(s, "Dot()", len(d.vals), m.r)
+		printErr(&ErrDimMismatch{"Dot()", len(d.vals), len(d.vals), m.r, m.c}, s)
+	}
+	return scaleRows(d.vals, m)
+}
+
+/*
+DotDiag returns the product of the receiver and the passed DiagMatf64, which
+is a copy of the receiver whose columns are scaled by the corresponding
+elements of the diagonal. The DiagMatf64 must have as many rows as the
+receiver has columns.
+*/
+func (m *Matf64) DotDiag(d *DiagMatf64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.DotDiag(d) })
+	}
+	if len(d.vals) != m.c {
+		s := "\nIn %s the number of columns of the receiver is %d, which is\n"
+		s += "not equal to the size of the DiagMatf64, which is %d. They must\n"
+		s += "be equal.\n"
+		s = fmt.Sprintf(s, "DotDiag()", m.c, len(d.vals))
+		printErr(&ErrDimMismatch{"DotDiag()", m.r, m.c, len(d.vals), len(d.vals)}, s)
+	}
+	return scaleCols(m, d.vals)
+}
+
+func scaleRows(diag []float64, m *Matf64) *Matf64 {
+	o := Newf64(m.r, m.c)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			o.vals[i*o.c+j] = diag[i] * m.vals[i*m.c+j]
+		}
+	}
+	return o
+}
+
+func scaleCols(m *Matf64, diag []float64) *Matf64 {
+	o := Newf64(m.r, m.c)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			o.vals[i*o.c+j] = m.vals[i*m.c+j] * diag[j]
+		}
+	}
+	return o
+}
@@ -0,0 +1,59 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDiagMatf64(t *testing.T) {
+	t.Helper()
+	v := []float64{1, 2, 3}
+	d := NewDiagMatf64(v)
+	v[0] = 10.0
+	assert.Equal(t, 3, d.Size(), "should be equal")
+	assert.Equal(t, []float64{1, 2, 3}, d.Diag(), "should be a copy")
+	assert.Equal(t, []float64{1, 0, 0, 0, 2, 0, 0, 0, 3}, d.ToMatf64().ToSlice1D(), "should be equal")
+	d.Set(1, 5.0)
+	assert.Equal(t, 5.0, d.Get(1), "should be equal")
+	assert.Equal(t, 2.0, d.Copy().Set(1, 2.0).Get(1), "should be equal")
+	assert.Equal(t, 5.0, d.Get(1), "should not change the original")
+}
+
+func TestDiagMatf64FromMatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	assert.Equal(t, []float64{1, 4}, DiagMatf64FromMatf64(m).Diag(), "should be equal")
+}
+
+func TestDiagMatf64Inv(t *testing.T) {
+	t.Helper()
+	d := NewDiagMatf64([]float64{2, 4, -0.5})
+	assert.Equal(t, []float64{0.5, 0.25, -2}, d.Inv().Diag(), "should be equal")
+}
+
+func TestDiagMatf64Dot(t *testing.T) {
+	t.Helper()
+	d := NewDiagMatf64([]float64{2, 3})
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	assert.Equal(t, []float64{2, 4, 6, 12, 15, 18}, d.Dot(m).ToSlice1D(), "should scale rows")
+	assert.Equal(t, []float64{1, 2, 3, 4, 5, 6}, m.ToSlice1D(), "should not change m")
+	e := NewDiagMatf64([]float64{1, 2, 3})
+	assert.Equal(t, []float64{1, 4, 9, 4, 10, 18}, m.DotDiag(e).ToSlice1D(), "should scale columns")
+}
+
+func TestDotDiagonalf64(t *testing.T) {
+	t.Helper()
+	d := NewDiagMatf64([]float64{2, 3}).ToMatf64()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	assert.Equal(t, []float64{2, 4, 6, 12, 15, 18}, d.Dot(m).ToSlice1D(), "should be equal")
+	e := NewDiagMatf64([]float64{1, 2, 3}).ToMatf64()
+	assert.Equal(t, []float64{1, 4, 9, 4, 10, 18}, m.Dot(e).ToSlice1D(), "should be equal")
+	n := Eyef64(5).Dot(Matf64FromData([]float64{
+		math.Inf(1), 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	}, 5, 5))
+	assert.True(t, math.IsInf(n.Get(0, 0), 1), "should be +Inf")
+	assert.True(t, math.IsNaN(n.Get(1, 0)), "0 times Inf should be NaN")
+}
@@ -0,0 +1,105 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+DiffReport summarizes how two Matf64s of the same shape differ, as returned
+by Diff.
+*/
+type DiffReport struct {
+	// MaxAbs is the largest absolute difference between two elements.
+	MaxAbs float64
+	// MeanAbs is the mean absolute difference, over all elements.
+	MeanAbs float64
+	// Count is the number of elements which differ.
+	Count int
+	// Row and Col are the position of the first element which differs, in
+	// row major order, or -1 if there is none.
+	Row, Col int
+}
+
+/*
+String returns a one line summary of a DiffReport.
+*/
+func (d DiffReport) String() string {
+	if d.Count == 0 {
+		return "no differences"
+	}
+	s := "%d differences, first at row %d and column %d, max abs %v, mean abs %v"
+	return fmt.Sprintf(s, d.Count, d.Row, d.Col, d.MaxAbs, d.MeanAbs)
+}
+
+/*
+Diff compares the receiver with the passed Matf64, which must have the same
+shape, and returns a DiffReport, which tells why Equals is false for large
+matrices:
+
+	if !got.Equals(want) {
+		t.Errorf("got != want: %v", got.Diff(want))
+	}
+
+Elements which are both NaN are considered equal. If only one of two
+elements is NaN, their difference is NaN, and so is MaxAbs and MeanAbs.
+*/
+func (m *Matf64) Diff(n *Matf64) DiffReport {
+	d, _ := m.diffHelper("Diff()", n, false)
+	return d
+}
+
+/*
+DiffWithMat does the same as Diff, and also returns the differences between
+the elements of the receiver and of the passed Matf64, as a new Matf64.
+*/
+func (m *Matf64) DiffWithMat(n *Matf64) (DiffReport, *Matf64) {
+	if m.deferErr {
+		var r DiffReport
+		var o *Matf64
+		if !m.deferredDo(func() { r, o = m.DiffWithMat(n) }) {
+			return DiffReport{}, m
+		}
+		o.deferErr = true
+		return r, o
+	}
+	return m.diffHelper("DiffWithMat()", n, true)
+}
+
+func (m *Matf64) diffHelper(fn string, n *Matf64, withMat bool) (DiffReport, *Matf64) {
+	if m.r != n.r || m.c != n.c {
+		s := "\nIn %s, the receiver is %d by %d, while the passed Matf64 is\n"
+		s += "%d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, fn, m.r, m.c, n.r, n.c)
+		printHelperErr(ErrShape, s)
+	}
+	var delta *Matf64
+	if withMat {
+		delta = Newf64(m.r, m.c)
+	}
+	d := DiffReport{Row: -1, Col: -1}
+	sum := 0.0
+	for i, a := range m.vals {
+		b := n.vals[i]
+		if a == b || (math.IsNaN(a) && math.IsNaN(b)) {
+			continue
+		}
+		diff := a - b
+		if withMat {
+			delta.vals[i] = diff
+		}
+		if d.Count == 0 {
+			d.Row, d.Col = i/m.c, i%m.c
+		}
+		d.Count++
+		abs := math.Abs(diff)
+		if abs > d.MaxAbs || math.IsNaN(abs) {
+			d.MaxAbs = abs
+		}
+		sum += abs
+	}
+	if len(m.vals) > 0 {
+		d.MeanAbs = sum / float64(len(m.vals))
+	}
+	return d, delta
+}
@@ -0,0 +1,24 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDifff64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, math.NaN(), 4}, 2, 2)
+	n := Matf64FromData([]float64{1, 2.5, math.NaN(), 1}, 2, 2)
+	d := m.Diff(n)
+	assert.Equal(t, DiffReport{MaxAbs: 3, MeanAbs: 0.875, Count: 2, Row: 0, Col: 1}, d, "should be equal")
+	assert.Equal(t, "2 differences, first at row 0 and column 1, max abs 3, mean abs 0.875", d.String(), "should be equal")
+	d, delta := m.DiffWithMat(n)
+	assert.Equal(t, 2, d.Count, "should be equal")
+	assert.Equal(t, []float64{0, -0.5, 0, 3}, delta.ToSlice1D(), "should be equal")
+	e := m.Diff(m)
+	assert.Equal(t, 0, e.Count, "should be equal")
+	assert.Equal(t, -1, e.Row, "should be equal")
+	assert.Equal(t, "no differences", e.String(), "should be equal")
+}
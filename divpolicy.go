@@ -0,0 +1,98 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+/*
+DivPolicy selects what DivWith does when an element is divided by zero.
+*/
+type DivPolicy int
+
+const (
+	// DivPropagate divides as Div does, giving +Inf, -Inf or NaN.
+	DivPropagate DivPolicy = iota
+	// DivZero sets the result of a division by zero to 0.
+	DivZero
+	// DivEpsilon divides by DivEps instead of zero, with the sign of the
+	// zero, so that the result is large but finite, except for 0/0 which
+	// is 0.
+	DivEpsilon
+	// DivError fails with ErrArgument, naming the first element divided
+	// by zero, in the same manner as the other errors of the package.
+	DivError
+)
+
+// DivEps is the value that DivWith with DivEpsilon divides by instead of
+// zero, which is the machine epsilon of float64.
+const DivEps = 2.220446049250313e-16
+
+/*
+DivWith does the same as Div, but handles division by zero by the passed
+policy, instead of producing Inf and NaN:
+
+	m.DivWith(counts, matrix.DivZero) // averages of empty bins are 0
+
+The passed object is a float64 or a Matf64 of the same shape as the
+receiver, as for Div.
+*/
+func (m *Matf64) DivWith(float64OrMatf64 interface{}, policy DivPolicy) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.DivWith(float64OrMatf64, policy) })
+	}
+	m.checkWritable("DivWith()")
+	if policy < DivPropagate || policy > DivError {
+		s := "\nIn %s, %d is not a known DivPolicy."
+		s = fmt.Sprintf(s, "DivWith()", int(policy))
+		printErr(ErrArgument, s)
+	}
+	var divisors []float64
+	switch v := float64OrMatf64.(type) {
+	case float64:
+		for i := range m.vals {
+			m.vals[i] = divWith(m.vals[i], v, policy, i, m.c)
+		}
+		return m
+	case *Matf64:
+		if v.r != m.r || v.c != m.c {
+			s := "\nIn %s, the receiver is %d by %d, while the passed Matf64 is\n"
+			s += "%d by %d. They must have the same shape."
+			s = fmt.Sprintf(s, "DivWith()", m.r, m.c, v.r, v.c)
+			printErr(ErrShape, s)
+		}
+		divisors = v.vals
+	default:
+		s := "\nIn %s, the passed value must be a float64 or *Matf64.\n"
+		s += "However, value of type \"%v\" was received.\n"
+		s = fmt.Sprintf(s, "DivWith()", reflect.TypeOf(v))
+		printErr(ErrArgument, s)
+	}
+	for i, d := range divisors {
+		m.vals[i] = divWith(m.vals[i], d, policy, i, m.c)
+	}
+	return m
+}
+
+// divWith returns x/d by the passed policy. i and c are the index of x, and
+// the number of columns, for the message of DivError.
+func divWith(x, d float64, policy DivPolicy, i, c int) float64 {
+	if d != 0.0 {
+		return x / d
+	}
+	switch policy {
+	case DivZero:
+		return 0.0
+	case DivEpsilon:
+		if x == 0.0 {
+			return 0.0
+		}
+		return x / math.Copysign(DivEps, d)
+	case DivError:
+		s := "\nIn %s, the element at row %d and column %d is divided by zero."
+		s = fmt.Sprintf(s, "DivWith()", i/c, i%c)
+		printHelperErr(ErrArgument, s)
+	}
+	return x / d
+}
@@ -0,0 +1,28 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDivWithf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, -2, 0, 4}, 2, 2)
+	d := Matf64FromData([]float64{0, math.Copysign(0, -1), 0, 2}, 2, 2)
+
+	p := m.Copy().DivWith(d, DivPropagate).ToSlice1D()
+	assert.True(t, math.IsInf(p[0], 1), "should be +Inf")
+	assert.True(t, math.IsInf(p[1], 1), "should be +Inf")
+	assert.True(t, math.IsNaN(p[2]), "should be NaN")
+	assert.Equal(t, 2.0, p[3], "should be equal")
+
+	assert.Equal(t, []float64{0, 0, 0, 2}, m.Copy().DivWith(d, DivZero).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{1 / DivEps, 2 / DivEps, 0, 2}, m.Copy().DivWith(d, DivEpsilon).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{0, 0, 0, 0}, m.Copy().DivWith(0.0, DivZero).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{0.5, -1, 0, 2}, m.Copy().DivWith(2.0, DivError).ToSlice1D(), "should be equal")
+	err := Catch(func() { m.Copy().DivWith(d, DivError) })
+	assert.True(t, errors.Is(err, ErrArgument), "should be an argument error")
+}
@@ -0,0 +1,88 @@
+package matrix
+
+import "fmt"
+
+/*
+DotTf64 returns the matrix product of a and b, where either of them may be
+transposed first, as selected by transA and transB. The transposes are never
+built, so for example
+
+	g := matrix.DotTf64(x, x, true, false)
+
+computes the same Matf64 as x.T().Dot(x), without copying x. The number of
+columns of a, or of its transpose, must be equal to the number of rows of b,
+or of its transpose. As with Dot, large products are split between
+goroutines, up to the number set by SetMaxThreads.
+*/
+func DotTf64(a, b *Matf64, transA, transB bool) *Matf64 {
+	// op(a) is m by k, and op(b) is k by n. Element (i, l) of op(a) is at
+	// i*ai + l*al in a.vals, and element (l, j) of op(b) at l*bl + j*bj.
+	m, k, ai, al := a.r, a.c, a.c, 1
+	if transA {
+		m, k, ai, al = a.c, a.r, 1, a.c
+	}
+	kb, n, bl, bj := b.r, b.c, b.c, 1
+	if transB {
+		kb, n, bl, bj = b.c, b.r, 1, b.c
+	}
+	if k != kb {
+		s := "\nIn matrix.%s the number of columns of the first mat is %d\n"
+		s += "which is not equal to the number of rows of the second mat,\n"
+		s += "which is %d, after transposing. They must be equal.\n"
+		s = fmt.Sprintf(s, "DotTf64()", k, kb)
+		printErr(ErrShape, s)
+	}
+	o := Newf64(m, n)
+	if !transB {
+		// Accumulate rows of b into each row of o, so that the inner loop
+		// runs along the rows of both.
+		parallelRows(m, m*k*n, MaxThreads(), func(lo, hi int) {
+			for i := lo; i < hi; i++ {
+				row := o.vals[i*n : (i+1)*n]
+				for l := 0; l < k; l++ {
+					x := a.vals[i*ai+l*al]
+					brow := b.vals[l*bl : l*bl+n]
+					for j := range row {
+						row[j] += x * brow[j]
+					}
+				}
+			}
+		})
+		return o
+	}
+	parallelRows(m, m*k*n, MaxThreads(), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			for j := 0; j < n; j++ {
+				sum := 0.0
+				for l := 0; l < k; l++ {
+					sum += a.vals[i*ai+l*al] * b.vals[l*bl+j*bj]
+				}
+				o.vals[i*n+j] = sum
+			}
+		}
+	})
+	return o
+}
+
+/*
+TraceDotf64 returns the trace of the matrix product of a and b, that is the
+sum of the diagonal of a.Dot(b), without computing the rest of the product.
+The number of columns of a must be equal to the number of rows of b, and the
+number of rows of a to the number of columns of b.
+*/
+func TraceDotf64(a, b *Matf64) float64 {
+	if a.c != b.r || a.r != b.c {
+		s := "\nIn matrix.%s, the first mat is %d by %d, and the second mat\n"
+		s += "is %d by %d. The second must have the shape of the transpose of\n"
+		s += "the first.\n"
+		s = fmt.Sprintf(s, "TraceDotf64()", a.r, a.c, b.r, b.c)
+		printErr(ErrShape, s)
+	}
+	sum := 0.0
+	for i := 0; i < a.r; i++ {
+		for k := 0; k < a.c; k++ {
+			sum += a.vals[i*a.c+k] * b.vals[k*b.c+i]
+		}
+	}
+	return sum
+}
@@ -0,0 +1,45 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDotTf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{
+		1, 2, 3,
+		4, 5, 6,
+	}, 2, 3)
+	b := Matf64FromData([]float64{
+		1, 0, 2,
+		-1, 3, 1,
+	}, 2, 3)
+	assert.True(t, DotTf64(a, b.T(), false, false).Equals(a.Dot(b.T())), "should be equal")
+	assert.True(t, DotTf64(a, b, true, false).Equals(a.T().Dot(b)), "should be equal")
+	assert.True(t, DotTf64(a, b, false, true).Equals(a.Dot(b.T())), "should be equal")
+	assert.True(t, DotTf64(a, b.T(), true, true).Equals(a.T().Dot(b)), "should be equal")
+	g := DotTf64(a, a, true, false)
+	assert.Equal(t, []float64{
+		17, 22, 27,
+		22, 29, 36,
+		27, 36, 45,
+	}, g.ToSlice1D(), "should be equal")
+}
+
+func TestTraceDotf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{
+		1, 2, 3,
+		4, 5, 6,
+	}, 2, 3)
+	b := Matf64FromData([]float64{
+		1, 0,
+		-1, 3,
+		2, 1,
+	}, 3, 2)
+	p := a.Dot(b)
+	assert.Equal(t, p.Get(0, 0)+p.Get(1, 1), TraceDotf64(a, b), "should be equal")
+	assert.Equal(t, 91.0, TraceDotf64(a, a.T()), "should be equal")
+}
@@ -0,0 +1,40 @@
+package matrix
+
+/*
+DotWith does the same as Dot, but adds up the products of each element of
+the result by the passed method, for inputs where the naive sum loses
+precision, such as rows with large elements which cancel out:
+
+	o := a.DotWith(b, matrix.SumKahan)
+
+With SumNaive, it is the same as Dot. The other methods skip the fast paths
+of Dot for tiny and diagonal mats, and are a few times slower, but the
+result does not depend on the number of goroutines, as with Dot.
+*/
+func (m *Matf64) DotWith(n *Matf64, method SumMethod) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.DotWith(n, method) })
+	}
+	if method == SumNaive {
+		return m.dotHelper("DotWith()", n, MaxThreads()).checkFinite("DotWith()")
+	}
+	if m.c != n.r {
+		// dotHelper reports the mismatch of the shapes.
+		m.dotHelper("DotWith()", n, 1)
+	}
+	sumSlice("DotWith()", m, method, nil)
+	o := Newf64(m.r, n.c)
+	parallelRows(m.r, m.r*m.c*n.c, MaxThreads(), func(lo, hi int) {
+		prods := make([]float64, m.c)
+		for i := lo; i < hi; i++ {
+			row := m.vals[i*m.c : (i+1)*m.c]
+			for j := 0; j < n.c; j++ {
+				for k, v := range row {
+					prods[k] = v * n.vals[k*n.c+j]
+				}
+				o.vals[i*o.c+j] = sumStrided(prods, m.c, 1, method)
+			}
+		}
+	})
+	return o.checkFinite("DotWith()")
+}
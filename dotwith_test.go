@@ -0,0 +1,25 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDotWithf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{1e16, 1, -1e16}, {1, 2, 3}})
+	b := Matf64FromData([][]float64{{1, 0}, {1, 1}, {1, 0}})
+	assert.Equal(t, []float64{0, 1, 6, 2}, a.Dot(b).vals, "should lose the 1")
+	assert.Equal(t, []float64{1, 1, 6, 2}, a.DotWith(b, SumKahan).vals, "should be equal")
+	assert.Equal(t, a.Dot(b).vals, a.DotWith(b, SumNaive).vals, "should be equal")
+
+	r := RandMatf64(30, 40)
+	s := RandMatf64(40, 20)
+	assertMatInDelta(t, r.Dot(s), r.DotWith(s, SumPairwise), 1e-12)
+	assertMatInDelta(t, r.Dot(s), r.DotWith(s, SumKahan), 1e-12)
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { a.DotWith(a, SumKahan) }, "should panic")
+	assert.Panics(t, func() { a.DotWith(b, SumMethod(-1)) }, "should panic")
+}
@@ -0,0 +1,179 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+ElimOpKind is the kind of a row operation of Gaussian elimination.
+*/
+type ElimOpKind int
+
+const (
+	// RowSwap swaps the rows I and J.
+	RowSwap ElimOpKind = iota
+	// RowSubtract subtracts Factor times row J from row I.
+	RowSubtract
+)
+
+/*
+ElimOp is a row operation applied by Eliminatef64, in the notation of
+textbooks when printed:
+
+	R1 <-> R3
+	R2 -= 0.5 * R1
+
+Rows are numbered from zero, as elsewhere in this package.
+*/
+type ElimOp struct {
+	Kind   ElimOpKind
+	I, J   int
+	Factor float64
+}
+
+func (op ElimOp) String() string {
+	if op.Kind == RowSwap {
+		return fmt.Sprintf("R%d <-> R%d", op.I, op.J)
+	}
+	return fmt.Sprintf("R%d -= %v * R%d", op.I, op.Factor, op.J)
+}
+
+/*
+Eliminationf64 is the result of Eliminatef64.
+*/
+type Eliminationf64 struct {
+	// U is the row echelon form of the eliminated matrix.
+	U *Matf64
+	// B is the right hand side, with the same operations applied, or nil if
+	// none was passed.
+	B *Matf64
+	// Ops are the row operations, in the order in which they were applied.
+	Ops []ElimOp
+	// Pivots holds the column of the pivot of each row of U which is not
+	// zero, so that its length is the rank of the matrix.
+	Pivots []int
+}
+
+/*
+Eliminatef64 reduces a copy of a to row echelon form, by Gaussian
+elimination with scaled partial pivoting, and returns it along with the row
+operations which were applied. It is the forward elimination of Solve, with
+scaled rather than plain partial pivoting, exposed for teaching, and for when
+the intermediate forms are needed rather than only the solution:
+
+	e := matrix.Eliminatef64(a, b)
+	for _, op := range e.Ops {
+		fmt.Println(op) // R0 <-> R2, R1 -= 0.5 * R0, ...
+	}
+	rank := len(e.Pivots)
+
+The same operations are applied to a copy of b, if it is not nil, which must
+then have as many rows as a. Neither a nor b is changed, and a need not be
+square.
+
+In each column, the pivot is the element at or below the current row which
+is largest relative to the largest element of its row in a, so that scaling
+a row of the system does not change the choice. A column whose candidates
+are all zero, relative to the scale of their rows and within the rounding
+error of float64, has no pivot, and is skipped, after its candidates are set
+to exactly zero. The elements below each pivot are also set to exactly zero,
+so that U is in row echelon form.
+*/
+func Eliminatef64(a, b *Matf64) *Eliminationf64 {
+	if b != nil && b.r != a.r {
+		s := "\nIn %s, the number of rows of a is %d, while the number of\n"
+		s += "rows of b is %d. They must be equal."
+		s = fmt.Sprintf(s, "matrix.Eliminatef64()", a.r, b.r)
+		printErr(ErrShape, s)
+	}
+	e := &Eliminationf64{U: a.Copy()}
+	if b != nil {
+		e.B = b.Copy()
+	}
+	u := e.U
+	scale := make([]float64, u.r)
+	for i := range scale {
+		for _, v := range u.vals[i*u.c : (i+1)*u.c] {
+			scale[i] = math.Max(scale[i], math.Abs(v))
+		}
+	}
+	n := u.r
+	if u.c > n {
+		n = u.c
+	}
+	tol := float64(n) * 0x1p-52
+	row := 0
+	for col := 0; col < u.c && row < u.r; col++ {
+		p, best := -1, tol
+		for i := row; i < u.r; i++ {
+			if scale[i] == 0.0 {
+				continue
+			}
+			if v := math.Abs(u.vals[i*u.c+col]) / scale[i]; v > best {
+				p, best = i, v
+			}
+		}
+		if p < 0 {
+			for i := row; i < u.r; i++ {
+				u.vals[i*u.c+col] = 0.0
+			}
+			continue
+		}
+		if p != row {
+			e.apply(ElimOp{Kind: RowSwap, I: row, J: p})
+			scale[p], scale[row] = scale[row], scale[p]
+		}
+		pivot := u.vals[row*u.c+col]
+		for i := row + 1; i < u.r; i++ {
+			f := u.vals[i*u.c+col] / pivot
+			if f == 0.0 {
+				continue
+			}
+			e.apply(ElimOp{Kind: RowSubtract, I: i, J: row, Factor: f})
+			u.vals[i*u.c+col] = 0.0
+		}
+		e.Pivots = append(e.Pivots, col)
+		row++
+	}
+	return e
+}
+
+/*
+Apply applies the row operations of an Eliminationf64, in order, to the
+passed Matf64, which must have as many rows as the eliminated matrix, and
+returns it. Applying them to the original matrix gives U, up to rounding,
+and to an identity matrix gives the matrix E for which E.Dot(a) is U.
+*/
+func (e *Eliminationf64) Apply(m *Matf64) *Matf64 {
+	if m.r != e.U.r {
+		s := "\nIn %s, the number of rows of the passed Matf64 is %d, while\n"
+		s += "the eliminated matrix has %d rows. They must be equal."
+		s = fmt.Sprintf(s, "Apply()", m.r, e.U.r)
+		printErr(ErrShape, s)
+	}
+	for _, op := range e.Ops {
+		applyElimOp(m, op)
+	}
+	return m
+}
+
+// apply records op, and applies it to U and B.
+func (e *Eliminationf64) apply(op ElimOp) {
+	e.Ops = append(e.Ops, op)
+	applyElimOp(e.U, op)
+	if e.B != nil {
+		applyElimOp(e.B, op)
+	}
+}
+
+func applyElimOp(m *Matf64, op ElimOp) {
+	if op.Kind == RowSwap {
+		swapRows(m, op.I, op.J)
+		return
+	}
+	ri, rj := m.vals[op.I*m.c:(op.I+1)*m.c], m.vals[op.J*m.c:(op.J+1)*m.c]
+	for k := range ri {
+		ri[k] -= op.Factor * rj[k]
+	}
+}
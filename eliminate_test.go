@@ -0,0 +1,48 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEliminatef64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{2, 1, -1}, {-3, -1, 2}, {-2, 1, 2}})
+	b := Matf64FromData([]float64{8, -11, -3}, 3, 1)
+	e := Eliminatef64(a, b)
+	assert.Equal(t, []int{0, 1, 2}, e.Pivots, "should be equal")
+	assert.Equal(t, []float64{2, 1, -1, -3, -1, 2, -2, 1, 2}, a.vals, "should not change a")
+	for i := 1; i < 3; i++ {
+		for j := 0; j < i; j++ {
+			assert.Equal(t, 0.0, e.U.Get(i, j), "should be upper triangular")
+		}
+	}
+	assertMatInDelta(t, e.U, e.Apply(a.Copy()), 1e-12)
+	assertMatInDelta(t, e.B, e.Apply(b.Copy()), 1e-12)
+	assertMatInDelta(t, e.U, e.Apply(Eyef64(3)).Dot(a), 1e-12)
+	assertMatInDelta(t, a.Solve(b), e.U.Solve(e.B), 1e-12)
+
+	// The scaled pivot of the first column is in row 1, although row 0 has
+	// the larger element.
+	s := Matf64FromData([][]float64{{30, 591400}, {5.291, -6.13}})
+	e = Eliminatef64(s, nil)
+	assert.Nil(t, e.B, "should be nil")
+	assert.Equal(t, ElimOp{Kind: RowSwap, I: 0, J: 1}, e.Ops[0], "should be equal")
+	assert.Equal(t, "R0 <-> R1", e.Ops[0].String(), "should be equal")
+	assert.Equal(t, "R1 -= 2 * R0", ElimOp{Kind: RowSubtract, I: 1, J: 0, Factor: 2}.String(), "should be equal")
+
+	r := Matf64FromData([][]float64{{1, 2, 3, 4}, {2, 4, 6, 8}, {1, 3, 3, 5}})
+	e = Eliminatef64(r, nil)
+	assert.Equal(t, []int{0, 1}, e.Pivots, "should skip the column without a pivot")
+	assert.Equal(t, []float64{0, 0, 0, 0}, e.U.Row(2).vals, "should be zero")
+
+	z := Matf64FromData([][]float64{{1, 2, 3}, {0, 1e-18, 1}})
+	ez := Eliminatef64(z, nil)
+	assert.Equal(t, []int{0, 2}, ez.Pivots, "should skip the negligible column")
+	assert.Equal(t, 0.0, ez.U.Get(1, 1), "should zero the skipped column")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { Eliminatef64(a, Newf64(2, 1)) }, "should panic")
+	assert.Panics(t, func() { e.Apply(Newf64(2, 2)) }, "should panic")
+}
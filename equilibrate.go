@@ -0,0 +1,56 @@
+package matrix
+
+import "math"
+
+/*
+Equilibrate scales the rows and then the columns of a Matf64 so that the
+largest absolute element of each is close to one, which can greatly reduce
+the condition number of a matrix whose rows or columns have very different
+magnitudes, such as one whose equations are in different units. It returns
+the scalings, R and C, so that the receiver becomes R.Dot(m).DotDiag(C) of
+the original m. To solve m.Dot(x) = b with the scaled matrix, scale b by R,
+and the solution by C:
+
+	r, c := a.Equilibrate()
+	x := c.Dot(a.Solve(r.Dot(b)))
+
+Each scaling is a power of two, so that scaling, and unscaling, changes no
+element by rounding. A row or a column whose elements are all zero, or which
+has no finite largest element, is not scaled.
+*/
+func (m *Matf64) Equilibrate() (*DiagMatf64, *DiagMatf64) {
+	m.checkWritable("Equilibrate()")
+	r := &DiagMatf64{make([]float64, m.r)}
+	for i := range r.vals {
+		largest := 0.0
+		for _, v := range m.vals[i*m.c : (i+1)*m.c] {
+			largest = math.Max(largest, math.Abs(v))
+		}
+		r.vals[i] = pow2Scale(largest)
+		for j := 0; j < m.c; j++ {
+			m.vals[i*m.c+j] *= r.vals[i]
+		}
+	}
+	c := &DiagMatf64{make([]float64, m.c)}
+	for j := range c.vals {
+		largest := 0.0
+		for i := 0; i < m.r; i++ {
+			largest = math.Max(largest, math.Abs(m.vals[i*m.c+j]))
+		}
+		c.vals[j] = pow2Scale(largest)
+		for i := 0; i < m.r; i++ {
+			m.vals[i*m.c+j] *= c.vals[j]
+		}
+	}
+	return r, c
+}
+
+// pow2Scale returns the power of two which scales x into [0.5, 1), or one if
+// x is zero or not finite.
+func pow2Scale(x float64) float64 {
+	if x == 0.0 || math.IsInf(x, 0) || math.IsNaN(x) {
+		return 1.0
+	}
+	_, exp := math.Frexp(x)
+	return math.Ldexp(1.0, -exp)
+}
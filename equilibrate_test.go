@@ -0,0 +1,37 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquilibratef64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{1e-8, 2e-8, 0}, {3e6, 1e6, 5e6}, {4, 0, 1}})
+	b := Matf64FromData([]float64{1, 2, 3}, 3, 1)
+	m := a.Copy()
+	r, c := m.Equilibrate()
+	assert.Equal(t, 3, r.Size(), "should be equal")
+	assert.Equal(t, 3, c.Size(), "should be equal")
+	assert.True(t, m.Equals(r.Dot(a).DotDiag(c)), "should be exactly equal")
+	for i := 0; i < 3; i++ {
+		largest := 0.0
+		for j := 0; j < 3; j++ {
+			largest = math.Max(largest, math.Abs(m.Get(i, j)))
+		}
+		assert.True(t, largest > 0.25 && largest <= 1, "should be close to one")
+	}
+	_, stats := a.SolveWithStats(b)
+	_, scaled := m.SolveWithStats(r.Dot(b))
+	assert.True(t, scaled.Cond < stats.Cond/1e6, "should reduce the condition number")
+	x := c.Dot(m.Solve(r.Dot(b)))
+	assertMatInDelta(t, b, a.Dot(x), 1e-9)
+
+	z := Matf64FromData([][]float64{{0, 0}, {0, 8}})
+	r, c = z.Equilibrate()
+	assert.Equal(t, []float64{1, 0.0625}, r.Diag(), "should not scale the zero row")
+	assert.Equal(t, []float64{1, 1}, c.Diag(), "should be equal")
+	assert.Equal(t, []float64{0, 0, 0, 0.5}, z.vals, "should be equal")
+}
@@ -1,24 +1,426 @@
 package matrix
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
+	"regexp"
+	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
-func printErr(s string) {
-	fmt.Println(s)
-	q := string(debug.Stack())
-	w := strings.Split(q, "\n")
-	fmt.Println(strings.Join(w[7:], "\n"))
-	os.Exit(1)
+/*
+Config controls how the failure of an operation is reported, when it exits
+the program, which is the default. The current settings are returned by
+GetConfig, and changed with SetConfig:
+
+	c := matrix.GetConfig()
+	c.Output = os.Stderr
+	c.StackTrace = false
+	matrix.SetConfig(c)
+*/
+type Config struct {
+	// Output is where the message is written. Nil means os.Stdout, which is
+	// the default.
+	Output io.Writer
+	// Color is whether the message is written in red, with ANSI escape
+	// codes, for a terminal. It is off by default, so that logs are kept
+	// free of escape codes.
+	Color bool
+	// StackTrace is whether the stack trace of the caller is written after
+	// the message, which is the default.
+	StackTrace bool
+	// Quiet is whether nothing is written at all, and the program only exits.
+	Quiet bool
+}
+
+// config is the current Config.
+var config = Config{StackTrace: true}
+
+/*
+GetConfig returns the current settings of how failures are reported.
+*/
+func GetConfig() Config {
+	return config
+}
+
+/*
+SetConfig sets how failures are reported, and returns the previous settings.
+It should be called before any other function of the package, as it is not
+safe to call concurrently with them.
+*/
+func SetConfig(c Config) Config {
+	prev := config
+	config = c
+	return prev
+}
+
+/*
+SetStackTrace sets whether a stack trace is printed along with the message
+when an operation fails and the program exits, which is the default. Logs
+which are read by people rather than by developers of the calling code can
+be kept shorter by turning it off:
+
+	matrix.SetStackTrace(false)
+
+It is the same as setting StackTrace in the Config, and should be called
+before any other function of the package, as it is not safe to call
+concurrently with them.
+*/
+func SetStackTrace(enabled bool) {
+	config.StackTrace = enabled
+}
+
+// panicMode is 1 if printErr and printHelperErr panic instead of exiting.
+var panicMode int32
+
+/*
+SetPanicMode sets whether an operation which fails panics with an *Error,
+instead of printing the error and exiting the program, which is the
+default. It returns the previous setting. In panic mode, the failure can be
+recovered and inspected, for example in tests:
+
+	defer matrix.SetPanicMode(matrix.SetPanicMode(true))
+	assert.Panics(t, func() { m.Dot(n) })
+
+It is safe to call concurrently with other functions of the package, but it
+applies to all goroutines. To get the error of a single call instead, see
+Catch.
+*/
+func SetPanicMode(enabled bool) bool {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	return atomic.SwapInt32(&panicMode, v) == 1
+}
+
+/*
+ErrorHandler, if set, is called with the name of the operation and the
+*Error of an operation which fails, instead of printing the error and a
+stack trace. This allows the errors to be sent to a structured logger, to
+metrics, or to a test harness:
+
+	matrix.ErrorHandler = func(op string, err error) {
+		logger.Error("matrix operation failed", "op", op, "err", err)
+	}
+
+The handler only replaces the printing of the error. The operation cannot
+continue after a failure, so if the handler returns, the failing call panics
+with the *Error, rather than exiting the program, and the panic can be
+recovered. A handler may instead panic itself, or call runtime.Goexit, as
+t.FailNow does in tests. Servers and other programs which must handle the
+failures of single calls should use Catch instead, which returns the error.
+The handler is not called for the errors returned by Catch, nor in panic
+mode. It should be set before any other function of the package is called,
+as it is not safe to set concurrently with them.
+*/
+var ErrorHandler func(op string, err error)
+
+// printErr reports the failure of an operation with the message s. kind is
+// the kind of the failure, as held by the Kind of its *Error, which is one of
+// the sentinel errors below, a typed error such as *ErrDimMismatch, or nil.
+func printErr(kind error, s string) {
+	reportErr(kind, s, 9)
 }
 
-func printHelperErr(s string) {
-	fmt.Println(s)
-	q := string(debug.Stack())
-	w := strings.Split(q, "\n")
-	fmt.Println(strings.Join(w[9:], "\n"))
+// printHelperErr is printErr for helpers, which are called by the methods
+// of the package rather than by users.
+func printHelperErr(kind error, s string) {
+	reportErr(kind, s, 11)
+}
+
+// reportErr panics with, hands over, or prints the error of the message s.
+// It exits after printing, and panics after a handler which returns. skip is
+// the number of lines of the stack trace which belong to the package, so
+// that the trace starts at the caller.
+func reportErr(kind error, s string, skip int) {
+	if atomic.LoadInt32(&panicMode) == 1 || catching() {
+		panic(newError(kind, s))
+	}
+	if h := ErrorHandler; h != nil {
+		e := newError(kind, s)
+		h(e.Op, e)
+		panic(e)
+	}
+	writeErr(config, s, skip+2)
 	os.Exit(1)
 }
+
+// writeErr writes the message s, and the stack trace if enabled, as set by
+// c. skip is as for reportErr, counted from the caller of writeErr.
+func writeErr(c Config, s string, skip int) {
+	if c.Quiet {
+		return
+	}
+	out := c.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	if c.Color {
+		s = "\x1b[31m" + s + "\x1b[0m"
+	}
+	fmt.Fprintln(out, s)
+	if c.StackTrace {
+		q := string(debug.Stack())
+		w := strings.Split(q, "\n")
+		fmt.Fprintln(out, strings.Join(w[skip:], "\n"))
+	}
+}
+
+/*
+Catch calls the passed function, and returns the error of the first
+operation of the package which fails in it, instead of exiting the program.
+If no operation fails, the returned error is nil. This makes any code using
+the package usable in servers and other long-running programs:
+
+	var c *matrix.Matf64
+	err := matrix.Catch(func() {
+		a := matrix.Matf64FromCSV(path)
+		c = a.Dot(b)
+	})
+	if err != nil {
+		// for example, the file is missing, or the shapes do not match
+	}
+
+The returned error is an *Error. The rest of the function is skipped after a
+failure, and a Matf64 which was being changed by the failing method may have
+been partially changed. Only failures on the goroutine which called Catch are
+caught: those in goroutines started by the function still exit the program.
+Panics which are not caused by the package are not recovered.
+*/
+func Catch(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+	f()
+	return nil
+}
+
+// catchName is the name of Catch, as reported in the frames of the stack.
+var catchName = runtime.FuncForPC(reflect.ValueOf(Catch).Pointer()).Name()
+
+// catching reports whether Catch is on the stack of the current goroutine,
+// in which case an error must be returned by it instead of exiting.
+func catching() bool {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(3, pcs)
+		frames := runtime.CallersFrames(pcs[:n])
+		for {
+			f, more := frames.Next()
+			if f.Function == catchName {
+				return true
+			}
+			if !more {
+				break
+			}
+		}
+		if n < len(pcs) {
+			return false
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+}
+
+/*
+ErrShape, ErrBounds, ErrParse, ErrArgument, ErrIO, ErrReadOnly,
+ErrNotFinite and ErrNoConvergence are the kinds of the failures of the
+package: shapes which do not fit together, indices outside of the bounds of
+a Matf64, data which cannot be parsed into numbers, other arguments which
+are not valid, such as a negative size or an unknown method, files or
+connections which cannot be read or written, changes to a read-only Matf64,
+such as one from ZerosSharedf64, results with a NaN or Inf in strict finite
+mode, and iterative methods which do not converge in the allowed number of
+iterations. The errors returned by the package match them with errors.Is, so
+callers can branch on the kind of a failure without matching the message:
+
+	m, err := matrix.Matf64FromCSVE(path)
+	if errors.Is(err, matrix.ErrParse) {
+		// the file has an element which is not a number
+	}
+*/
+var (
+	ErrShape  = errors.New("matrix: the shapes do not match")
+	ErrBounds = errors.New("matrix: the index is outside of the bounds")
+	ErrParse  = errors.New("matrix: the data cannot be parsed")
+
+	ErrArgument  = errors.New("matrix: an argument is not valid")
+	ErrIO        = errors.New("matrix: the data cannot be read or written")
+	ErrReadOnly  = errors.New("matrix: the mat is read-only")
+	ErrNotFinite = errors.New("matrix: the result is not finite")
+
+	ErrNoConvergence = errors.New("matrix: the iteration did not converge")
+)
+
+/*
+Error is the error of an operation which failed, as returned by Catch and the
+E variants, and as panicked with in panic mode. It holds the name of the
+operation, such as "Dot()", the shapes of the matrices named in the message,
+in order, the kind of the failure, and the message itself, on a single line:
+
+	err := matrix.Catch(func() { a.Dot(b) })
+	var e *matrix.Error
+	if errors.As(err, &e) {
+		fmt.Println(e.Op, e.Shapes)
+	}
+
+The kind is what errors.Is and errors.As match, by Unwrap. It is one of the
+sentinel errors, such as ErrShape, or a typed error, such as *ErrDimMismatch,
+which holds the details of the failure and also matches its sentinel.
+*/
+type Error struct {
+	Op     string
+	Shapes []Shape
+	Kind   error
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+// Unwrap returns the kind of the error.
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+var (
+	errOpRe    = regexp.MustCompile(`^In (?:matrix\.)?([A-Za-z0-9_.]+\(\))`)
+	errShapeRe = regexp.MustCompile(`(\d+) by (\d+)`)
+)
+
+// newError returns the *Error of the kind and the message of printErr.
+func newError(kind error, s string) *Error {
+	e := &Error{Kind: kind, Msg: strings.Join(strings.Fields(s), " ")}
+	if m := errOpRe.FindStringSubmatch(e.Msg); m != nil {
+		e.Op = m[1]
+	}
+	for _, m := range errShapeRe.FindAllStringSubmatch(e.Msg, -1) {
+		r, _ := strconv.Atoi(m[1])
+		c, _ := strconv.Atoi(m[2])
+		e.Shapes = append(e.Shapes, Shape{r, c})
+	}
+	return e
+}
+
+/*
+ErrDimMismatch is the error returned when the shapes of the operands of an
+operation do not fit together, such as in Dot of a 2 by 3 and a 2 by 3
+Matf64. It holds the name of the operation, and the shape of both operands,
+where the first is usually the receiver. It can be inspected with errors.As:
+
+	var dim *matrix.ErrDimMismatch
+	if errors.As(err, &dim) {
+		fmt.Println(dim.Op, dim.R1, dim.C1, dim.R2, dim.C2)
+	}
+
+and matched with errors.Is(err, &matrix.ErrDimMismatch{}), regardless of
+its fields, or with errors.Is(err, matrix.ErrShape). It is returned by the
+error-returning paths of the package: by Chainf64 as is, and by Catch, the E
+and Try variants and panic mode as the Kind of their *Error, so errors.As
+finds it through all of them.
+*/
+type ErrDimMismatch struct {
+	Op     string
+	R1, C1 int
+	R2, C2 int
+}
+
+func (e *ErrDimMismatch) Error() string {
+	s := "In %s, the shapes %d by %d and %d by %d do not match."
+	return fmt.Sprintf(s, e.Op, e.R1, e.C1, e.R2, e.C2)
+}
+
+// Is reports whether target is also an *ErrDimMismatch, or is ErrShape.
+func (e *ErrDimMismatch) Is(target error) bool {
+	_, ok := target.(*ErrDimMismatch)
+	return ok || target == ErrShape
+}
+
+/*
+ErrIndexOOB is the error returned when an index is outside of the bounds of
+a Matf64. It holds the name of the operation, the axis of the index, which is
+0 for a row and 1 for a column as in Sum, the index, and the number of rows or
+columns. It is used with errors.Is and errors.As, and returned, in the same
+manner as ErrDimMismatch, and matches ErrBounds.
+*/
+type ErrIndexOOB struct {
+	Op    string
+	Axis  int
+	Index int
+	Len   int
+}
+
+func (e *ErrIndexOOB) Error() string {
+	kind := "row"
+	if e.Axis == 1 {
+		kind = "column"
+	}
+	s := "In %s, %s %d is outside of the bounds [-%d, %d)."
+	return fmt.Sprintf(s, e.Op, kind, e.Index, e.Len, e.Len)
+}
+
+// Is reports whether target is also an *ErrIndexOOB, or is ErrBounds.
+func (e *ErrIndexOOB) Is(target error) bool {
+	_, ok := target.(*ErrIndexOOB)
+	return ok || target == ErrBounds
+}
+
+/*
+ErrSingular is the error returned when a matrix which must be invertible is
+singular. It holds the name of the operation, and the row at which the
+elimination found a zero pivot. It is used with errors.Is and errors.As, and
+returned, in the same manner as ErrDimMismatch.
+*/
+type ErrSingular struct {
+	Op  string
+	Row int
+}
+
+func (e *ErrSingular) Error() string {
+	s := "In %s, the matrix is singular, with a zero pivot in row %d."
+	return fmt.Sprintf(s, e.Op, e.Row)
+}
+
+// Is reports whether target is also an *ErrSingular.
+func (e *ErrSingular) Is(target error) bool {
+	_, ok := target.(*ErrSingular)
+	return ok
+}
+
+/*
+ErrNotPositiveDefinite is the error returned when a matrix which must be
+positive definite is not, as found by the factorization of Cholesky. It
+holds the name of the operation, and the first row whose pivot is not
+positive, along with that pivot. It is used with errors.Is and errors.As, and
+returned, in the same manner as ErrDimMismatch, and matches ErrArgument.
+*/
+type ErrNotPositiveDefinite struct {
+	Op    string
+	Row   int
+	Pivot float64
+}
+
+func (e *ErrNotPositiveDefinite) Error() string {
+	s := "In %s, the matrix is not positive definite, as the pivot of row %d is %v."
+	return fmt.Sprintf(s, e.Op, e.Row, e.Pivot)
+}
+
+// Is reports whether target is also an *ErrNotPositiveDefinite, or is
+// ErrArgument.
+func (e *ErrNotPositiveDefinite) Is(target error) bool {
+	_, ok := target.(*ErrNotPositiveDefinite)
+	return ok || target == ErrArgument
+}
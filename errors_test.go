@@ -0,0 +1,232 @@
+//go:build !matnocheck
+// +build !matnocheck
+
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPanicModef64(t *testing.T) {
+	t.Helper()
+	assert.False(t, SetPanicMode(true), "should be off by default")
+	assert.True(t, SetPanicMode(false), "should return the previous setting")
+
+	defer SetPanicMode(SetPanicMode(true))
+	a, b := Newf64(2, 3), Newf64(2, 4)
+	assert.Panics(t, func() { a.Dot(b) }, "should panic")
+	assert.Panics(t, func() { a.Reshape(4, 4) }, "should panic")
+	assert.NotPanics(t, func() { a.Dot(Newf64(3, 4)) }, "should not panic")
+
+	defer func() {
+		e, ok := recover().(*Error)
+		assert.True(t, ok, "should panic with an *Error")
+		assert.Equal(t, "Dot()", e.Op, "should be equal")
+		assert.Equal(t, []Shape{{2, 3}, {2, 4}}, e.Shapes, "should be equal")
+		assert.Contains(t, e.Msg, "the number of columns", "should have the message")
+	}()
+	a.Dot(b)
+}
+
+func TestErrorf64(t *testing.T) {
+	t.Helper()
+	err := Catch(func() { Newf64(3, 4).Dot(Newf64(3, 4)) })
+	var e *Error
+	assert.True(t, errors.As(err, &e), "should be an *Error")
+	assert.Equal(t, "Dot()", e.Op, "should be equal")
+	assert.Equal(t, []Shape{{3, 4}, {3, 4}}, e.Shapes, "should be equal")
+	assert.Equal(t, e.Msg, err.Error(), "should be equal")
+
+	err = Catch(func() { Matf64FromData([]float64{1, 2, 3}, 2) })
+	assert.True(t, errors.As(err, &e), "should be an *Error")
+	assert.Equal(t, "Matf64FromData()", e.Op, "should be equal")
+}
+
+func TestErrorHandlerf64(t *testing.T) {
+	t.Helper()
+	type handled struct {
+		op  string
+		err error
+	}
+	ErrorHandler = func(op string, err error) { panic(handled{op, err}) }
+	defer func() { ErrorHandler = nil }()
+
+	func() {
+		defer func() {
+			h, ok := recover().(handled)
+			assert.True(t, ok, "should call the handler")
+			assert.Equal(t, "Dot()", h.op, "should be equal")
+			var e *Error
+			assert.True(t, errors.As(h.err, &e), "should be an *Error")
+			assert.Equal(t, []Shape{{2, 3}, {2, 3}}, e.Shapes, "should be equal")
+		}()
+		Newf64(2, 3).Dot(Newf64(2, 3))
+	}()
+
+	err := Catch(func() { Newf64(2, 3).Dot(Newf64(2, 3)) })
+	assert.Error(t, err, "should be returned by Catch instead")
+
+	var calls int
+	ErrorHandler = func(op string, err error) { calls++ }
+	func() {
+		defer func() {
+			e, ok := recover().(*Error)
+			assert.True(t, ok, "should panic with the *Error after the handler")
+			assert.True(t, errors.Is(e, ErrShape), "should be a shape error")
+		}()
+		Newf64(2, 3).Dot(Newf64(2, 3))
+	}()
+	assert.Equal(t, 1, calls, "should call the handler once")
+}
+
+func TestErrKindsf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 3)
+	err := Catch(func() { m.Dot(m) })
+	assert.True(t, errors.Is(err, ErrShape), "should be a shape error")
+	assert.False(t, errors.Is(err, ErrBounds), "should not be a bounds error")
+	err = Catch(func() { m.Reshape(4, 4) })
+	assert.True(t, errors.Is(err, ErrShape), "should be a shape error")
+	err = Catch(func() { m.Row(5) })
+	assert.True(t, errors.Is(err, ErrBounds), "should be a bounds error")
+	err = Catch(func() { ParseMatf64("[[1, x]]") })
+	assert.True(t, errors.Is(err, ErrParse), "should be a parse error")
+	err = Catch(func() { Matf64FromData([]float64{1, 2}, 2, 2, 2) })
+	var e *Error
+	assert.True(t, errors.As(err, &e), "should be an *Error")
+	assert.True(t, errors.Is(err, ErrArgument), "should be an argument error")
+
+	kinds := []struct {
+		f    func()
+		kind error
+	}{
+		{func() { TraceDotf64(m, m) }, ErrShape},
+		{func() { m.Gather([]int{0}, []int{0, 1}) }, ErrShape},
+		{func() { Matf64FromColMajor([]float64{1, 2}, 2, 2) }, ErrShape},
+		{func() { Matf64FromRowMajor([]float64{1, 2}, 2, 2) }, ErrShape},
+		{func() { Col2Imf64(m, 4, 4, 2, 2, 1, 0) }, ErrShape},
+		{func() { NewBuilderf64(2).AppendRow([]float64{1}) }, ErrShape},
+		{func() { Matf64FromData([]float64{1, 2}, 3) }, ErrShape},
+		{func() { m.Gather([]int{5}, []int{0}) }, ErrBounds},
+		{func() { NewBlockSparsef64(4, 4, 2, 2, []int{2}, []int{0}, []*Matf64{Newf64(2, 2)}) }, ErrBounds},
+		{func() { Eyef64(-1) }, ErrArgument},
+		{func() { m.EWM(2, 0) }, ErrArgument},
+		{func() { Matf64FromCSV("/no/such/file.csv") }, ErrIO},
+	}
+	for i, k := range kinds {
+		err = Catch(k.f)
+		assert.True(t, errors.Is(err, k.kind), fmt.Sprintf("case %d should be %v, got %v", i, k.kind, err))
+	}
+
+	_, err = m.Chain().Dot(m).Result()
+	assert.True(t, errors.Is(err, ErrShape), "should be a shape error")
+	_, err = m.Chain().Set(5, 0, 1).Result()
+	assert.True(t, errors.Is(err, ErrBounds), "should be a bounds error")
+	_, err = Matf64FromData([][]float64{{1, 2}, {2, 4}}).Chain().Inv().Result()
+	assert.False(t, errors.Is(err, ErrShape), "should not be a shape error")
+}
+
+func TestConfigf64(t *testing.T) {
+	t.Helper()
+	c := GetConfig()
+	assert.True(t, c.StackTrace, "should print the stack trace by default")
+	assert.False(t, c.Color, "should not color by default")
+	assert.Nil(t, c.Output, "should write to os.Stdout by default")
+
+	var buf bytes.Buffer
+	prev := SetConfig(Config{Output: &buf, Color: true})
+	assert.Equal(t, c, prev, "should return the previous settings")
+	writeErr(GetConfig(), "\nIn Dot(), it failed.", 0)
+	assert.Equal(t, "\x1b[31m\nIn Dot(), it failed.\x1b[0m\n", buf.String(), "should be equal")
+
+	buf.Reset()
+	SetConfig(Config{Output: &buf, StackTrace: true})
+	writeErr(GetConfig(), "\nIn Dot(), it failed.", 0)
+	assert.True(t, strings.HasPrefix(buf.String(), "\nIn Dot(), it failed.\n"), "should be plain")
+	assert.Contains(t, buf.String(), "TestConfigf64", "should have the stack trace")
+
+	buf.Reset()
+	SetConfig(Config{Output: &buf, StackTrace: true, Quiet: true})
+	writeErr(GetConfig(), "\nIn Dot(), it failed.", 0)
+	assert.Equal(t, "", buf.String(), "should write nothing")
+
+	SetStackTrace(false)
+	assert.False(t, GetConfig().StackTrace, "should be equal")
+	SetConfig(c)
+}
+
+func TestTypedErrorsf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 3)
+	err := Catch(func() { m.Dot(m) })
+	var e *Error
+	assert.True(t, errors.As(err, &e), "should be an *Error")
+	var dim *ErrDimMismatch
+	assert.True(t, errors.As(err, &dim), "should wrap an *ErrDimMismatch")
+	assert.Equal(t, ErrDimMismatch{"Dot()", 2, 3, 2, 3}, *dim, "should hold the shapes")
+	assert.True(t, errors.Is(err, ErrShape), "should be a shape error")
+
+	err = Catch(func() { m.Add(Newf64(3, 3)) })
+	assert.True(t, errors.As(err, &dim), "should wrap an *ErrDimMismatch")
+	assert.Equal(t, ErrDimMismatch{"Add()", 2, 3, 3, 3}, *dim, "should hold the shapes")
+
+	var oob *ErrIndexOOB
+	err = Catch(func() { m.Col(-4) })
+	assert.True(t, errors.As(err, &oob), "should wrap an *ErrIndexOOB")
+	assert.Equal(t, ErrIndexOOB{"Col()", 1, -4, 3}, *oob, "should hold the index")
+	assert.True(t, errors.Is(err, ErrBounds), "should be a bounds error")
+
+	var sing *ErrSingular
+	for _, n := range []int{2, 5} {
+		err = Catch(func() { Newf64(n, n).Inv() })
+		assert.True(t, errors.As(err, &sing), "should wrap an *ErrSingular")
+		assert.Equal(t, "Inv()", sing.Op, "should be equal")
+	}
+	err = Catch(func() { Matf64FromData([][]float64{{1, 2}, {2, 4}}).Solve(Newf64(2, 1)) })
+	assert.True(t, errors.As(err, &sing), "should wrap an *ErrSingular")
+	assert.Equal(t, ErrSingular{"Solve()", 1}, *sing, "should hold the row")
+
+	// The same failure gives the same typed error through Chainf64.
+	_, chainErr := m.Chain().Dot(m).Result()
+	var chainDim *ErrDimMismatch
+	assert.True(t, errors.As(chainErr, &chainDim), "should be an *ErrDimMismatch")
+	err = Catch(func() { m.Dot(m) })
+	errors.As(err, &dim)
+	assert.Equal(t, *chainDim, *dim, "should be equal")
+}
+
+func TestCatchf64(t *testing.T) {
+	t.Helper()
+	var c *Matf64
+	err := Catch(func() {
+		c = Newf64(2, 3).Dot(Newf64(2, 3))
+	})
+	assert.Error(t, err, "should fail")
+	assert.Nil(t, c, "should skip the rest of the function")
+	assert.Contains(t, err.Error(), "In Dot() the number of columns", "should have the message")
+	assert.False(t, strings.Contains(err.Error(), "\n"), "should be a single line")
+
+	err = Catch(func() {
+		c = Newf64(2, 3).Dot(Newf64(3, 2))
+	})
+	assert.NoError(t, err, "should not fail")
+	assert.NotNil(t, c, "should be set")
+
+	// Nested calls return the error from the innermost Catch.
+	err = Catch(func() {
+		inner := Catch(func() { Newf64(1, 2, 3) })
+		assert.Error(t, inner, "should fail")
+	})
+	assert.NoError(t, err, "should not fail")
+
+	boom := errors.New("boom")
+	assert.Panics(t, func() {
+		_ = Catch(func() { panic(boom) })
+	}, "should not recover other panics")
+}
@@ -0,0 +1,111 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+EWMf64 computes exponentially weighted moving statistics along the rows or
+the columns of a Matf64, as returned by its EWM method. The fields of this
+struct are not directly accessible.
+*/
+type EWMf64 struct {
+	m     *Matf64
+	alpha float64
+	axis  int
+}
+
+/*
+EWM returns an EWMf64, from which the exponentially weighted moving mean,
+variance and standard deviation of each row (axis 0) or column (axis 1) of a
+Matf64 are computed, with the passed smoothing factor, which must be in
+(0, 1]:
+
+	ewm := prices.EWM(0.1, 1) // each column is a series
+	mean, std := ewm.Mean(), ewm.Std()
+
+Each statistic is a new Matf64 of the shape of the receiver, whose element at
+each position is the statistic of the series up to, and including, that
+position. The mean is updated as
+
+	mean = alpha*x + (1-alpha)*mean
+
+starting at the first element of the series, so that larger values of alpha
+forget the past faster, and alpha of 1 gives the series itself. The variance
+is the biased exponentially weighted variance about this mean, which starts
+at zero. The elements of the receiver are read when a statistic is
+computed, not when EWM is called. A NaN in a series makes the rest of its
+statistics NaN.
+*/
+func (m *Matf64) EWM(alpha float64, axis int) *EWMf64 {
+	if !(alpha > 0.0 && alpha <= 1.0) {
+		s := "\nIn %s, the smoothing factor must be in (0, 1], however %v\n"
+		s += "was received."
+		s = fmt.Sprintf(s, "EWM()", alpha)
+		printErr(ErrArgument, s)
+	}
+	imputeLayout("EWM()", m, axis)
+	return &EWMf64{m, alpha, axis}
+}
+
+/*
+Mean returns the exponentially weighted moving mean.
+*/
+func (e *EWMf64) Mean() *Matf64 {
+	mean, _ := e.stats(false)
+	return mean
+}
+
+/*
+Var returns the exponentially weighted moving variance.
+*/
+func (e *EWMf64) Var() *Matf64 {
+	_, v := e.stats(true)
+	return v
+}
+
+/*
+Std returns the exponentially weighted moving standard deviation, which is
+the square root of Var.
+*/
+func (e *EWMf64) Std() *Matf64 {
+	_, v := e.stats(true)
+	for i := range v.vals {
+		v.vals[i] = math.Sqrt(v.vals[i])
+	}
+	return v
+}
+
+// stats returns the moving mean, and the moving variance if withVar. The
+// variance is updated by the recurrence of Finch, "Incremental calculation
+// of weighted mean and variance" (2009), in a single pass.
+func (e *EWMf64) stats(withVar bool) (*Matf64, *Matf64) {
+	m, a := e.m, e.alpha
+	count, n, step, stride := imputeLayout("EWM()", m, e.axis)
+	mean := Newf64(m.r, m.c)
+	var v *Matf64
+	if withVar {
+		v = Newf64(m.r, m.c)
+	}
+	for k := 0; k < count; k++ {
+		mu, s2 := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			idx := k*stride + i*step
+			x := m.vals[idx]
+			if i == 0 {
+				mu = x
+			} else {
+				diff := x - mu
+				incr := a * diff
+				mu += incr
+				s2 = (1.0 - a) * (s2 + diff*incr)
+			}
+			mean.vals[idx] = mu
+			if withVar {
+				v.vals[idx] = s2
+			}
+		}
+	}
+	return mean, v
+}
@@ -0,0 +1,42 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEWMf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 4}, {3, 3, 3}})
+	ewm := m.EWM(0.5, 0)
+	assert.Equal(t, []float64{1, 1.5, 2.75, 3, 3, 3}, ewm.Mean().vals, "should be equal")
+	assertMatInDelta(t, ewm.Mean().T(), m.Copy().T().EWM(0.5, 1).Mean(), 1e-15)
+
+	// The variance is the weighted variance about the weighted mean, with
+	// weights (1-alpha)^(t-i) for i > 0, and (1-alpha)^t for the first
+	// element.
+	v := ewm.Var()
+	assert.Equal(t, []float64{0, 0.25, 1.6875, 0, 0, 0}, v.vals, "should be equal")
+	w := []float64{0.25, 0.25, 0.5}
+	want := 0.0
+	for i, x := range []float64{1, 2, 4} {
+		want += w[i] * (x - 2.75) * (x - 2.75)
+	}
+	assert.InDelta(t, want, v.Get(0, 2), 1e-15, "should be equal")
+	assert.InDelta(t, math.Sqrt(1.6875), ewm.Std().Get(0, 2), 1e-15, "should be equal")
+
+	assert.Equal(t, m.vals, m.EWM(1.0, 1).Mean().vals, "should be the series itself")
+	assert.Equal(t, []float64{0, 0, 0, 0, 0, 0}, m.EWM(1.0, 1).Var().vals, "should be zero")
+
+	m.Set(0, 1, math.NaN())
+	assert.True(t, math.IsNaN(m.EWM(0.5, 0).Mean().Get(0, 2)), "should be NaN")
+	assert.Equal(t, 3.0, m.EWM(0.5, 0).Mean().Get(1, 2), "should be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { m.EWM(0, 0) }, "should panic")
+	assert.Panics(t, func() { m.EWM(1.5, 0) }, "should panic")
+	assert.Panics(t, func() { m.EWM(math.NaN(), 0) }, "should panic")
+	assert.Panics(t, func() { m.EWM(0.5, 2) }, "should panic")
+}
@@ -0,0 +1,38 @@
+package matrix
+
+import "fmt"
+
+/*
+Eyef64 returns the n by n identity matrix, with ones on the diagonal and
+zeros elsewhere:
+
+	a.Dot(matrix.Eyef64(3)) // is equal to a, if a has 3 columns
+
+Unlike EyeSharedf64, the returned Matf64 is new, and can be changed.
+*/
+func Eyef64(n int) *Matf64 {
+	if n < 0 {
+		s := "\nIn matrix.%s, the size must not be negative, however %d was\n"
+		s += "received."
+		s = fmt.Sprintf(s, "Eyef64()", n)
+		printErr(ErrArgument, s)
+	}
+	m := Newf64(n)
+	for i := 0; i < n; i++ {
+		m.vals[i*n+i] = 1.0
+	}
+	return m
+}
+
+/*
+EyeLikef64 returns a Matf64 of the same shape as the passed one, with ones
+on its main diagonal and zeros elsewhere. For a square Matf64, it is the
+identity matrix of the same size.
+*/
+func EyeLikef64(m *Matf64) *Matf64 {
+	o := Newf64(m.r, m.c)
+	for i := 0; i < m.r && i < m.c; i++ {
+		o.vals[i*m.c+i] = 1.0
+	}
+	return o
+}
@@ -0,0 +1,27 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEyef64(t *testing.T) {
+	t.Helper()
+	assert.Equal(t, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}, Eyef64(3).vals, "should be equal")
+	assert.Equal(t, 0, len(Eyef64(0).vals), "should be empty")
+	assert.True(t, If64(4).Equals(Eyef64(4)), "should be equal")
+	a := RandMatf64(5, 4)
+	assert.True(t, a.Dot(Eyef64(4)).Equals(a), "should be equal")
+
+	e := Eyef64(2)
+	e.Set(0, 1, 5)
+	assert.Equal(t, 0.0, Eyef64(2).Get(0, 1), "should be a new Matf64")
+
+	assert.Equal(t, []float64{1, 0, 0, 0, 1, 0}, EyeLikef64(Newf64(2, 3)).vals, "should be equal")
+	assert.Equal(t, []float64{1, 0, 0, 1, 0, 0}, EyeLikef64(Newf64(3, 2)).vals, "should be equal")
+	assert.True(t, EyeLikef64(Newf64(3, 3)).Equals(Eyef64(3)), "should be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { Eyef64(-1) }, "should panic")
+}
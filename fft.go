@@ -0,0 +1,183 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+/*
+FFTf64 computes the discrete Fourier transform of each row or each column of
+a complex matrix, which is passed as its real and imaginary parts. The
+imaginary part may be nil, in which case it is taken to be zero. The result
+is returned in the same manner, as two new Matf64s holding the real and the
+imaginary parts.
+
+The axis selects the direction of the transform, in the same manner as the
+axis passed to Sum or Max: 0 transforms each row, and 1 transforms each
+column:
+
+	re, im := matrix.FFTf64(signals, nil, 0) // the spectrum of each row
+
+Lengths which are powers of two use the radix-2 algorithm, while other
+lengths use Bluestein's algorithm, so that all lengths take O(n log(n))
+operations.
+*/
+func FFTf64(re, im *Matf64, axis int) (*Matf64, *Matf64) {
+	return fftAxis("FFTf64()", re, im, axis, false)
+}
+
+/*
+IFFTf64 computes the inverse discrete Fourier transform of each row or each
+column of a complex matrix, in the same manner as FFTf64. The result is
+scaled by 1/n, so that IFFTf64 undoes FFTf64 along the same axis.
+*/
+func IFFTf64(re, im *Matf64, axis int) (*Matf64, *Matf64) {
+	return fftAxis("IFFTf64()", re, im, axis, true)
+}
+
+/*
+FFT2f64 computes the 2D discrete Fourier transform of a complex matrix, which
+is passed and returned as its real and imaginary parts, in the same manner as
+FFTf64. This is the transform of each row, followed by the transform of each
+column.
+*/
+func FFT2f64(re, im *Matf64) (*Matf64, *Matf64) {
+	re, im = fftAxis("FFT2f64()", re, im, 0, false)
+	return fftAxis("FFT2f64()", re, im, 1, false)
+}
+
+/*
+IFFT2f64 computes the inverse 2D discrete Fourier transform of a complex
+matrix, in the same manner as FFT2f64, such that it undoes FFT2f64.
+*/
+func IFFT2f64(re, im *Matf64) (*Matf64, *Matf64) {
+	re, im = fftAxis("IFFT2f64()", re, im, 0, true)
+	return fftAxis("IFFT2f64()", re, im, 1, true)
+}
+
+func fftAxis(fn string, re, im *Matf64, axis int, inverse bool) (*Matf64, *Matf64) {
+	if im != nil && (im.r != re.r || im.c != re.c) {
+		s := "\nIn matrix.%s, the real part is %d by %d, while the imaginary\n"
+		s += "part is %d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, fn, re.r, re.c, im.r, im.c)
+		printHelperErr(ErrShape, s)
+	}
+	if axis != 0 && axis != 1 {
+		s := "\nIn matrix.%s, the axis must be 0 or 1, however %d was received."
+		s = fmt.Sprintf(s, fn, axis)
+		printHelperErr(ErrArgument, s)
+	}
+	// Each of the count lines has n elements, which are step apart, and the
+	// first element of line k is at k*stride.
+	count, n, step, stride := re.r, re.c, 1, re.c
+	if axis == 1 {
+		count, n, step, stride = re.c, re.r, re.c, 1
+	}
+	outRe, outIm := Newf64(re.r, re.c), Newf64(re.r, re.c)
+	x := make([]complex128, n)
+	for k := 0; k < count; k++ {
+		for i := range x {
+			idx := k*stride + i*step
+			v := 0.0
+			if im != nil {
+				v = im.vals[idx]
+			}
+			x[i] = complex(re.vals[idx], v)
+		}
+		fft(x, inverse)
+		for i := range x {
+			idx := k*stride + i*step
+			outRe.vals[idx] = real(x[i])
+			outIm.vals[idx] = imag(x[i])
+		}
+	}
+	return outRe, outIm
+}
+
+// fft computes the discrete Fourier transform of x in place, or its inverse
+// scaled by 1/len(x).
+func fft(x []complex128, inverse bool) {
+	n := len(x)
+	if n <= 1 {
+		return
+	}
+	if inverse {
+		for i := range x {
+			x[i] = cmplx.Conj(x[i])
+		}
+	}
+	if n&(n-1) == 0 {
+		radix2(x)
+	} else {
+		bluestein(x)
+	}
+	if inverse {
+		scale := 1.0 / float64(n)
+		for i := range x {
+			x[i] = cmplx.Conj(x[i]) * complex(scale, 0)
+		}
+	}
+}
+
+// radix2 computes the forward transform of x in place, whose length must be
+// a power of two.
+func radix2(x []complex128) {
+	n := len(x)
+	shift := uint(64 - bits.TrailingZeros(uint(n)))
+	for i := range x {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		w := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			wk := complex(1, 0)
+			for k := 0; k < half; k++ {
+				a, b := x[start+k], x[start+k+half]*wk
+				x[start+k], x[start+k+half] = a+b, a-b
+				wk *= w
+			}
+		}
+	}
+}
+
+// bluestein computes the forward transform of x in place, for any length, by
+// expressing it as a convolution whose length is a power of two.
+func bluestein(x []complex128) {
+	n := len(x)
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	// chirp[k] = exp(-i*pi*k^2/n), where k^2 is reduced mod 2n to keep the
+	// angle small for large k.
+	chirp := make([]complex128, n)
+	for k := range chirp {
+		kk := (k * k) % (2 * n)
+		chirp[k] = cmplx.Exp(complex(0, -math.Pi*float64(kk)/float64(n)))
+	}
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for k := 0; k < n; k++ {
+		a[k] = x[k] * chirp[k]
+		b[k] = cmplx.Conj(chirp[k])
+		if k > 0 {
+			b[m-k] = b[k]
+		}
+	}
+	radix2(a)
+	radix2(b)
+	for i := range a {
+		a[i] = cmplx.Conj(a[i] * b[i])
+	}
+	radix2(a)
+	scale := complex(1.0/float64(m), 0)
+	for k := 0; k < n; k++ {
+		x[k] = cmplx.Conj(a[k]) * scale * chirp[k]
+	}
+}
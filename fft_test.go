@@ -0,0 +1,80 @@
+package matrix
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// naiveDFT is the O(n^2) definition of the discrete Fourier transform.
+func naiveDFT(x []complex128) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := range out {
+		for j := range x {
+			out[k] += x[j] * cmplx.Exp(complex(0, -2*math.Pi*float64(j*k)/float64(n)))
+		}
+	}
+	return out
+}
+
+func TestFFTf64(t *testing.T) {
+	t.Helper()
+	for _, n := range []int{1, 2, 5, 8, 12} {
+		re := RandMatf64(3, n)
+		im := RandMatf64(3, n)
+		gotRe, gotIm := FFTf64(re, im, 0)
+		for i := 0; i < 3; i++ {
+			x := make([]complex128, n)
+			for j := range x {
+				x[j] = complex(re.Get(i, j), im.Get(i, j))
+			}
+			want := naiveDFT(x)
+			for j := range want {
+				assert.InDelta(t, real(want[j]), gotRe.Get(i, j), 1e-9, "should be equal")
+				assert.InDelta(t, imag(want[j]), gotIm.Get(i, j), 1e-9, "should be equal")
+			}
+		}
+	}
+}
+
+func TestFFTf64Axis(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(6, 4)
+	re0, im0 := FFTf64(m, nil, 1)
+	re1, im1 := FFTf64(m.T(), nil, 0)
+	assert.True(t, re0.T().Equals(re1), "should be equal")
+	assert.True(t, im0.T().Equals(im1), "should be equal")
+}
+
+func TestIFFTf64(t *testing.T) {
+	t.Helper()
+	for _, n := range []int{4, 7} {
+		m := RandMatf64(n, 3)
+		fre, fim := FFTf64(m, nil, 1)
+		re, im := IFFTf64(fre, fim, 1)
+		for i, v := range m.ToSlice1D() {
+			assert.InDelta(t, v, re.vals[i], 1e-12, "should be equal")
+			assert.InDelta(t, 0.0, im.vals[i], 1e-12, "should be zero")
+		}
+	}
+}
+
+func TestFFT2f64(t *testing.T) {
+	t.Helper()
+	m := Newf64(3, 4)
+	m.Set(0, 0, 1.0)
+	re, im := FFT2f64(m, nil)
+	for i, v := range re.ToSlice1D() {
+		assert.InDelta(t, 1.0, v, 1e-12, "an impulse should have a flat spectrum")
+		assert.InDelta(t, 0.0, im.vals[i], 1e-12, "should be zero")
+	}
+	n := RandMatf64(5, 6)
+	re, im = IFFT2f64(FFT2f64(n, nil))
+	for i, v := range n.ToSlice1D() {
+		assert.InDelta(t, v, re.vals[i], 1e-12, "should be equal")
+		assert.InDelta(t, 0.0, im.vals[i], 1e-12, "should be zero")
+	}
+}
@@ -0,0 +1,37 @@
+package matrix
+
+/*
+Find returns the row and column of each element of a mat object for which the
+supplied function is true, in row major order. The function is of the same
+kind as for All and Any, so for instance
+
+	idx := m.Find(matrix.Negative)
+	for _, rc := range idx {
+		m.Set(rc[0], rc[1], 0.0)
+	}
+
+sets all the negative elements of m to zero.
+*/
+func (m *Matf64) Find(f func(*float64) bool) [][2]int {
+	var idx [][2]int
+	for i := range m.vals {
+		if f(&m.vals[i]) {
+			idx = append(idx, [2]int{i / m.c, i % m.c})
+		}
+	}
+	return idx
+}
+
+/*
+FindFirst returns the row and column of the first element of a mat object, in
+row major order, for which the supplied function is true. The last returned
+value is false if there is no such element.
+*/
+func (m *Matf64) FindFirst(f func(*float64) bool) (int, int, bool) {
+	for i := range m.vals {
+		if f(&m.vals[i]) {
+			return i / m.c, i % m.c, true
+		}
+	}
+	return -1, -1, false
+}
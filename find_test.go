@@ -0,0 +1,32 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, -2, 3,
+		-4, 5, -6,
+	}, 2, 3)
+	neg := func(v *float64) bool { return *v < 0.0 }
+	assert.Equal(t, [][2]int{{0, 1}, {1, 0}, {1, 2}}, m.Find(neg), "should be equal")
+	assert.Nil(t, m.Find(func(v *float64) bool { return *v > 10 }), "should be empty")
+}
+
+func TestFindFirstf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 2, 3,
+		-4, 5, -6,
+	}, 2, 3)
+	r, c, ok := m.FindFirst(func(v *float64) bool { return *v < 0.0 })
+	assert.True(t, ok, "should be found")
+	assert.Equal(t, 1, r, "should be equal")
+	assert.Equal(t, 0, c, "should be equal")
+	_, _, ok = m.FindFirst(func(v *float64) bool { return *v > 10 })
+	assert.False(t, ok, "should not be found")
+}
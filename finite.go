@@ -0,0 +1,75 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+/*
+HasNaN reports whether any element of a Matf64 is NaN.
+*/
+func (m *Matf64) HasNaN() bool {
+	for _, v := range m.vals {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+HasInf reports whether any element of a Matf64 is +Inf or -Inf.
+*/
+func (m *Matf64) HasInf() bool {
+	for _, v := range m.vals {
+		if math.IsInf(v, 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// strictFinite is 1 if the arithmetic methods check that their results are
+// finite.
+var strictFinite int32
+
+/*
+SetStrictFinite sets whether the arithmetic methods of Matf64, which are Add,
+Sub, Mul, Div, Scale, AddScaled, Dot, DotThreads and DotWith, check that
+their result is finite, and fail with the position of the first NaN or Inf
+if it is not. It returns the previous setting, which is off by default. This
+finds the operation which first produced a NaN in a long computation:
+
+	defer matrix.SetStrictFinite(matrix.SetStrictFinite(true))
+	y := x.Dot(w).Div(norm) // fails in Div, if norm has a zero
+
+The check reads every element of the result, so it is meant for debugging.
+A NaN or Inf which was already in an operand is reported by the first
+operation to which it is passed. The failure is of kind ErrNotFinite. It is safe to call concurrently with other
+functions of the package, but it applies to all goroutines.
+*/
+func SetStrictFinite(enabled bool) bool {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	return atomic.SwapInt32(&strictFinite, v) == 1
+}
+
+// checkFinite fails if strict finite mode is on and the result m of the
+// operation fn has an element which is NaN or Inf. It returns m.
+func (m *Matf64) checkFinite(fn string) *Matf64 {
+	if atomic.LoadInt32(&strictFinite) == 0 {
+		return m
+	}
+	for i, v := range m.vals {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			s := "\nIn %s, the result has %v at row %d, column %d, which is not\n"
+			s += "allowed in strict finite mode."
+			s = fmt.Sprintf(s, fn, v, i/m.c, i%m.c)
+			printHelperErr(ErrNotFinite, s)
+		}
+	}
+	return m
+}
@@ -0,0 +1,46 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasNaNf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 3)
+	assert.False(t, m.HasNaN(), "should be false")
+	assert.False(t, m.HasInf(), "should be false")
+	m.Set(1, 2, math.NaN())
+	assert.True(t, m.HasNaN(), "should be true")
+	assert.False(t, m.HasInf(), "should be false")
+	m.Set(1, 2, math.Inf(-1))
+	assert.False(t, m.HasNaN(), "should be false")
+	assert.True(t, m.HasInf(), "should be true")
+}
+
+func TestSetStrictFinitef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	n := Matf64FromData([][]float64{{1, 1}, {0, 1}})
+	assert.False(t, SetStrictFinite(true), "should be off by default")
+	defer SetStrictFinite(false)
+
+	err := Catch(func() { m.Copy().Div(n) })
+	var e *Error
+	assert.True(t, errors.As(err, &e), "should be an *Error")
+	assert.Equal(t, "Div()", e.Op, "should be equal")
+	assert.Contains(t, e.Msg, "+Inf at row 1, column 0", "should name the element")
+	assert.True(t, errors.Is(err, ErrNotFinite), "should not be finite")
+
+	assert.NoError(t, Catch(func() { m.Copy().Add(n).Mul(n).Sub(1.0).Dot(n) }), "should not fail")
+	assert.Error(t, Catch(func() { m.Copy().Scale(math.Inf(1)) }), "should fail")
+	big := Newf64(2, 2).SetAll(math.MaxFloat64)
+	assert.Error(t, Catch(func() { big.Dot(big) }), "should fail")
+	assert.Error(t, Catch(func() { big.DotWith(big, SumKahan) }), "should fail")
+
+	assert.True(t, SetStrictFinite(false), "should return the previous setting")
+	assert.NoError(t, Catch(func() { m.Copy().Div(n) }), "should not check")
+}
@@ -0,0 +1,65 @@
+//go:build go1.16
+// +build go1.16
+
+package matrix
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// The functions below read from an fs.FS, which was added in Go 1.16.
+
+/*
+Matf64FromCSVFS creates a mat object from a CSV file in the passed file
+system, in the same manner as Matf64FromCSV. This allows matrices to be loaded
+from any fs.FS, such as files embedded with go:embed, or zip archives:
+
+	//go:embed data
+	var data embed.FS
+
+	m := matrix.Matf64FromCSVFS(data, "data/weights.csv")
+*/
+func Matf64FromCSVFS(fsys fs.FS, name string) *Matf64 {
+	f, err := fsys.Open(name)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromCSVFS()", name, err)
+		printErr(ErrIO, s)
+	}
+	defer f.Close()
+	return matf64FromCSVReaderHelper(f, "Matf64FromCSVFS()", name)
+}
+
+/*
+Loadf64FS reads a Matf64 written by Save from a file in the passed file
+system, in the same manner as Loadf64. This allows matrices to be loaded from
+any fs.FS, such as files embedded with go:embed, or zip archives:
+
+	//go:embed data
+	var data embed.FS
+
+	m := matrix.Loadf64FS(data, "data/weights.bin")
+*/
+func Loadf64FS(fsys fs.FS, name string) *Matf64 {
+	readFile := func(name string) ([]byte, error) { return fs.ReadFile(fsys, name) }
+	return loadBinaryHelper("Loadf64FS()", name, readFile, true)
+}
+
+/*
+Matf64FromCSVFSE does the same as Matf64FromCSVFS, but returns an error
+instead of exiting.
+*/
+func Matf64FromCSVFSE(fsys fs.FS, name string) (m *Matf64, err error) {
+	err = Catch(func() { m = Matf64FromCSVFS(fsys, name) })
+	return m, err
+}
+
+/*
+Loadf64FSE does the same as Loadf64FS, but returns an error instead of
+exiting.
+*/
+func Loadf64FSE(fsys fs.FS, name string) (m *Matf64, err error) {
+	err = Catch(func() { m = Loadf64FS(fsys, name) })
+	return m, err
+}
@@ -0,0 +1,38 @@
+//go:build go1.16
+// +build go1.16
+
+package matrix
+
+import (
+	"math"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64FromCSVFS(t *testing.T) {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"data/m.csv": &fstest.MapFile{Data: []byte("1.0,2.0\n3.0,4.0\n5.0,6.0")},
+	}
+	m := Matf64FromCSVFS(fsys, "data/m.csv")
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 2, m.c, "should be equal")
+	assert.Equal(t, []float64{1, 2, 3, 4, 5, 6}, m.vals, "should be equal")
+}
+
+func TestLoadf64FS(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1.0 / 3.0, -2, math.Inf(1), 4e-300, 5, 6}, 2, 3)
+	b := m.encodeBinary()
+	fsys := fstest.MapFS{
+		"data/m.bin":       &fstest.MapFile{Data: b},
+		"data/corrupt.bin": &fstest.MapFile{Data: append(b[:len(b)-1:len(b)-1], b[len(b)-1]^1)},
+	}
+	assert.True(t, Loadf64FS(fsys, "data/m.bin").Equals(m), "should be equal")
+	_, err := Loadf64FSE(fsys, "data/corrupt.bin")
+	assert.Error(t, err, "should fail on a bad checksum")
+	_, err = Loadf64FSE(fsys, "data/missing.bin")
+	assert.Error(t, err, "should fail")
+}
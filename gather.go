@@ -0,0 +1,73 @@
+package matrix
+
+import "fmt"
+
+/*
+Gather returns the elements of the receiver at the passed rows and columns,
+in one pass, where element i of the result is m.Get(rows[i], cols[i]):
+
+	vals := m.Gather([]int{0, 2, 2}, []int{1, 0, 3})
+
+rows and cols must have the same length.
+*/
+func (m *Matf64) Gather(rows, cols []int) []float64 {
+	m.checkScatterIdx("Gather()", rows, cols, len(rows))
+	vals := make([]float64, len(rows))
+	for i, r := range rows {
+		vals[i] = m.vals[r*m.c+cols[i]]
+	}
+	return vals
+}
+
+/*
+Scatter sets the elements of the receiver at the passed rows and columns to
+the passed values, in one pass, in the same manner as calling
+m.Set(rows[i], cols[i], vals[i]) for each i. If a position is repeated, the
+last value is kept. rows, cols and vals must have the same length.
+*/
+func (m *Matf64) Scatter(rows, cols []int, vals []float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Scatter(rows, cols, vals) })
+	}
+	m.checkWritable("Scatter()")
+	m.checkScatterIdx("Scatter()", rows, cols, len(vals))
+	for i, r := range rows {
+		m.vals[r*m.c+cols[i]] = vals[i]
+	}
+	return m
+}
+
+/*
+ScatterAdd adds the passed values to the elements of the receiver at the
+passed rows and columns, in the same manner as Scatter, except that the
+values at a repeated position are summed. This is the update of sparse
+gradients, such as those of an embedding table.
+*/
+func (m *Matf64) ScatterAdd(rows, cols []int, vals []float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.ScatterAdd(rows, cols, vals) })
+	}
+	m.checkWritable("ScatterAdd()")
+	m.checkScatterIdx("ScatterAdd()", rows, cols, len(vals))
+	for i, r := range rows {
+		m.vals[r*m.c+cols[i]] += vals[i]
+	}
+	return m
+}
+
+func (m *Matf64) checkScatterIdx(fn string, rows, cols []int, n int) {
+	if len(rows) != len(cols) || len(rows) != n {
+		s := "\nIn %s, the rows, columns and values must have the same length,\n"
+		s += "however their lengths are %d, %d and %d."
+		s = fmt.Sprintf(s, fn, len(rows), len(cols), n)
+		printHelperErr(ErrShape, s)
+	}
+	for i, r := range rows {
+		if r < 0 || r >= m.r || cols[i] < 0 || cols[i] >= m.c {
+			s := "\nIn %s, position %d is row %d and column %d, which is outside\n"
+			s += "of the bounds of a %d by %d Matf64."
+			s = fmt.Sprintf(s, fn, i, r, cols[i], m.r, m.c)
+			printHelperErr(ErrBounds, s)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatherf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 2, 3,
+		4, 5, 6,
+	}, 2, 3)
+	assert.Equal(t, []float64{2, 4, 6, 2}, m.Gather([]int{0, 1, 1, 0}, []int{1, 0, 2, 1}), "should be equal")
+	assert.Equal(t, []float64{}, m.Gather(nil, nil), "should be empty")
+}
+
+func TestScatterf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 3)
+	m.Scatter([]int{0, 1, 0}, []int{1, 2, 1}, []float64{5, 6, 7})
+	assert.Equal(t, []float64{0, 7, 0, 0, 0, 6}, m.ToSlice1D(), "should keep the last value")
+	m.ScatterAdd([]int{0, 1, 0}, []int{1, 0, 1}, []float64{1, 2, 3})
+	assert.Equal(t, []float64{0, 11, 0, 2, 0, 6}, m.ToSlice1D(), "should sum repeated positions")
+}
@@ -0,0 +1,67 @@
+/*
+Package gonumconv converts between the Matf64 of package matrix and the
+matrices of gonum, so that the solvers and decompositions of gonum can be
+used on a Matf64 without copying it:
+
+	m := matrix.RandMatf64(3, 3)
+	var lu mat.LU
+	lu.Factorize(gonumconv.AsGonum(m))
+
+It is a separate package so that package matrix itself does not depend on
+gonum, which is not managed by the Gopkg files of this repository, and must
+be installed by the users of this package.
+*/
+package gonumconv
+
+import (
+	"github.com/NDari/matrix"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+AsGonum returns a gonum *mat.Dense which shares the underlying data of the
+passed Matf64. Since no copy is made, changes to the elements of the returned
+*mat.Dense are reflected in the Matf64, and vice versa. Note that methods that
+change the shape of the Matf64, such as AppendRow or Concat, may reallocate
+its underlying slice, after which the two no longer share their data.
+
+An empty Matf64 results in an empty *mat.Dense, as gonum does not allow for
+matrices with zero rows or columns to be created with mat.NewDense.
+*/
+func AsGonum(m *matrix.Matf64) *mat.Dense {
+	r, c := m.Shape()
+	if r == 0 || c == 0 {
+		return &mat.Dense{}
+	}
+	return mat.NewDense(r, c, m.RawRowMajor())
+}
+
+/*
+Matf64FromGonum creates a Matf64 from any gonum mat.Matrix. If the passed
+matrix exposes its raw data (for example a *mat.Dense) and its rows are
+contiguous in memory, the returned Matf64 shares the underlying data with it,
+and no copy is made:
+
+	d := mat.NewDense(2, 2, []float64{1, 2, 3, 4})
+	m := gonumconv.Matf64FromGonum(d)
+	m.Set(0, 0, 10.0) // d.At(0, 0) is now 10.0 as well
+
+In all other cases, such as a transposed or a sliced matrix, the elements
+are copied into a new Matf64.
+*/
+func Matf64FromGonum(a mat.Matrix) *matrix.Matf64 {
+	r, c := a.Dims()
+	if rm, ok := a.(mat.RawMatrixer); ok {
+		raw := rm.RawMatrix()
+		if r*c > 0 && raw.Stride == raw.Cols && len(raw.Data) >= r*c {
+			return matrix.Matf64FromRowMajor(raw.Data[:r*c], r, c)
+		}
+	}
+	data := make([]float64, r*c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			data[i*c+j] = a.At(i, j)
+		}
+	}
+	return matrix.Matf64FromRowMajor(data, r, c)
+}
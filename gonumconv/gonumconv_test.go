@@ -0,0 +1,56 @@
+package gonumconv
+
+import (
+	"testing"
+
+	"github.com/NDari/matrix"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestAsGonum(t *testing.T) {
+	t.Helper()
+	rows, cols := 4, 3
+	data := make([]float64, rows*cols)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	m := matrix.Matf64FromData(data, rows, cols)
+	d := AsGonum(m)
+	r, c := d.Dims()
+	assert.Equal(t, rows, r, "should be equal")
+	assert.Equal(t, cols, c, "should be equal")
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			assert.Equal(t, m.Get(i, j), d.At(i, j), "should be equal")
+		}
+	}
+	d.Set(1, 1, 100.0)
+	assert.Equal(t, 100.0, m.Get(1, 1), "changing the dense should change mat")
+
+	assert.True(t, AsGonum(matrix.Newf64()).IsEmpty(), "should be empty")
+}
+
+func TestMatf64FromGonum(t *testing.T) {
+	t.Helper()
+	d := mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	m := Matf64FromGonum(d)
+	r, c := m.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			assert.Equal(t, d.At(i, j), m.Get(i, j), "should be equal")
+		}
+	}
+	m.Set(0, 0, 10.0)
+	assert.Equal(t, 10.0, d.At(0, 0), "changing mat should change the dense")
+
+	n := Matf64FromGonum(d.T())
+	r, c = n.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	assert.True(t, n.Equals(m.T()), "should be equal")
+	n.Set(0, 0, 20.0)
+	assert.Equal(t, 10.0, d.At(0, 0), "a transpose should be copied")
+}
@@ -0,0 +1,126 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// The functions below treat a square Matf64 as the adjacency matrix of a
+// graph, where the element at row i and column j is the weight of the edge
+// from node i to node j, and 0 means that there is no such edge.
+
+/*
+DegreeMatrixf64 returns the degree matrix of the graph with the passed
+adjacency matrix, which is the diagonal matrix of the sums of the rows of
+the adjacency matrix. For a weighted graph, these are the sums of the weights
+of the edges leaving each node, and for a directed graph, the out-degrees.
+*/
+func DegreeMatrixf64(adj *Matf64) *Matf64 {
+	checkAdjacency("DegreeMatrixf64()", adj)
+	d := Newf64(adj.r, adj.c)
+	for i, v := range degrees(adj) {
+		d.vals[i*d.c+i] = v
+	}
+	return d
+}
+
+/*
+Laplacianf64 returns the Laplacian of the graph with the passed adjacency
+matrix, which is the degree matrix minus the adjacency matrix:
+
+	l := matrix.Laplacianf64(adj)
+
+For an undirected graph, the Laplacian is symmetric, and the number of its
+eigenvalues which are zero is the number of connected components of the
+graph.
+*/
+func Laplacianf64(adj *Matf64) *Matf64 {
+	checkAdjacency("Laplacianf64()", adj)
+	l := adj.Copy().Neg()
+	for i, v := range degrees(adj) {
+		l.vals[i*l.c+i] += v
+	}
+	return l
+}
+
+/*
+NormalizedLaplacianf64 returns the symmetric normalized Laplacian of the
+graph with the passed adjacency matrix, which is I - D^-1/2 A D^-1/2, where D
+is the degree matrix and A is the adjacency matrix. Its eigenvalues are
+between 0 and 2 for an undirected graph, which makes it the usual choice for
+spectral clustering. The rows and columns of nodes which have no edges are
+zero. The weights of the edges must not be negative.
+*/
+func NormalizedLaplacianf64(adj *Matf64) *Matf64 {
+	checkAdjacency("NormalizedLaplacianf64()", adj)
+	n := adj.r
+	scale := degrees(adj)
+	for i, v := range scale {
+		if v < 0 {
+			s := "\nIn matrix.%s, the weights of the edges must not be negative,\n"
+			s += "however the degree of node %d is %v."
+			s = fmt.Sprintf(s, "NormalizedLaplacianf64()", i, v)
+			printErr(ErrArgument, s)
+		}
+		if v > 0 {
+			scale[i] = 1.0 / math.Sqrt(v)
+		}
+	}
+	l := Newf64(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			l.vals[i*n+j] = -scale[i] * adj.vals[i*n+j] * scale[j]
+		}
+		if scale[i] > 0 {
+			l.vals[i*n+i] += 1.0
+		}
+	}
+	return l
+}
+
+/*
+IsConnectedf64 reports whether the graph with the passed adjacency matrix
+is connected, that is, whether every node can be reached from every other,
+following the edges in either direction. A directed graph is therefore
+checked for weak connectivity. A graph with a single node is connected.
+*/
+func IsConnectedf64(adj *Matf64) bool {
+	checkAdjacency("IsConnectedf64()", adj)
+	n := adj.r
+	seen := make([]bool, n)
+	seen[0] = true
+	stack := []int{0}
+	count := 1
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for j := 0; j < n; j++ {
+			if !seen[j] && (adj.vals[i*n+j] != 0 || adj.vals[j*n+i] != 0) {
+				seen[j] = true
+				stack = append(stack, j)
+				count++
+			}
+		}
+	}
+	return count == n
+}
+
+// degrees returns the sums of the rows of the adjacency matrix.
+func degrees(adj *Matf64) []float64 {
+	d := make([]float64, adj.r)
+	for i := range d {
+		for _, v := range adj.vals[i*adj.c : (i+1)*adj.c] {
+			d[i] += v
+		}
+	}
+	return d
+}
+
+func checkAdjacency(fn string, adj *Matf64) {
+	if adj.r != adj.c || adj.r == 0 {
+		s := "\nIn matrix.%s, the adjacency matrix must be square and not\n"
+		s += "empty, however it is %d by %d."
+		s = fmt.Sprintf(s, fn, adj.r, adj.c)
+		printHelperErr(ErrShape, s)
+	}
+}
@@ -0,0 +1,50 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphf64(t *testing.T) {
+	t.Helper()
+	// The path 0 - 1 - 2, and the isolated node 3.
+	adj := Matf64FromData([][]float64{
+		{0, 1, 0, 0},
+		{1, 0, 1, 0},
+		{0, 1, 0, 0},
+		{0, 0, 0, 0},
+	})
+	assert.Equal(t, []float64{
+		1, 0, 0, 0,
+		0, 2, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 0,
+	}, DegreeMatrixf64(adj).vals, "should be equal")
+	assert.Equal(t, []float64{
+		1, -1, 0, 0,
+		-1, 2, -1, 0,
+		0, -1, 1, 0,
+		0, 0, 0, 0,
+	}, Laplacianf64(adj).vals, "should be equal")
+
+	h := 1 / math.Sqrt(2)
+	want := Matf64FromData([][]float64{
+		{1, -h, 0, 0},
+		{-h, 1, -h, 0},
+		{0, -h, 1, 0},
+		{0, 0, 0, 0},
+	})
+	assertMatInDelta(t, want, NormalizedLaplacianf64(adj), 1e-12)
+
+	assert.False(t, IsConnectedf64(adj), "should not be connected")
+	adj.Set(3, 2, 1)
+	assert.True(t, IsConnectedf64(adj), "should be weakly connected")
+	assert.True(t, IsConnectedf64(Newf64(1, 1)), "should be connected")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { Laplacianf64(Newf64(2, 3)) }, "should panic")
+	neg := Matf64FromData([][]float64{{0, -1}, {-1, 0}})
+	assert.Panics(t, func() { NormalizedLaplacianf64(neg) }, "should panic")
+}
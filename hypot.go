@@ -0,0 +1,53 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Hypot sets each element of the receiver to math.Hypot of itself and the
+corresponding element of the passed Matf64, which must have the same shape.
+With x and y components in the receiver and n, the result is the magnitude:
+
+	mag := x.Copy().Hypot(y)
+*/
+func (m *Matf64) Hypot(n *Matf64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Hypot(n) })
+	}
+	m.checkWritable("Hypot()")
+	checkSameShape("Hypot()", m, n)
+	for i, v := range n.vals {
+		m.vals[i] = math.Hypot(m.vals[i], v)
+	}
+	return m
+}
+
+/*
+Atan2 sets each element of the receiver to math.Atan2 of itself and the
+corresponding element of the passed Matf64, which must have the same shape.
+As in math.Atan2, the receiver holds the y components and n the x components:
+
+	angle := y.Copy().Atan2(x)
+*/
+func (m *Matf64) Atan2(n *Matf64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Atan2(n) })
+	}
+	m.checkWritable("Atan2()")
+	checkSameShape("Atan2()", m, n)
+	for i, v := range n.vals {
+		m.vals[i] = math.Atan2(m.vals[i], v)
+	}
+	return m
+}
+
+func checkSameShape(fn string, m, n *Matf64) {
+	if m.r != n.r || m.c != n.c {
+		s := "\nIn %s, the receiver is %d by %d, while the passed Matf64 is\n"
+		s += "%d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, fn, m.r, m.c, n.r, n.c)
+		printHelperErr(ErrShape, s)
+	}
+}
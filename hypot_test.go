@@ -0,0 +1,27 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHypotf64(t *testing.T) {
+	t.Helper()
+	x := Matf64FromData([]float64{3, 0, -5, 1}, 2, 2)
+	y := Matf64FromData([]float64{4, 2, 12, 0}, 2, 2)
+	assert.Equal(t, []float64{5, 2, 13, 1}, x.Hypot(y).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{4, 2, 12, 0}, y.ToSlice1D(), "should not change y")
+}
+
+func TestAtan2f64(t *testing.T) {
+	t.Helper()
+	y := Matf64FromData([]float64{1, 0, -1, 1}, 2, 2)
+	x := Matf64FromData([]float64{0, -1, 0, 1}, 2, 2)
+	got := y.Atan2(x).ToSlice1D()
+	want := []float64{math.Pi / 2, math.Pi, -math.Pi / 2, math.Pi / 4}
+	for i := range want {
+		assert.InDelta(t, want[i], got[i], 1e-15, "should be equal")
+	}
+}
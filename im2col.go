@@ -0,0 +1,118 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+Im2Colf64 rearranges the kh by kw patches of an image, which are taken every
+stride pixels after padding the image with pad zeros on every side, into the
+columns of a Matf64. The returned Matf64 has kh*kw rows, and one column for
+each patch, in row major order of the patches. Each column holds the pixels
+of its patch, in row major order.
+
+This turns a 2D convolution (more precisely, a cross-correlation) into a
+single call to Dot:
+
+	cols := matrix.Im2Colf64(img, 3, 3, 1, 1)
+	out := kernel.Reshape(1, 9).Dot(cols)
+	outH, outW := matrix.ConvOutputSizef64(img, 3, 3, 1, 1)
+	out.Reshape(outH, outW)
+
+and several kernels can be applied at once by stacking them as the rows of
+the first argument of Dot.
+*/
+func Im2Colf64(m *Matf64, kh, kw, stride, pad int) *Matf64 {
+	checkConvParams("matrix.Im2Colf64()", m.r, m.c, kh, kw, stride, pad)
+	outH, outW := convOutputSize(m.r, m.c, kh, kw, stride, pad)
+	cols := Newf64(kh*kw, outH*outW)
+	for ki := 0; ki < kh; ki++ {
+		for kj := 0; kj < kw; kj++ {
+			row := cols.vals[(ki*kw+kj)*cols.c:]
+			for oi := 0; oi < outH; oi++ {
+				i := oi*stride + ki - pad
+				if i < 0 || i >= m.r {
+					continue
+				}
+				for oj := 0; oj < outW; oj++ {
+					j := oj*stride + kj - pad
+					if j >= 0 && j < m.c {
+						row[oi*outW+oj] = m.vals[i*m.c+j]
+					}
+				}
+			}
+		}
+	}
+	return cols
+}
+
+/*
+Col2Imf64 is the adjoint of Im2Colf64. It returns an h by w image, where each
+pixel is the sum of the elements of the passed Matf64 which Im2Colf64 would
+have copied from that pixel. Elements which fall in the padding are dropped.
+The passed Matf64 must have the shape returned by Im2Colf64 for an h by w
+image and the same parameters.
+
+When the patches do not overlap, Col2Imf64 undoes Im2Colf64. Otherwise, it is
+what is needed to backpropagate the gradient of a convolution to its input.
+*/
+func Col2Imf64(cols *Matf64, h, w, kh, kw, stride, pad int) *Matf64 {
+	checkConvParams("matrix.Col2Imf64()", h, w, kh, kw, stride, pad)
+	outH, outW := convOutputSize(h, w, kh, kw, stride, pad)
+	if cols.r != kh*kw || cols.c != outH*outW {
+		s := "\nIn matrix.%s, the passed Matf64 is %d by %d, however it must\n"
+		s += "be %d by %d for a %d by %d image with the passed parameters."
+		s = fmt.Sprintf(s, "Col2Imf64()", cols.r, cols.c, kh*kw, outH*outW, h, w)
+		printErr(ErrShape, s)
+	}
+	m := Newf64(h, w)
+	for ki := 0; ki < kh; ki++ {
+		for kj := 0; kj < kw; kj++ {
+			row := cols.vals[(ki*kw+kj)*cols.c:]
+			for oi := 0; oi < outH; oi++ {
+				i := oi*stride + ki - pad
+				if i < 0 || i >= h {
+					continue
+				}
+				for oj := 0; oj < outW; oj++ {
+					j := oj*stride + kj - pad
+					if j >= 0 && j < w {
+						m.vals[i*w+j] += row[oi*outW+oj]
+					}
+				}
+			}
+		}
+	}
+	return m
+}
+
+/*
+ConvOutputSizef64 returns the number of rows and columns of the output of a
+convolution of the passed image with a kh by kw kernel, with the passed
+stride and padding, which is also the number of patches along each axis in
+Im2Colf64.
+*/
+func ConvOutputSizef64(m *Matf64, kh, kw, stride, pad int) (int, int) {
+	checkConvParams("matrix.ConvOutputSizef64()", m.r, m.c, kh, kw, stride, pad)
+	return convOutputSize(m.r, m.c, kh, kw, stride, pad)
+}
+
+func convOutputSize(h, w, kh, kw, stride, pad int) (int, int) {
+	return (h+2*pad-kh)/stride + 1, (w+2*pad-kw)/stride + 1
+}
+
+func checkConvParams(fn string, h, w, kh, kw, stride, pad int) {
+	if kh < 1 || kw < 1 || stride < 1 || pad < 0 {
+		s := "\nIn %s, the kernel size and the stride must be positive, and\n"
+		s += "the padding cannot be negative, however a %d by %d kernel, a\n"
+		s += "stride of %d, and a padding of %d were received."
+		s = fmt.Sprintf(s, fn, kh, kw, stride, pad)
+		printHelperErr(ErrArgument, s)
+	}
+	if kh > h+2*pad || kw > w+2*pad {
+		s := "\nIn %s, the %d by %d kernel is larger than the %d by %d image\n"
+		s += "with a padding of %d."
+		s = fmt.Sprintf(s, fn, kh, kw, h, w, pad)
+		printHelperErr(ErrShape, s)
+	}
+}
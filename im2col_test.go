@@ -0,0 +1,60 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIm2Colf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	cols := Im2Colf64(m, 2, 2, 1, 0)
+	r, c := cols.Shape()
+	assert.Equal(t, 4, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+	assert.Equal(t, []float64{1, 2, 4, 5}, cols.Col(0).ToSlice1D(), "should be the first patch")
+	assert.Equal(t, []float64{5, 6, 8, 9}, cols.Col(3).ToSlice1D(), "should be the last patch")
+
+	padded := Im2Colf64(m, 3, 3, 2, 1)
+	r, c = padded.Shape()
+	assert.Equal(t, 9, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+	assert.Equal(t, []float64{0, 0, 0, 0, 1, 2, 0, 4, 5}, padded.Col(0).ToSlice1D(), "should be padded")
+}
+
+func TestIm2ColConvolutionf64(t *testing.T) {
+	t.Helper()
+	img := RandMatf64(5, 6)
+	kernel := RandMatf64(3, 3)
+	outH, outW := ConvOutputSizef64(img, 3, 3, 1, 1)
+	assert.Equal(t, 5, outH, "should be equal")
+	assert.Equal(t, 6, outW, "should be equal")
+	out := kernel.Copy().Reshape(1, 9).Dot(Im2Colf64(img, 3, 3, 1, 1)).Reshape(outH, outW)
+	for i := 0; i < outH; i++ {
+		for j := 0; j < outW; j++ {
+			want := 0.0
+			for ki := 0; ki < 3; ki++ {
+				for kj := 0; kj < 3; kj++ {
+					y, x := i+ki-1, j+kj-1
+					if y >= 0 && y < 5 && x >= 0 && x < 6 {
+						want += kernel.Get(ki, kj) * img.Get(y, x)
+					}
+				}
+			}
+			assert.InDelta(t, want, out.Get(i, j), 1e-12, "should be equal")
+		}
+	}
+}
+
+func TestCol2Imf64(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(4, 6)
+	assert.True(t, Col2Imf64(Im2Colf64(m, 2, 2, 2, 0), 4, 6, 2, 2, 2, 0).Equals(m), "should undo Im2Colf64")
+
+	ones := Newf64(4, 4).SetAll(1.0)
+	counts := Col2Imf64(Im2Colf64(ones, 3, 3, 1, 0), 4, 4, 3, 3, 1, 0)
+	assert.Equal(t, 1.0, counts.Get(0, 0), "should be covered by one patch")
+	assert.Equal(t, 4.0, counts.Get(1, 1), "should be covered by four patches")
+	assert.Equal(t, 2.0, counts.Get(0, 1), "should be covered by two patches")
+}
@@ -0,0 +1,137 @@
+package matrix
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// heatmapStops are the colors of the heatmap created by ToHeatmap, ordered
+// from the lowest to the highest value.
+var heatmapStops = []color.NRGBA{
+	{0, 0, 255, 255},
+	{0, 255, 255, 255},
+	{0, 255, 0, 255},
+	{255, 255, 0, 255},
+	{255, 0, 0, 255},
+}
+
+/*
+Matf64FromImage creates a Matf64 from an image, where each element of the
+Matf64 is the grayscale intensity of the corresponding pixel, in the range
+[0, 1]. The number of rows of the Matf64 is equal to the height of the image,
+and the number of columns is equal to its width:
+
+	f, _ := os.Open("photo.png")
+	img, _, _ := image.Decode(f)
+	m := matrix.Matf64FromImage(img)
+
+Colored images are converted to grayscale using the standard luminance
+weights of the image/color package.
+*/
+func Matf64FromImage(img image.Image) *Matf64 {
+	b := img.Bounds()
+	m := Newf64(b.Dy(), b.Dx())
+	idx := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			g := color.Gray16Model.Convert(img.At(x, y)).(color.Gray16)
+			m.vals[idx] = float64(g.Y) / math.MaxUint16
+			idx++
+		}
+	}
+	return m
+}
+
+/*
+ToImage returns a grayscale image of the receiver, where each element is
+mapped to a pixel. Values less than or equal to min are black, values greater
+than or equal to max are white, and values in between are scaled linearly:
+
+	img := m.ToImage(0.0, 1.0)
+	f, _ := os.Create("m.png")
+	png.Encode(f, img)
+
+The height of the returned image is equal to the number of rows of the
+receiver, and its width is equal to the number of columns. Note that min
+must be strictly less than max.
+*/
+func (m *Matf64) ToImage(min, max float64) *image.Gray {
+	if !(min < max) {
+		s := "\nIn %s, the first argument, %f, is not less than the\n"
+		s += "second argument, %f. The first argument must be strictly\n"
+		s += "less than the second.\n"
+		s = fmt.Sprintf(s, "ToImage()", min, max)
+		printErr(ErrArgument, s)
+	}
+	img := image.NewGray(image.Rect(0, 0, m.c, m.r))
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			t := scaleToUnit(m.vals[i*m.c+j], min, max)
+			img.SetGray(j, i, color.Gray{uint8(math.Round(t * 255))})
+		}
+	}
+	return img
+}
+
+/*
+ToHeatmap returns a colored image of the receiver, which is useful for
+visualizing the contents of a Matf64. Values less than or equal to min are
+blue, values greater than or equal to max are red, and values in between go
+through cyan, green and yellow:
+
+	_, lo := m.Min()
+	_, hi := m.Max()
+	img := m.ToHeatmap(lo, hi)
+
+The height of the returned image is equal to the number of rows of the
+receiver, and its width is equal to the number of columns. Note that min
+must be strictly less than max.
+*/
+func (m *Matf64) ToHeatmap(min, max float64) *image.NRGBA {
+	if !(min < max) {
+		s := "\nIn %s, the first argument, %f, is not less than the\n"
+		s += "second argument, %f. The first argument must be strictly\n"
+		s += "less than the second.\n"
+		s = fmt.Sprintf(s, "ToHeatmap()", min, max)
+		printErr(ErrArgument, s)
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, m.c, m.r))
+	segments := float64(len(heatmapStops) - 1)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			t := scaleToUnit(m.vals[i*m.c+j], min, max) * segments
+			k := int(t)
+			if k == len(heatmapStops)-1 {
+				k--
+			}
+			f := t - float64(k)
+			lo, hi := heatmapStops[k], heatmapStops[k+1]
+			img.SetNRGBA(j, i, color.NRGBA{
+				lerpUint8(lo.R, hi.R, f),
+				lerpUint8(lo.G, hi.G, f),
+				lerpUint8(lo.B, hi.B, f),
+				255,
+			})
+		}
+	}
+	return img
+}
+
+// scaleToUnit linearly maps v from [min, max] to [0, 1], clamping values
+// outside of that range. NaN values are mapped to 0.
+func scaleToUnit(v, min, max float64) float64 {
+	t := (v - min) / (max - min)
+	if t > 1.0 {
+		return 1.0
+	}
+	if !(t > 0.0) {
+		return 0.0
+	}
+	return t
+}
+
+func lerpUint8(a, b uint8, f float64) uint8 {
+	return uint8(math.Round(float64(a) + (float64(b)-float64(a))*f))
+}
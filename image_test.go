@@ -0,0 +1,50 @@
+package matrix
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64FromImage(t *testing.T) {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, 3, 2))
+	img.SetGray(0, 0, color.Gray{255})
+	img.SetGray(2, 1, color.Gray{51})
+	m := Matf64FromImage(img)
+	assert.Equal(t, 2, m.r, "rows should be the height")
+	assert.Equal(t, 3, m.c, "cols should be the width")
+	assert.Equal(t, 1.0, m.Get(0, 0), "should be equal")
+	assert.Equal(t, 0.0, m.Get(0, 1), "should be equal")
+	assert.InDelta(t, 0.2, m.Get(1, 2), 1e-12, "should be equal")
+}
+
+func TestToImagef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{-1.0, 0.0, 0.5, 1.0, 2.0, 0.25}, 2, 3)
+	img := m.ToImage(0.0, 1.0)
+	assert.Equal(t, image.Rect(0, 0, 3, 2), img.Bounds(), "should be equal")
+	assert.Equal(t, uint8(0), img.GrayAt(0, 0).Y, "should be clamped")
+	assert.Equal(t, uint8(0), img.GrayAt(1, 0).Y, "should be equal")
+	assert.Equal(t, uint8(128), img.GrayAt(2, 0).Y, "should be equal")
+	assert.Equal(t, uint8(255), img.GrayAt(0, 1).Y, "should be equal")
+	assert.Equal(t, uint8(255), img.GrayAt(1, 1).Y, "should be clamped")
+	assert.Equal(t, uint8(64), img.GrayAt(2, 1).Y, "should be equal")
+
+	n := Matf64FromImage(m.ToImage(-1.0, 2.0))
+	assert.InDelta(t, 0.0, n.Get(0, 0), 1e-2, "should round trip")
+	assert.InDelta(t, 1.0, n.Get(1, 1), 1e-2, "should round trip")
+}
+
+func TestToHeatmapf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{0.0, 0.5, 1.0, 0.25})
+	img := m.ToHeatmap(0.0, 1.0)
+	assert.Equal(t, image.Rect(0, 0, 4, 1), img.Bounds(), "should be equal")
+	assert.Equal(t, color.NRGBA{0, 0, 255, 255}, img.NRGBAAt(0, 0), "min should be blue")
+	assert.Equal(t, color.NRGBA{0, 255, 0, 255}, img.NRGBAAt(1, 0), "middle should be green")
+	assert.Equal(t, color.NRGBA{255, 0, 0, 255}, img.NRGBAAt(2, 0), "max should be red")
+	assert.Equal(t, color.NRGBA{0, 255, 255, 255}, img.NRGBAAt(3, 0), "should be cyan")
+}
@@ -0,0 +1,171 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+ImputeStrategy selects how the missing (NaN) elements of a Matf64 are filled
+by Impute.
+*/
+type ImputeStrategy int
+
+const (
+	// ImputeMean fills with the mean of the elements which are not NaN.
+	ImputeMean ImputeStrategy = iota
+	// ImputeMedian fills with the median of the elements which are not NaN.
+	ImputeMedian
+	// ImputeMode fills with the most frequent element which is not NaN, or the
+	// smallest of them in case of a tie.
+	ImputeMode
+	// ImputeConstant fills with a constant, which is passed to Impute.
+	ImputeConstant
+)
+
+/*
+Impute fills the NaN elements of a Matf64 by the passed strategy, applied to
+each row or each column. The axis has the same meaning as in Sum: 0 computes
+a fill value for each row, and 1 for each column. For ImputeConstant, the
+constant must be passed as an additional argument:
+
+	fill := m.Impute(matrix.ImputeMedian, 1) // fill each column with its median
+	m.Impute(matrix.ImputeConstant, 1, 0.0)  // fill with zeros
+
+The fill value of each row or column is returned, so that the same
+imputation can be applied to new data with FillNaN:
+
+	fill := train.Impute(matrix.ImputeMean, 1)
+	test.FillNaN(fill, 1)
+
+A row or column which only holds NaNs has a fill value of NaN, and is left
+unchanged, except by ImputeConstant.
+*/
+func (m *Matf64) Impute(strategy ImputeStrategy, axis int, args ...float64) []float64 {
+	m.checkWritable("Impute()")
+	count, n, step, stride := imputeLayout("Impute()", m, axis)
+	if strategy == ImputeConstant && len(args) != 1 {
+		s := "\nIn %s, ImputeConstant needs exactly one value to fill with,\n"
+		s += "however %d values were received."
+		s = fmt.Sprintf(s, "Impute()", len(args))
+		printErr(ErrArgument, s)
+	}
+	if strategy != ImputeConstant && len(args) != 0 {
+		s := "\nIn %s, only ImputeConstant takes a value to fill with, however\n"
+		s += "%d values were received."
+		s = fmt.Sprintf(s, "Impute()", len(args))
+		printErr(ErrArgument, s)
+	}
+	fill := make([]float64, count)
+	vals := make([]float64, 0, n)
+	for k := range fill {
+		vals = vals[:0]
+		for i := 0; i < n; i++ {
+			if v := m.vals[k*stride+i*step]; !math.IsNaN(v) {
+				vals = append(vals, v)
+			}
+		}
+		switch strategy {
+		case ImputeMean:
+			fill[k] = imputeMean(vals)
+		case ImputeMedian:
+			fill[k] = imputeMedian(vals)
+		case ImputeMode:
+			fill[k] = imputeMode(vals)
+		case ImputeConstant:
+			fill[k] = args[0]
+		default:
+			s := "\nIn %s, %d is not a known ImputeStrategy."
+			s = fmt.Sprintf(s, "Impute()", int(strategy))
+			printErr(ErrArgument, s)
+		}
+	}
+	m.FillNaN(fill, axis)
+	return fill
+}
+
+/*
+FillNaN replaces the NaN elements of each row (axis 0) or each column (axis 1)
+of a Matf64 with the corresponding element of the passed slice, which must
+have one element per row or column. This applies the fill values returned by
+Impute to new data.
+*/
+func (m *Matf64) FillNaN(fill []float64, axis int) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.FillNaN(fill, axis) })
+	}
+	m.checkWritable("FillNaN()")
+	count, n, step, stride := imputeLayout("FillNaN()", m, axis)
+	if len(fill) != count {
+		s := "\nIn %s, %d fill values are needed, however %d were received."
+		s = fmt.Sprintf(s, "FillNaN()", count, len(fill))
+		printErr(ErrShape, s)
+	}
+	for k, f := range fill {
+		for i := 0; i < n; i++ {
+			if idx := k*stride + i*step; math.IsNaN(m.vals[idx]) {
+				m.vals[idx] = f
+			}
+		}
+	}
+	return m
+}
+
+// imputeLayout returns the number of rows or columns along the passed axis,
+// the number of elements in each, the distance between their elements, and
+// the distance between their first elements.
+func imputeLayout(fn string, m *Matf64, axis int) (count, n, step, stride int) {
+	switch axis {
+	case 0:
+		return m.r, m.c, 1, m.c
+	case 1:
+		return m.c, m.r, m.c, 1
+	}
+	s := "\nIn %s, the axis must be 0 or 1, however %d was received."
+	s = fmt.Sprintf(s, fn, axis)
+	printHelperErr(ErrArgument, s)
+	return 0, 0, 0, 0
+}
+
+func imputeMean(vals []float64) float64 {
+	if len(vals) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range vals {
+		sum += v
+	}
+	return sum / float64(len(vals))
+}
+
+func imputeMedian(vals []float64) float64 {
+	if len(vals) == 0 {
+		return math.NaN()
+	}
+	sort.Float64s(vals)
+	mid := len(vals) / 2
+	if len(vals)%2 == 1 {
+		return vals[mid]
+	}
+	return (vals[mid-1] + vals[mid]) / 2
+}
+
+func imputeMode(vals []float64) float64 {
+	if len(vals) == 0 {
+		return math.NaN()
+	}
+	sort.Float64s(vals)
+	best, bestCount := vals[0], 0
+	for i := 0; i < len(vals); {
+		j := i
+		for j < len(vals) && vals[j] == vals[i] {
+			j++
+		}
+		if j-i > bestCount {
+			best, bestCount = vals[i], j-i
+		}
+		i = j
+	}
+	return best
+}
@@ -0,0 +1,55 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func imputeTestMat() *Matf64 {
+	nan := math.NaN()
+	return Matf64FromData([]float64{
+		1, nan, 5,
+		nan, 2, 5,
+		3, 2, nan,
+		8, 6, 1,
+	}, 4, 3)
+}
+
+func TestImputef64(t *testing.T) {
+	t.Helper()
+	m := imputeTestMat()
+	fill := m.Impute(ImputeMean, 1)
+	assert.Equal(t, []float64{4, 10.0 / 3.0, 11.0 / 3.0}, fill, "should be equal")
+	assert.Equal(t, []float64{1, 10.0 / 3.0, 5, 4, 2, 5, 3, 2, 11.0 / 3.0, 8, 6, 1}, m.ToSlice1D(), "should be equal")
+
+	m = imputeTestMat()
+	assert.Equal(t, []float64{3, 2, 5}, m.Impute(ImputeMedian, 1), "should be equal")
+	m = imputeTestMat()
+	assert.Equal(t, []float64{1, 2, 5}, m.Impute(ImputeMode, 1), "should be equal")
+	m = imputeTestMat()
+	assert.Equal(t, []float64{3, 3.5, 2.5, 6}, m.Impute(ImputeMedian, 0), "should be equal")
+	assert.Equal(t, 3.5, m.Get(1, 0), "should fill along rows")
+	m = imputeTestMat()
+	assert.Equal(t, []float64{-1, -1, -1}, m.Impute(ImputeConstant, 1, -1.0), "should be equal")
+	assert.Equal(t, -1.0, m.Get(2, 2), "should be equal")
+}
+
+func TestImputef64AllNaN(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{math.NaN(), 1, math.NaN(), 3}, 2, 2)
+	fill := m.Impute(ImputeMean, 1)
+	assert.True(t, math.IsNaN(fill[0]), "should be NaN")
+	assert.Equal(t, 2.0, fill[1], "should be equal")
+	assert.True(t, math.IsNaN(m.Get(0, 0)), "should be left unchanged")
+}
+
+func TestFillNaNf64(t *testing.T) {
+	t.Helper()
+	train := imputeTestMat()
+	fill := train.Impute(ImputeMedian, 1)
+	test := Matf64FromData([]float64{math.NaN(), 0, math.NaN()}, 1, 3)
+	test.FillNaN(fill, 1)
+	assert.Equal(t, []float64{3, 0, 5}, test.ToSlice1D(), "should be equal")
+}
@@ -0,0 +1,106 @@
+package matrix
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+Interp returns a new Matf64 in which each row (axis 0) or each column (axis
+1) of the receiver is linearly resampled to the passed length. The first and
+last elements are kept, and the new elements are evenly spaced between them.
+For example, to bring time series sampled at different rates, one per row,
+to a common length:
+
+	a := slow.Interp(100, 0)
+	b := fast.Interp(100, 0)
+	diff := a.Sub(b)
+
+The receiver is not changed.
+*/
+func (m *Matf64) Interp(newLen int, axis int) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Interp(newLen, axis) })
+	}
+	count, n, step, stride := imputeLayout("Interp()", m, axis)
+	if newLen < 1 || n == 0 {
+		s := "\nIn %s, the new length must be positive, and the receiver must\n"
+		s += "not be empty, however %d and a %d by %d receiver were received."
+		s = fmt.Sprintf(s, "Interp()", newLen, m.r, m.c)
+		printErr(ErrArgument, s)
+	}
+	var o *Matf64
+	ostep, ostride := 1, newLen
+	if axis == 0 {
+		o = Newf64(count, newLen)
+	} else {
+		o = Newf64(newLen, count)
+		ostep, ostride = count, 1
+	}
+	for k := 0; k < count; k++ {
+		for i := 0; i < newLen; i++ {
+			pos := 0.0
+			if newLen > 1 {
+				pos = float64(i) * float64(n-1) / float64(newLen-1)
+			}
+			j := int(pos)
+			v := m.vals[k*stride+j*step]
+			if j+1 < n {
+				frac := pos - float64(j)
+				v += frac * (m.vals[k*stride+(j+1)*step] - v)
+			}
+			o.vals[k*ostride+i*ostep] = v
+		}
+	}
+	return o
+}
+
+/*
+Interp1Df64 returns the piecewise linear interpolation of the points (x, y)
+at each element of xq. x and y must be row or column vectors of the same
+length, and x must be strictly increasing. The returned Matf64 has the shape
+of xq. Elements of xq outside of the range of x get the first or last element
+of y.
+*/
+func Interp1Df64(x, y, xq *Matf64) *Matf64 {
+	checkVector("Interp1Df64()", "x", x)
+	checkVector("Interp1Df64()", "y", y)
+	if len(x.vals) != len(y.vals) || len(x.vals) == 0 {
+		s := "\nIn matrix.%s, x and y must have the same, non-zero, number of\n"
+		s += "elements, however they have %d and %d."
+		s = fmt.Sprintf(s, "Interp1Df64()", len(x.vals), len(y.vals))
+		printErr(ErrShape, s)
+	}
+	for i := 1; i < len(x.vals); i++ {
+		if !(x.vals[i] > x.vals[i-1]) {
+			s := "\nIn matrix.%s, x must be strictly increasing, however element\n"
+			s += "%d is %v, and element %d is %v."
+			s = fmt.Sprintf(s, "Interp1Df64()", i-1, x.vals[i-1], i, x.vals[i])
+			printErr(ErrArgument, s)
+		}
+	}
+	n := len(x.vals)
+	o := xq.Copy()
+	for i, q := range o.vals {
+		j := sort.SearchFloat64s(x.vals, q)
+		switch {
+		case j == 0:
+			o.vals[i] = y.vals[0]
+		case j == n:
+			o.vals[i] = y.vals[n-1]
+		default:
+			frac := (q - x.vals[j-1]) / (x.vals[j] - x.vals[j-1])
+			o.vals[i] = y.vals[j-1] + frac*(y.vals[j]-y.vals[j-1])
+		}
+	}
+	return o
+}
+
+func checkVector(fn, name string, m *Matf64) {
+	if !m.isRowVector() && !m.isColVector() {
+		s := "\nIn matrix.%s, %s must be a row or a column vector, however a\n"
+		s += "%d by %d Matf64 was received."
+		s = fmt.Sprintf(s, fn, name, m.r, m.c)
+		printHelperErr(ErrShape, s)
+	}
+}
@@ -0,0 +1,42 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		0, 10, 20,
+		4, 2, 0,
+	}, 2, 3)
+	rows := m.Interp(5, 0)
+	assert.Equal(t, []float64{
+		0, 5, 10, 15, 20,
+		4, 3, 2, 1, 0,
+	}, rows.ToSlice1D(), "should be equal")
+	cols := m.Interp(3, 1)
+	r, c := cols.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	assert.Equal(t, []float64{
+		0, 10, 20,
+		2, 6, 10,
+		4, 2, 0,
+	}, cols.ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{0, 20, 4, 0}, m.Interp(2, 0).ToSlice1D(), "should keep the ends")
+}
+
+func TestInterp1Df64(t *testing.T) {
+	t.Helper()
+	x := Matf64FromData([]float64{0, 1, 3}, 1, 3)
+	y := Matf64FromData([]float64{0, 10, 30}, 3, 1)
+	xq := Matf64FromData([]float64{-1, 0, 0.5, 2, 3, 5}, 2, 3)
+	got := Interp1Df64(x, y, xq)
+	assert.Equal(t, []float64{0, 0, 5, 20, 30, 30}, got.ToSlice1D(), "should be equal")
+	r, c := got.Shape()
+	assert.Equal(t, 2, r, "should have the shape of xq")
+	assert.Equal(t, 3, c, "should have the shape of xq")
+}
@@ -0,0 +1,124 @@
+package matrix
+
+/*
+ElemIterf64 iterates over the elements of a Matf64 in row major order,
+without any index arithmetic on the side of the caller:
+
+	it := m.Iter()
+	for it.Next() {
+		r, c := it.Index()
+		fmt.Println(r, c, it.Value())
+	}
+
+The Matf64 must not change shape while it is being iterated over.
+*/
+type ElemIterf64 struct {
+	m *Matf64
+	i int
+}
+
+/*
+Iter returns an ElemIterf64 over the elements of the receiver.
+*/
+func (m *Matf64) Iter() *ElemIterf64 {
+	return &ElemIterf64{m, -1}
+}
+
+/*
+Next advances an ElemIterf64 to the next element, and reports whether there
+is one. It must be called before the first element is accessed.
+*/
+func (it *ElemIterf64) Next() bool {
+	if it.i < it.m.r*it.m.c {
+		it.i++
+	}
+	return it.i < it.m.r*it.m.c
+}
+
+/*
+Index returns the row and the column of the current element.
+*/
+func (it *ElemIterf64) Index() (int, int) {
+	return it.i / it.m.c, it.i % it.m.c
+}
+
+/*
+Value returns the current element.
+*/
+func (it *ElemIterf64) Value() float64 {
+	return it.m.vals[it.i]
+}
+
+/*
+Set sets the current element of the Matf64 to the passed value.
+*/
+func (it *ElemIterf64) Set(val float64) {
+	it.m.vals[it.i] = val
+}
+
+/*
+VecIterf64 iterates over the rows or the columns of a Matf64, in order:
+
+	it := m.IterRows()
+	for it.Next() {
+		fmt.Println(it.Index(), it.Vec().Sum())
+	}
+
+The Matf64 must not change shape while it is being iterated over.
+*/
+type VecIterf64 struct {
+	m    *Matf64
+	cols bool
+	i    int
+}
+
+/*
+IterRows returns a VecIterf64 over the rows of the receiver.
+*/
+func (m *Matf64) IterRows() *VecIterf64 {
+	return &VecIterf64{m, false, -1}
+}
+
+/*
+IterCols returns a VecIterf64 over the columns of the receiver.
+*/
+func (m *Matf64) IterCols() *VecIterf64 {
+	return &VecIterf64{m, true, -1}
+}
+
+func (it *VecIterf64) len() int {
+	if it.cols {
+		return it.m.c
+	}
+	return it.m.r
+}
+
+/*
+Next advances a VecIterf64 to the next row or column, and reports whether
+there is one. It must be called before the first row or column is accessed.
+*/
+func (it *VecIterf64) Next() bool {
+	if it.i < it.len() {
+		it.i++
+	}
+	return it.i < it.len()
+}
+
+/*
+Index returns the index of the current row or column.
+*/
+func (it *VecIterf64) Index() int {
+	return it.i
+}
+
+/*
+Vec returns a copy of the current row, as a row vector, or of the current
+column, as a column vector, in the same manner as the Row and Col methods of
+Matf64.
+*/
+func (it *VecIterf64) Vec() *Matf64 {
+	if it.cols {
+		return it.m.Col(it.i)
+	}
+	return it.m.Row(it.i)
+}
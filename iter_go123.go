@@ -0,0 +1,58 @@
+//go:build go1.23
+// +build go1.23
+
+package matrix
+
+import (
+	"iter"
+)
+
+/*
+Values returns an iterator over the elements of a Matf64 in row major order,
+for use with range:
+
+	for v := range m.Values() {
+		sum += v
+	}
+*/
+func (m *Matf64) Values() iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for it := m.Iter(); it.Next(); {
+			if !yield(it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Rows returns an iterator over the index and a copy of each row of a Matf64,
+for use with range:
+
+	for i, row := range m.Rows() {
+		fmt.Println(i, row.Sum())
+	}
+*/
+func (m *Matf64) Rows() iter.Seq2[int, *Matf64] {
+	return vecSeq(m.IterRows)
+}
+
+/*
+Cols returns an iterator over the index and a copy of each column of a
+Matf64, in the same manner as Rows.
+*/
+func (m *Matf64) Cols() iter.Seq2[int, *Matf64] {
+	return vecSeq(m.IterCols)
+}
+
+// vecSeq creates a new VecIterf64 for each use of the iterator, so that it
+// can be ranged over more than once.
+func vecSeq(newIter func() *VecIterf64) iter.Seq2[int, *Matf64] {
+	return func(yield func(int, *Matf64) bool) {
+		for it := newIter(); it.Next(); {
+			if !yield(it.Index(), it.Vec()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+//go:build go1.23
+// +build go1.23
+
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValuesf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	var vals []float64
+	for v := range m.Values() {
+		if v > 4 {
+			break
+		}
+		vals = append(vals, v)
+	}
+	assert.Equal(t, []float64{1, 2, 3, 4}, vals, "should stop at break")
+}
+
+func TestRowsColsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	rows := m.Rows()
+	for range 2 {
+		n := 0
+		for i, row := range rows {
+			assert.True(t, row.Equals(m.Row(i)), "should be equal")
+			n++
+		}
+		assert.Equal(t, 2, n, "should be reusable")
+	}
+	n := 0
+	for j, col := range m.Cols() {
+		assert.True(t, col.Equals(m.Col(j)), "should be equal")
+		n++
+	}
+	assert.Equal(t, 3, n, "should be equal")
+}
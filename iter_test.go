@@ -0,0 +1,52 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	var rows, cols []int
+	var vals []float64
+	it := m.Iter()
+	for it.Next() {
+		r, c := it.Index()
+		rows = append(rows, r)
+		cols = append(cols, c)
+		vals = append(vals, it.Value())
+		it.Set(it.Value() * 10)
+	}
+	assert.False(t, it.Next(), "should stay exhausted")
+	assert.Equal(t, []int{0, 0, 0, 1, 1, 1}, rows, "should be equal")
+	assert.Equal(t, []int{0, 1, 2, 0, 1, 2}, cols, "should be equal")
+	assert.Equal(t, []float64{1, 2, 3, 4, 5, 6}, vals, "should be equal")
+	assert.Equal(t, []float64{10, 20, 30, 40, 50, 60}, m.ToSlice1D(), "should set the elements")
+	assert.False(t, Newf64(0, 3).Iter().Next(), "should be empty")
+}
+
+func TestIterRowsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	var sums []float64
+	it := m.IterRows()
+	for it.Next() {
+		assert.True(t, it.Vec().Equals(m.Row(it.Index())), "should be equal")
+		sums = append(sums, it.Vec().Sum())
+	}
+	assert.Equal(t, []float64{6, 15}, sums, "should be equal")
+}
+
+func TestIterColsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	var sums []float64
+	it := m.IterCols()
+	for it.Next() {
+		assert.True(t, it.Vec().Equals(m.Col(it.Index())), "should be equal")
+		sums = append(sums, it.Vec().Sum())
+	}
+	assert.Equal(t, []float64{5, 7, 9}, sums, "should be equal")
+}
@@ -0,0 +1,151 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+DistanceMetric selects how the distance between two rows is measured.
+*/
+type DistanceMetric int
+
+const (
+	// Euclidean is the square root of the sum of squared differences.
+	Euclidean DistanceMetric = iota
+	// SqEuclidean is the sum of squared differences, which orders neighbors
+	// the same as Euclidean, without taking the square root.
+	SqEuclidean
+	// Manhattan is the sum of absolute differences.
+	Manhattan
+	// Chebyshev is the largest absolute difference.
+	Chebyshev
+)
+
+/*
+String returns the name of a DistanceMetric.
+*/
+func (d DistanceMetric) String() string {
+	switch d {
+	case Euclidean:
+		return "Euclidean"
+	case SqEuclidean:
+		return "SqEuclidean"
+	case Manhattan:
+		return "Manhattan"
+	case Chebyshev:
+		return "Chebyshev"
+	}
+	return fmt.Sprintf("DistanceMetric(%d)", int(d))
+}
+
+func (d DistanceMetric) dist(a, b []float64) float64 {
+	sum := 0.0
+	switch d {
+	case Euclidean, SqEuclidean:
+		for i := range a {
+			diff := a[i] - b[i]
+			sum += diff * diff
+		}
+		if d == Euclidean {
+			sum = math.Sqrt(sum)
+		}
+	case Manhattan:
+		for i := range a {
+			sum += math.Abs(a[i] - b[i])
+		}
+	case Chebyshev:
+		for i := range a {
+			sum = math.Max(sum, math.Abs(a[i]-b[i]))
+		}
+	}
+	return sum
+}
+
+/*
+PairwiseDistf64 returns a Matf64 whose element at row i and column j is the
+distance between row i of a and row j of b, measured with the passed metric.
+The two Matf64s must have the same number of columns.
+*/
+func PairwiseDistf64(a, b *Matf64, metric DistanceMetric) *Matf64 {
+	checkDistArgs("PairwiseDistf64()", a, b, metric)
+	d := Newf64(a.r, b.r)
+	parallelRows(a.r, a.r*b.r*a.c, MaxThreads(), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			ai := a.vals[i*a.c : (i+1)*a.c]
+			for j := 0; j < b.r; j++ {
+				d.vals[i*d.c+j] = metric.dist(ai, b.vals[j*b.c:(j+1)*b.c])
+			}
+		}
+	})
+	return d
+}
+
+func checkDistArgs(fn string, a, b *Matf64, metric DistanceMetric) {
+	if metric < Euclidean || metric > Chebyshev {
+		s := "\nIn matrix.%s, %v is not a known DistanceMetric."
+		s = fmt.Sprintf(s, fn, metric)
+		printHelperErr(ErrArgument, s)
+	}
+	if a.c != b.c {
+		s := "\nIn matrix.%s, the first Matf64 has %d columns, while the\n"
+		s += "second has %d. They must be equal."
+		s = fmt.Sprintf(s, fn, a.c, b.c)
+		printHelperErr(ErrShape, s)
+	}
+}
+
+/*
+ArgsortRow returns the indices of the columns of the passed row of a Matf64,
+in the order which sorts the elements of the row in ascending order. Equal
+elements keep their original order.
+*/
+func (m *Matf64) ArgsortRow(row int) []int {
+	if row < 0 || row >= m.r {
+		s := "\nIn %s, row %d is outside of the bounds [0, %d)\n"
+		s = fmt.Sprintf(s, "ArgsortRow()", row, m.r)
+		printErr(ErrBounds, s)
+	}
+	vals := m.vals[row*m.c : (row+1)*m.c]
+	idx := make([]int, m.c)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return vals[idx[i]] < vals[idx[j]]
+	})
+	return idx
+}
+
+/*
+KNNf64 finds the k rows of train which are nearest to each row of query,
+measured with the passed metric. It returns the indices of the neighbors, with
+one slice per query row, and a Matf64 of their distances, with one row per
+query row. The neighbors of each query row are ordered from the nearest to
+the farthest, and ties are broken by the lower index:
+
+	idx, dist := matrix.KNNf64(train, query, 5, matrix.Euclidean)
+	// idx[i][0] is the row of train which is nearest to query.Row(i),
+	// and dist.Get(i, 0) is its distance.
+
+k must be between 1 and the number of rows of train.
+*/
+func KNNf64(train, query *Matf64, k int, metric DistanceMetric) ([][]int, *Matf64) {
+	if k < 1 || k > train.r {
+		s := "\nIn matrix.%s, k must be in [1, %d], however %d was received."
+		s = fmt.Sprintf(s, "KNNf64()", train.r, k)
+		printErr(ErrArgument, s)
+	}
+	checkDistArgs("KNNf64()", query, train, metric)
+	d := PairwiseDistf64(query, train, metric)
+	idx := make([][]int, query.r)
+	dist := Newf64(query.r, k)
+	for i := range idx {
+		idx[i] = d.ArgsortRow(i)[:k:k]
+		for j, n := range idx[i] {
+			dist.vals[i*k+j] = d.vals[i*d.c+n]
+		}
+	}
+	return idx, dist
+}
@@ -0,0 +1,41 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairwiseDistf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{0, 0, 1, 1}, 2, 2)
+	b := Matf64FromData([]float64{3, 4, 1, 1, -1, 2}, 3, 2)
+	d := PairwiseDistf64(a, b, Euclidean)
+	assert.Equal(t, []float64{5, math.Sqrt(2), math.Sqrt(5), math.Sqrt(13), 0, math.Sqrt(5)}, d.ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{25, 2, 5, 13, 0, 5}, PairwiseDistf64(a, b, SqEuclidean).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{7, 2, 3, 5, 0, 3}, PairwiseDistf64(a, b, Manhattan).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{4, 1, 2, 3, 0, 2}, PairwiseDistf64(a, b, Chebyshev).ToSlice1D(), "should be equal")
+	assert.Equal(t, "Manhattan", Manhattan.String(), "should be equal")
+}
+
+func TestArgsortRowf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{3, 1, 2, 1, 0, 0, 0, 0}, 2, 4)
+	assert.Equal(t, []int{1, 3, 2, 0}, m.ArgsortRow(0), "should be stable")
+	assert.Equal(t, []int{0, 1, 2, 3}, m.ArgsortRow(1), "should be stable")
+}
+
+func TestKNNf64(t *testing.T) {
+	t.Helper()
+	train := Matf64FromData([]float64{0, 0, 10, 10, 1, 0, 0, 2}, 4, 2)
+	query := Matf64FromData([]float64{0.1, 0, 9, 9}, 2, 2)
+	idx, dist := KNNf64(train, query, 2, Euclidean)
+	assert.Equal(t, [][]int{{0, 2}, {1, 3}}, idx, "should be equal")
+	r, c := dist.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	assert.InDelta(t, 0.1, dist.Get(0, 0), 1e-12, "should be equal")
+	assert.InDelta(t, 0.9, dist.Get(0, 1), 1e-12, "should be equal")
+	assert.InDelta(t, math.Sqrt(2), dist.Get(1, 0), 1e-12, "should be equal")
+}
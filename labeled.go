@@ -0,0 +1,457 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+)
+
+/*
+Labeledf64 is a thin wrapper around a Matf64 which carries a name for each of
+its columns, and optionally for each of its rows. The rows and columns can be
+accessed by their names:
+
+	l := matrix.Matf64FromCSVWithHeader("people.csv")
+	ages := l.Col("age")
+	bob := l.Row("bob")
+
+Row, Col, Slice, Concat, AppendCol and AppendRow return Labeledf64s, whose
+names follow the data. The data can only be changed by these methods, so
+that the names always match it. A read-only view of the data is returned by
+Mat, for the other methods of Matf64.
+*/
+type Labeledf64 struct {
+	m        *Matf64
+	colNames []string
+	colIdx   map[string]int
+	rowNames []string
+	rowIdx   map[string]int
+	// colMeta is the metadata of each column, or nil if none was set.
+	colMeta []ColMeta
+}
+
+/*
+NewLabeledf64 wraps a copy of the passed Matf64 with the passed column names.
+The number of names must be equal to the number of columns of the Matf64, and
+the names must be unique. Row names can be added with SetRowNames.
+*/
+func NewLabeledf64(m *Matf64, colNames []string) *Labeledf64 {
+	names, idx := labelIndex("NewLabeledf64()", "column", colNames, m.c)
+	return &Labeledf64{m: m.Copy(), colNames: names, colIdx: idx}
+}
+
+/*
+Mat returns a read-only Matf64 which shares the data of a Labeledf64, for
+the methods of Matf64 which are not methods of Labeledf64:
+
+	total := l.Mat().Sum()
+	n := l.Mat().Copy() // a Matf64 which can be changed
+
+The methods which change the returned Matf64 fail with ErrReadOnly. It is a
+view of the data at the time of the call: a later change of the shape of the
+Labeledf64, such as by AppendRow, is not reflected in it.
+*/
+func (l *Labeledf64) Mat() *Matf64 {
+	n := l.m.r * l.m.c
+	return &Matf64{r: l.m.r, c: l.m.c, vals: l.m.vals[:n:n], readOnly: true}
+}
+
+// labelIndex checks that there are n unique names, and returns a copy of them
+// along with a map from each name to its position.
+func labelIndex(fn, kind string, names []string, n int) ([]string, map[string]int) {
+	if len(names) != n {
+		s := "\nIn %s, the number of %s names is %d, which does not match\n"
+		s += "the number of %ss, %d."
+		s = fmt.Sprintf(s, fn, kind, len(names), kind, n)
+		printHelperErr(ErrShape, s)
+	}
+	idx := make(map[string]int, len(names))
+	for i, name := range names {
+		if j, ok := idx[name]; ok {
+			s := "\nIn %s, the %s name \"%s\" is used by both %s %d and\n"
+			s += "%s %d. Names must be unique."
+			s = fmt.Sprintf(s, fn, kind, name, kind, j, kind, i)
+			printHelperErr(ErrArgument, s)
+		}
+		idx[name] = i
+	}
+	cp := make([]string, len(names))
+	copy(cp, names)
+	return cp, idx
+}
+
+/*
+Matf64FromCSVWithHeader creates a Labeledf64 from a CSV file whose first line
+is a header containing the names of the columns. The rest of the file is read
+in the same manner as Matf64FromCSV. For example, a file containing:
+
+	price,volume
+	10.5,300
+	11.0,120
+
+results in a 2 by 2 Matf64 whose columns are named "price" and "volume". If
+the first entry of the header is empty, the first column of the file holds the
+names of the rows:
+
+	,price,volume
+	mon,10.5,300
+	tue,11.0,120
+
+A name may be followed by the unit of the column in brackets, and optionally
+its scale, as in "length [m]" or "current [0.001 A]", which sets the ColMeta
+of the column, and is not part of its name.
+
+This is the format written by the ToCSV method of Labeledf64, so that the
+names and units survive a round trip through a file. The names must be unique.
+*/
+func Matf64FromCSVWithHeader(filename string) *Labeledf64 {
+	f, err := os.Open(filename)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromCSVWithHeader()", filename, err)
+		printErr(ErrIO, s)
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		s := "\nIn matrix.%s, cannot read the header of %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromCSVWithHeader()", filename, err)
+		printErr(ErrIO, s)
+	}
+	hasRowNames := len(header) > 0 && header[0] == ""
+	if hasRowNames {
+		header = header[1:]
+	}
+	var metas []ColMeta
+	for j := range header {
+		var meta ColMeta
+		header[j], meta = parseHeaderName("Matf64FromCSVWithHeader()", header[j])
+		if meta != (ColMeta{}) {
+			if metas == nil {
+				metas = make([]ColMeta, len(header))
+			}
+			metas[j] = meta
+		}
+	}
+	var rowNames []string
+	m := Newf64()
+	m.c = len(header)
+	row := make([]float64, len(header))
+	for {
+		str, err := r.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			s := "\nIn matrix.%s, cannot read from %s due to error: %v.\n"
+			s = fmt.Sprintf(s, "Matf64FromCSVWithHeader()", filename, err)
+			printErr(ErrIO, s)
+		}
+		if hasRowNames {
+			rowNames = append(rowNames, str[0])
+			str = str[1:]
+		}
+		for i := range str {
+			row[i], err = strconv.ParseFloat(str[i], 64)
+			if err != nil {
+				s := "\nIn matrix.%s, item %d in line %d is %s, which cannot\n"
+				s += "be converted to a float64 due to: %v"
+				s = fmt.Sprintf(s, "Matf64FromCSVWithHeader()", i, m.r+1, str[i], err)
+				printErr(ErrParse, s)
+			}
+		}
+		m.vals = append(m.vals, row...)
+		m.r++
+	}
+	names, idx := labelIndex("Matf64FromCSVWithHeader()", "column", header, m.c)
+	l := &Labeledf64{m: m, colNames: names, colIdx: idx, colMeta: metas}
+	if hasRowNames {
+		l.SetRowNames(rowNames)
+	}
+	return l
+}
+
+/*
+ToCSV writes a Labeledf64 to a file with the passed name, in the same format
+as the ToCSV method of Matf64, preceded by a header line with the names of the
+columns. If the Labeledf64 has row names, they are written as the first
+column, and the first entry of the header is left empty. The units and
+scales of the columns set by SetColMeta are written in brackets after their
+names. The file can be read back with Matf64FromCSVWithHeader.
+*/
+func (l *Labeledf64) ToCSV(fileName string) {
+	f, err := os.Create(fileName)
+	if err != nil {
+		s := "\nIn %s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "ToCSV()", fileName, err)
+		printErr(ErrIO, s)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	var record []string
+	if l.rowNames != nil {
+		record = append(record, "")
+	}
+	for j := range l.colNames {
+		record = append(record, l.headerName(j))
+	}
+	w.Write(record)
+	for i := 0; i < l.m.r; i++ {
+		record = record[:0]
+		if l.rowNames != nil {
+			record = append(record, l.rowNames[i])
+		}
+		for j := 0; j < l.m.c; j++ {
+			record = append(record, strconv.FormatFloat(l.m.vals[i*l.m.c+j], 'e', 14, 64))
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		s := "\nIn %s, cannot write to %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "ToCSV()", fileName, err)
+		printErr(ErrIO, s)
+	}
+}
+
+/*
+SetRowNames sets the names of the rows of a Labeledf64. The number of names
+must be equal to the number of rows, and the names must be unique. Passing nil
+removes the row names.
+*/
+func (l *Labeledf64) SetRowNames(rowNames []string) *Labeledf64 {
+	if rowNames == nil {
+		l.rowNames, l.rowIdx = nil, nil
+		return l
+	}
+	l.rowNames, l.rowIdx = labelIndex("SetRowNames()", "row", rowNames, l.m.r)
+	return l
+}
+
+/*
+ColNames returns the names of the columns of a Labeledf64, in order.
+*/
+func (l *Labeledf64) ColNames() []string {
+	names := make([]string, len(l.colNames))
+	copy(names, l.colNames)
+	return names
+}
+
+/*
+RowNames returns the names of the rows of a Labeledf64, in order, or nil if
+the rows are not named.
+*/
+func (l *Labeledf64) RowNames() []string {
+	if l.rowNames == nil {
+		return nil
+	}
+	names := make([]string, len(l.rowNames))
+	copy(names, l.rowNames)
+	return names
+}
+
+/*
+ColIndex returns the index of the column with the passed name, and whether
+such a column exists.
+*/
+func (l *Labeledf64) ColIndex(name string) (int, bool) {
+	i, ok := l.colIdx[name]
+	return i, ok
+}
+
+/*
+RowIndex returns the index of the row with the passed name, and whether such
+a row exists.
+*/
+func (l *Labeledf64) RowIndex(name string) (int, bool) {
+	i, ok := l.rowIdx[name]
+	return i, ok
+}
+
+// resolveLabel returns the non-negative index of a row or column, which is passed
+// either as its name or as an int. Negative ints index from the end.
+func resolveLabel(fn, kind string, intOrString interface{}, idx map[string]int, n int) int {
+	switch v := intOrString.(type) {
+	case int:
+		if v >= n || v < -n {
+			s := "\nIn %s, %s %d is outside of the bounds [-%d, %d)\n"
+			s = fmt.Sprintf(s, fn, kind, v, n, n)
+			printHelperErr(ErrBounds, s)
+		}
+		if v < 0 {
+			v += n
+		}
+		return v
+	case string:
+		i, ok := idx[v]
+		if !ok {
+			s := "\nIn %s, there is no %s named \"%s\".\n"
+			s = fmt.Sprintf(s, fn, kind, v)
+			printHelperErr(ErrBounds, s)
+		}
+		return i
+	default:
+		s := "\nIn %s, the %s must be an int or a string.\n"
+		s += "However, value of type  \"%v\" was received.\n"
+		s = fmt.Sprintf(s, fn, kind, reflect.TypeOf(v))
+		printHelperErr(ErrArgument, s)
+	}
+	return 0
+}
+
+/*
+ColByName returns a new Matf64 whose values are equal to the column with the
+passed name, in the same manner as the Col method of Matf64:
+
+	p := l.ColByName("price") // same as l.m.Col(0) if "price" is the first column
+*/
+func (l *Labeledf64) ColByName(name string) *Matf64 {
+	i, ok := l.colIdx[name]
+	if !ok {
+		s := "\nIn %s, there is no column named \"%s\".\n"
+		s = fmt.Sprintf(s, "ColByName()", name)
+		printErr(ErrBounds, s)
+	}
+	return l.m.Col(i)
+}
+
+/*
+Col returns a new Labeledf64 holding a copy of a single column of the
+receiver, which can be given by its name or its index. Negative indices are
+supported, as in the Col method of Matf64. The returned Labeledf64 keeps the
+name of the column, and the row names of the receiver:
+
+	ages := l.Col("age")
+	last := l.Col(-1)
+*/
+func (l *Labeledf64) Col(intOrString interface{}) *Labeledf64 {
+	j := resolveLabel("Col()", "column", intOrString, l.colIdx, l.m.c)
+	return l.Slice(0, l.m.r, j, j+1)
+}
+
+/*
+Row returns a new Labeledf64 holding a copy of a single row of the receiver,
+which can be given by its name or its index. Negative indices are supported,
+as in the Row method of Matf64. The returned Labeledf64 keeps the name of the
+row, if any, and the column names of the receiver.
+*/
+func (l *Labeledf64) Row(intOrString interface{}) *Labeledf64 {
+	i := resolveLabel("Row()", "row", intOrString, l.rowIdx, l.m.r)
+	return l.Slice(i, i+1, 0, l.m.c)
+}
+
+/*
+Slice returns a new Labeledf64 holding a copy of the rows in [rowStart,
+rowEnd) and the columns in [colStart, colEnd) of the receiver, along with
+their names:
+
+	s := l.Slice(0, 10, 1, 3) // the first 10 rows of the 2nd and 3rd columns
+*/
+func (l *Labeledf64) Slice(rowStart, rowEnd, colStart, colEnd int) *Labeledf64 {
+	if rowStart < 0 || rowEnd > l.m.r || rowStart > rowEnd ||
+		colStart < 0 || colEnd > l.m.c || colStart > colEnd {
+		s := "\nIn %s, rows [%d, %d) and columns [%d, %d) are not a valid\n"
+		s += "range of a %d by %d matrix."
+		s = fmt.Sprintf(s, "Slice()", rowStart, rowEnd, colStart, colEnd, l.m.r, l.m.c)
+		printErr(ErrBounds, s)
+	}
+	m := Newf64(rowEnd-rowStart, colEnd-colStart)
+	for i := range m.vals {
+		m.vals[i] = l.m.vals[(rowStart+i/m.c)*l.m.c+colStart+i%m.c]
+	}
+	n := &Labeledf64{m: m}
+	n.colNames, n.colIdx = labelIndex("Slice()", "column", l.colNames[colStart:colEnd], m.c)
+	if l.rowNames != nil {
+		n.rowNames, n.rowIdx = labelIndex("Slice()", "row", l.rowNames[rowStart:rowEnd], m.r)
+	}
+	if l.colMeta != nil {
+		n.colMeta = append([]ColMeta(nil), l.colMeta[colStart:colEnd]...)
+	}
+	return n
+}
+
+/*
+Concat merges the passed Labeledf64 to the right side of the receiver, in the
+same manner as the Concat method of Matf64, and appends its column names to
+those of the receiver, along with their ColMeta. The column names of the two
+must not overlap. If both have row names, they must be equal; if only the
+passed Labeledf64 has row names, the receiver takes them. The receiver is
+left unchanged if the two cannot be merged.
+*/
+func (l *Labeledf64) Concat(n *Labeledf64) *Labeledf64 {
+	if l.rowNames != nil && n.rowNames != nil {
+		for i := range l.rowNames {
+			if i >= len(n.rowNames) || l.rowNames[i] != n.rowNames[i] {
+				s := "\nIn %s, the row names of the receiver and the passed\n"
+				s += "Labeledf64 are not the same."
+				s = fmt.Sprintf(s, "Concat()")
+				printErr(ErrArgument, s)
+			}
+		}
+	}
+	colNames := append(l.ColNames(), n.colNames...)
+	names, idx := labelIndex("Concat()", "column", colNames, l.m.c+n.m.c)
+	var metas []ColMeta
+	if l.colMeta != nil || n.colMeta != nil {
+		metas = make([]ColMeta, l.m.c+n.m.c)
+		copy(metas, l.colMeta)
+		copy(metas[l.m.c:], n.colMeta)
+	}
+	l.m.Concat(n.m)
+	l.colNames, l.colIdx, l.colMeta = names, idx, metas
+	if l.rowNames == nil && n.rowNames != nil {
+		l.SetRowNames(n.rowNames)
+	}
+	return l
+}
+
+/*
+AppendCol appends a column with the passed name to the right side of a
+Labeledf64, in the same manner as the AppendCol method of Matf64. The name
+must not be used by another column. The column has no ColMeta.
+*/
+func (l *Labeledf64) AppendCol(name string, v []float64) *Labeledf64 {
+	names, idx := labelIndex("AppendCol()", "column", append(l.ColNames(), name), l.m.c+1)
+	l.m.AppendCol(v)
+	l.colNames, l.colIdx = names, idx
+	if l.colMeta != nil {
+		l.colMeta = append(l.colMeta, ColMeta{})
+	}
+	return l
+}
+
+/*
+AppendRow appends a row to the bottom of a Labeledf64, in the same manner as
+the AppendRow method of Matf64. If the Labeledf64 has row names, the name of
+the new row must be passed, and must not be used by another row. Otherwise,
+no name can be passed:
+
+	l.AppendRow([]float64{1.5, 20})        // no row names
+	l.AppendRow([]float64{1.5, 20}, "wed") // with row names
+*/
+func (l *Labeledf64) AppendRow(v []float64, rowName ...string) *Labeledf64 {
+	if l.rowNames != nil && len(rowName) != 1 {
+		s := "\nIn %s, %d row names were passed, however the receiver has\n"
+		s += "row names, so exactly one must be passed."
+		s = fmt.Sprintf(s, "AppendRow()", len(rowName))
+		printErr(ErrArgument, s)
+	}
+	if l.rowNames == nil && len(rowName) != 0 {
+		s := "\nIn %s, %d row names were passed, however the receiver has\n"
+		s += "no row names, so none can be passed."
+		s = fmt.Sprintf(s, "AppendRow()", len(rowName))
+		printErr(ErrArgument, s)
+	}
+	if l.rowNames == nil {
+		l.m.AppendRow(v)
+		return l
+	}
+	names, idx := labelIndex("AppendRow()", "row", append(l.RowNames(), rowName[0]), l.m.r+1)
+	l.m.AppendRow(v)
+	l.rowNames, l.rowIdx = names, idx
+	return l
+}
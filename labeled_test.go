@@ -0,0 +1,220 @@
+package matrix
+
+import (
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLabeledf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 3)
+	names := []string{"a", "b", "c"}
+	l := NewLabeledf64(m, names)
+	m.Set(0, 0, 1.0)
+	assert.Equal(t, 0.0, l.Mat().Get(0, 0), "should wrap a copy")
+	assert.Equal(t, names, l.ColNames(), "should be equal")
+	names[0] = "z"
+	assert.Equal(t, "a", l.ColNames()[0], "changing names should not effect l")
+	i, ok := l.ColIndex("c")
+	assert.True(t, ok, "should exist")
+	assert.Equal(t, 2, i, "should be equal")
+	_, ok = l.ColIndex("z")
+	assert.False(t, ok, "should not exist")
+}
+
+func TestMatf64FromCSVWithHeader(t *testing.T) {
+	t.Helper()
+	filename := "header_test.csv"
+	str := "price,volume,day\n10.5,300,1\n11.0,120,2"
+	if err := os.WriteFile(filename, []byte(str), 0644); err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		if err := os.Remove(filename); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	l := Matf64FromCSVWithHeader(filename)
+	assert.Equal(t, []string{"price", "volume", "day"}, l.ColNames(), "should be equal")
+	r, c := l.Mat().Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	assert.Equal(t, []float64{10.5, 300, 1, 11.0, 120, 2}, l.Mat().ToSlice1D(), "should be equal")
+}
+
+func TestColByNamef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	l := NewLabeledf64(m, []string{"a", "b", "c"})
+	assert.True(t, l.ColByName("b").Equals(m.Col(1)), "should be equal")
+	assert.True(t, l.ColByName("c").Equals(m.Col(-1)), "should be equal")
+}
+
+func TestSetRowNamesf64(t *testing.T) {
+	t.Helper()
+	l := NewLabeledf64(Newf64(2, 1), []string{"a"})
+	assert.Nil(t, l.RowNames(), "should have no row names")
+	l.SetRowNames([]string{"x", "y"})
+	assert.Equal(t, []string{"x", "y"}, l.RowNames(), "should be equal")
+	i, ok := l.RowIndex("y")
+	assert.True(t, ok, "should exist")
+	assert.Equal(t, 1, i, "should be equal")
+	l.SetRowNames(nil)
+	assert.Nil(t, l.RowNames(), "should have no row names")
+	_, ok = l.RowIndex("y")
+	assert.False(t, ok, "should not exist")
+}
+
+func TestLabeledColRowf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	l := NewLabeledf64(m, []string{"a", "b", "c"}).SetRowNames([]string{"x", "y"})
+	c := l.Col("b")
+	assert.True(t, c.Mat().Equals(m.Col(1)), "should be equal")
+	assert.Equal(t, []string{"b"}, c.ColNames(), "should keep the column name")
+	assert.Equal(t, []string{"x", "y"}, c.RowNames(), "should keep the row names")
+	assert.Equal(t, []string{"c"}, l.Col(-1).ColNames(), "should be equal")
+	r := l.Row("y")
+	assert.True(t, r.Mat().Equals(m.Row(1)), "should be equal")
+	assert.Equal(t, []string{"y"}, r.RowNames(), "should keep the row name")
+	assert.Equal(t, []string{"a", "b", "c"}, r.ColNames(), "should keep the column names")
+	assert.Equal(t, []string{"x"}, l.Row(0).RowNames(), "should be equal")
+}
+
+func TestLabeledSlicef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	l := NewLabeledf64(m, []string{"a", "b", "c"})
+	s := l.Slice(1, 3, 0, 2)
+	assert.Equal(t, []float64{4, 5, 7, 8}, s.Mat().ToSlice1D(), "should be equal")
+	assert.Equal(t, []string{"a", "b"}, s.ColNames(), "should be equal")
+	assert.Nil(t, s.RowNames(), "should have no row names")
+	s.m.Set(0, 0, 100.0)
+	assert.Equal(t, 4.0, m.Get(1, 0), "should be a copy")
+}
+
+func TestLabeledConcatf64(t *testing.T) {
+	t.Helper()
+	a := NewLabeledf64(Matf64FromData([]float64{1, 2}, 2, 1), []string{"a"})
+	b := NewLabeledf64(Matf64FromData([]float64{3, 4, 5, 6}, 2, 2), []string{"b", "c"})
+	b.SetRowNames([]string{"x", "y"})
+	a.Concat(b)
+	assert.Equal(t, []float64{1, 3, 4, 2, 5, 6}, a.Mat().ToSlice1D(), "should be equal")
+	assert.Equal(t, []string{"a", "b", "c"}, a.ColNames(), "should be equal")
+	assert.Equal(t, []string{"x", "y"}, a.RowNames(), "should take the row names")
+	assert.Equal(t, []float64{4, 6}, a.Col("c").Mat().ToSlice1D(), "should be equal")
+}
+
+func TestLabeledConcatFailsf64(t *testing.T) {
+	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
+	a := NewLabeledf64(Matf64FromData([]float64{1, 2}, 2, 1), []string{"a"})
+	b := NewLabeledf64(Matf64FromData([]float64{3, 4, 5}, 3, 1), []string{"b"})
+	b.SetColMeta("b", ColMeta{Unit: "m"})
+	assert.Panics(t, func() { a.Concat(b) }, "the shapes do not match")
+	assert.Equal(t, []string{"a"}, a.ColNames(), "should be unchanged")
+	assert.Equal(t, ColMeta{}, a.ColMeta(0), "should be unchanged")
+	assert.Nil(t, a.colMeta, "should be unchanged")
+	assert.Panics(t, func() { a.ColMeta(1) }, "should have a single column")
+}
+
+func TestLabeledAppendColf64(t *testing.T) {
+	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
+	l := NewLabeledf64(Matf64FromData([]float64{1, 2, 3, 4}, 2, 2), []string{"a", "b"})
+	l.SetColMeta("a", ColMeta{Unit: "m"})
+	l.AppendCol("c", []float64{5, 6})
+	assert.Equal(t, []string{"a", "b", "c"}, l.ColNames(), "should be equal")
+	assert.Equal(t, []float64{5, 6}, l.Col(2).Mat().ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{5, 6}, l.Col("c").Mat().ToSlice1D(), "should be equal")
+	assert.Equal(t, ColMeta{}, l.ColMeta("c"), "should have no ColMeta")
+	assert.Panics(t, func() { l.AppendCol("a", []float64{7, 8}) }, "names must be unique")
+	assert.Panics(t, func() { l.AppendCol("d", []float64{7}) }, "length must match")
+	assert.Equal(t, []string{"a", "b", "c"}, l.ColNames(), "should be unchanged")
+	r, c := l.Mat().Shape()
+	assert.Equal(t, []int{2, 3}, []int{r, c}, "should be unchanged")
+
+	filename := "labeled_append_test.csv"
+	defer os.Remove(filename)
+	l.ToCSV(filename)
+	n := Matf64FromCSVWithHeader(filename)
+	assert.Equal(t, []string{"a", "b", "c"}, n.ColNames(), "should write all the names")
+	assert.True(t, n.Mat().Equals(l.Mat()), "should be equal")
+}
+
+func TestLabeledAppendRowf64(t *testing.T) {
+	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
+	l := NewLabeledf64(Matf64FromData([]float64{1, 2}, 1, 2), []string{"a", "b"})
+	l.AppendRow([]float64{3, 4})
+	assert.Equal(t, []float64{3, 4}, l.Row(1).Mat().ToSlice1D(), "should be equal")
+	assert.Panics(t, func() { l.AppendRow([]float64{5, 6}, "z") }, "should not take a name")
+
+	l.SetRowNames([]string{"x", "y"})
+	l.AppendRow([]float64{5, 6}, "z")
+	assert.Equal(t, []string{"x", "y", "z"}, l.RowNames(), "should be equal")
+	assert.Equal(t, []float64{5, 6}, l.Row("z").Mat().ToSlice1D(), "should be equal")
+	assert.Panics(t, func() { l.AppendRow([]float64{7, 8}) }, "should need a name")
+	assert.Panics(t, func() { l.AppendRow([]float64{7, 8}, "x") }, "names must be unique")
+	assert.Equal(t, 3, len(l.RowNames()), "should be unchanged")
+	r, _ := l.Mat().Shape()
+	assert.Equal(t, 3, r, "should be unchanged")
+}
+
+func TestLabeledMatf64(t *testing.T) {
+	t.Helper()
+	l := NewLabeledf64(Matf64FromData([]float64{1, 2, 3, 4}, 2, 2), []string{"a", "b"})
+	v := l.Mat()
+	assert.True(t, v.IsReadOnly(), "should be read-only")
+	assert.Equal(t, 10.0, v.Sum(), "should be equal")
+	err := Catch(func() { v.Reshape(4, 1) })
+	assert.True(t, errors.Is(err, ErrReadOnly), "should not change the shape")
+	err = Catch(func() { v.Set(0, 0, 10.0) })
+	assert.True(t, errors.Is(err, ErrReadOnly), "should not change the data")
+	n := v.Copy()
+	n.Set(0, 0, 10.0)
+	assert.Equal(t, 1.0, l.Mat().Get(0, 0), "a copy should not change l")
+}
+
+func TestLabeledReshapef64(t *testing.T) {
+	t.Helper()
+	// Reshape and the other methods of Matf64 which change the shape are
+	// not promoted to Labeledf64, so they cannot desynchronize the names.
+	var l interface{} = NewLabeledf64(Newf64(2, 2), []string{"a", "b"})
+	_, ok := l.(interface{ Reshape(int, int) *Matf64 })
+	assert.False(t, ok, "should not have Reshape")
+	_, ok = l.(interface{ AppendCol([]float64) *Matf64 })
+	assert.False(t, ok, "should not have the AppendCol of Matf64")
+	_, ok = l.(interface {
+		DropOutliers(OutlierMethod, float64) *Matf64
+	})
+	assert.False(t, ok, "should not have DropOutliers")
+}
+
+func TestLabeledToCSVf64(t *testing.T) {
+	t.Helper()
+	filename := "labeled_test.csv"
+	defer func() {
+		if err := os.Remove(filename); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	m := Matf64FromData([]float64{1.5, 2, 3, 4}, 2, 2)
+	l := NewLabeledf64(m, []string{"price", "volume"})
+	l.ToCSV(filename)
+	n := Matf64FromCSVWithHeader(filename)
+	assert.True(t, n.Mat().Equals(m), "should be equal")
+	assert.Equal(t, l.ColNames(), n.ColNames(), "should be equal")
+	assert.Nil(t, n.RowNames(), "should have no row names")
+
+	l.SetRowNames([]string{"mon", "tue"})
+	l.ToCSV(filename)
+	n = Matf64FromCSVWithHeader(filename)
+	assert.True(t, n.Mat().Equals(m), "should be equal")
+	assert.Equal(t, l.ColNames(), n.ColNames(), "should be equal")
+	assert.Equal(t, []string{"mon", "tue"}, n.RowNames(), "should be equal")
+}
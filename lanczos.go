@@ -0,0 +1,208 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+/*
+PowerIterationf64 returns the eigenvalue of the passed square Matf64 which is
+largest in absolute value, and its eigenvector, as a column vector of unit
+length, found by power iteration:
+
+	rho, v := matrix.PowerIterationf64(a, 1e-10, 1000) // rho is the spectral radius, if positive
+
+Each iteration is one product by Dot, which is split between goroutines for
+large matrices, so it is suited to matrices which are too large for Eigen.
+The iteration stops when |Av - rho v| is at most tol times |rho|. It fails
+with ErrNoConvergence if this is not reached in maxIter iterations, which
+happens when the two largest eigenvalues are close in absolute value, or
+when the largest ones are complex, as for a rotation. The starting vector is
+random, from a fixed seed, so the results are reproducible.
+*/
+func PowerIterationf64(m *Matf64, tol float64, maxIter int) (float64, *Matf64) {
+	checkSquareEigen("matrix.PowerIterationf64()", m)
+	if !(tol > 0) || maxIter < 1 {
+		s := "\nIn matrix.%s, the tolerance and the number of iterations must be\n"
+		s += "positive, however %v and %d were received."
+		s = fmt.Sprintf(s, "PowerIterationf64()", tol, maxIter)
+		printErr(ErrArgument, s)
+	}
+	v := randomUnit(m.r, rand.New(rand.NewSource(1)))
+	for iter := 0; iter < maxIter; iter++ {
+		w := m.Dot(v)
+		lambda := dotSlices(v.vals, w.vals)
+		res := 0.0
+		for i, x := range w.vals {
+			d := x - lambda*v.vals[i]
+			res += d * d
+		}
+		if math.Sqrt(res) <= tol*math.Abs(lambda) || res == 0 {
+			return lambda, v
+		}
+		norm := math.Sqrt(dotSlices(w.vals, w.vals))
+		v = w.Scale(1.0 / norm)
+	}
+	s := "\nIn matrix.%s, the iteration did not converge to the tolerance %v\n"
+	s += "in %d iterations."
+	s = fmt.Sprintf(s, "PowerIterationf64()", tol, maxIter)
+	printErr(ErrNoConvergence, s)
+	return 0, nil
+}
+
+// lanczosTol is the residual, relative to the largest Ritz value, at which
+// TopEigen takes a Ritz pair to have converged.
+const lanczosTol = 1e-10
+
+/*
+TopEigen returns the k largest eigenvalues of a symmetric Matf64 as a row
+vector, in descending order, and the corresponding eigenvectors as the
+columns of an n by k Matf64:
+
+	vals, vecs := cov.TopEigen(10) // the 10 leading principal axes
+
+They are found by the method of Lanczos, which only needs products of the
+receiver with vectors, and stops as soon as the k eigenvalues have
+converged, so that it is much cheaper than Eigen for a large matrix and a
+small k. The Lanczos vectors are kept orthogonal to each other, which costs
+memory for one vector per iteration. The receiver must be symmetric, and k
+must be between 1 and the size of the receiver.
+*/
+func (m *Matf64) TopEigen(k int) (*Matf64, *Matf64) {
+	if m.deferErr {
+		var vals, vecs *Matf64
+		if !m.deferredDo(func() { vals, vecs = m.TopEigen(k) }) {
+			return m, m
+		}
+		vals.deferErr, vecs.deferErr = true, true
+		return vals, vecs
+	}
+	checkSquareEigen("TopEigen()", m)
+	n := m.r
+	if k < 1 || k > n {
+		s := "\nIn %s, k must be between 1 and %d, however %d was received."
+		s = fmt.Sprintf(s, "TopEigen()", n, k)
+		printErr(ErrArgument, s)
+	}
+	scale := 0.0
+	for _, v := range m.vals {
+		scale = math.Max(scale, math.Abs(v))
+	}
+	if !m.IsSymmetric(1e-12 * scale) {
+		s := "\nIn %s, the receiver must be symmetric."
+		s = fmt.Sprintf(s, "TopEigen()")
+		printErr(ErrArgument, s)
+	}
+	rng := rand.New(rand.NewSource(1))
+	q := []*Matf64{randomUnit(n, rng)}
+	var alpha, beta []float64
+	for j := 0; ; j++ {
+		w := m.Dot(q[j])
+		alpha = append(alpha, dotSlices(q[j].vals, w.vals))
+		// Orthogonalize against all Lanczos vectors, twice, rather than only
+		// the last two, to keep them orthogonal in floating point.
+		for pass := 0; pass < 2; pass++ {
+			for _, qi := range q {
+				w.AddScaled(-dotSlices(qi.vals, w.vals), qi)
+			}
+		}
+		b := math.Sqrt(dotSlices(w.vals, w.vals))
+		steps := j + 1
+		if steps == n {
+			return ritzPairs(q, alpha, beta, k)
+		}
+		if b <= 1e-12*scale {
+			// The Krylov subspace is invariant. Its Ritz pairs are exact,
+			// but there may be fewer than k of them, so the iteration
+			// continues from a new vector.
+			if steps >= k {
+				return ritzPairs(q, alpha, beta, k)
+			}
+			w = randomUnit(n, rng)
+			for pass := 0; pass < 2; pass++ {
+				for _, qi := range q {
+					w.AddScaled(-dotSlices(qi.vals, w.vals), qi)
+				}
+			}
+			w.Scale(1.0 / math.Sqrt(dotSlices(w.vals, w.vals)))
+			b = 0.0
+		} else {
+			w.Scale(1.0 / b)
+		}
+		if steps >= k && steps%5 == 0 && lanczosConverged(alpha, beta, b, k) {
+			return ritzPairs(q, alpha, beta, k)
+		}
+		beta = append(beta, b)
+		q = append(q, w)
+	}
+}
+
+// lanczosTridiag returns the eigenvalues and eigenvectors of the tridiagonal
+// matrix of the Lanczos iteration, with the passed diagonal and off diagonal.
+func lanczosTridiag(alpha, beta []float64) (*Matf64, *Matf64) {
+	t := NewSymMatf64(len(alpha))
+	for i, a := range alpha {
+		t.Set(i, i, a)
+		if i+1 < len(alpha) {
+			t.Set(i, i+1, beta[i])
+		}
+	}
+	return t.Eigen()
+}
+
+// lanczosConverged reports whether the k largest Ritz values of the Lanczos
+// iteration have converged, where b is the norm of the next Lanczos vector
+// before normalization.
+func lanczosConverged(alpha, beta []float64, b float64, k int) bool {
+	vals, vecs := lanczosTridiag(alpha, beta)
+	steps := len(alpha)
+	top := math.Max(math.Abs(vals.vals[0]), math.Abs(vals.vals[steps-1]))
+	for i := steps - k; i < steps; i++ {
+		// The residual of a Ritz pair is b times the last element of the
+		// eigenvector of the tridiagonal matrix.
+		if math.Abs(b*vecs.vals[(steps-1)*steps+i]) > lanczosTol*top {
+			return false
+		}
+	}
+	return true
+}
+
+// ritzPairs returns the k largest Ritz values, in descending order, and the
+// corresponding Ritz vectors, of the Lanczos vectors q.
+func ritzPairs(q []*Matf64, alpha, beta []float64, k int) (*Matf64, *Matf64) {
+	vals, vecs := lanczosTridiag(alpha, beta[:len(alpha)-1])
+	steps, n := len(alpha), q[0].r
+	topVals := Newf64(1, k)
+	topVecs := Newf64(n, k)
+	for c := 0; c < k; c++ {
+		i := steps - 1 - c
+		topVals.vals[c] = vals.vals[i]
+		for j, qj := range q {
+			y := vecs.vals[j*steps+i]
+			for r := 0; r < n; r++ {
+				topVecs.vals[r*k+c] += y * qj.vals[r]
+			}
+		}
+	}
+	return topVals, topVecs
+}
+
+// randomUnit returns a column vector of length n, with random elements drawn
+// from rng, and unit length.
+func randomUnit(n int, rng *rand.Rand) *Matf64 {
+	v := Newf64(n, 1)
+	for i := range v.vals {
+		v.vals[i] = rng.NormFloat64()
+	}
+	return v.Scale(1.0 / math.Sqrt(dotSlices(v.vals, v.vals)))
+}
+
+func checkSquareEigen(fn string, m *Matf64) {
+	if m.r != m.c || m.r == 0 {
+		s := "\nIn %s, the matrix must be square and not empty, however it is\n"
+		s += "%d by %d."
+		s = fmt.Sprintf(s, fn, m.r, m.c)
+		printHelperErr(ErrShape, s)
+	}
+}
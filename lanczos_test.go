@@ -0,0 +1,63 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerIterationf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{2, 1}, {1, 2}})
+	lambda, v := PowerIterationf64(a, 1e-12, 1000)
+	assert.InDelta(t, 3.0, lambda, 1e-10, "should be equal")
+	h := 1 / math.Sqrt(2)
+	assert.InDelta(t, h, math.Abs(v.Get(0, 0)), 1e-6, "should be equal")
+	assert.InDelta(t, h, math.Abs(v.Get(1, 0)), 1e-6, "should be equal")
+
+	// The dominant eigenvalue is negative.
+	b := Matf64FromData([][]float64{{-5, 0}, {0, 1}})
+	lambda, _ = PowerIterationf64(b, 1e-12, 1000)
+	assert.InDelta(t, -5.0, lambda, 1e-10, "should be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	rot := Matf64FromData([][]float64{{0, -1}, {1, 0}})
+	assert.Panics(t, func() { PowerIterationf64(rot, 1e-12, 100) }, "should panic")
+	err := Catch(func() { PowerIterationf64(rot, 1e-12, 100) })
+	assert.True(t, errors.Is(err, ErrNoConvergence), "should not converge")
+	assert.Panics(t, func() { PowerIterationf64(a, 0, 100) }, "should panic")
+	assert.Panics(t, func() { PowerIterationf64(Newf64(2, 3), 1e-9, 100) }, "should panic")
+}
+
+func TestTopEigenf64(t *testing.T) {
+	t.Helper()
+	x := RandMatf64(60, 40)
+	a := x.T().Dot(x)
+	want, _ := SymMatf64FromMatf64(a).Eigen()
+	vals, vecs := a.TopEigen(3)
+	r, c := vecs.Shape()
+	assert.Equal(t, 40, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	for i := 0; i < 3; i++ {
+		lambda := vals.Get(0, i)
+		assert.InDelta(t, want.Get(0, 39-i), lambda, 1e-8*want.Get(0, 39), "should be equal")
+		v := vecs.Col(i)
+		assert.InDelta(t, 1.0, math.Sqrt(v.Copy().Mul(v).Sum()), 1e-8, "should have unit length")
+		assertMatInDelta(t, v.Copy().Scale(lambda), a.Dot(v), 1e-6*lambda)
+	}
+
+	// An invariant subspace is found before k Ritz values.
+	id := Newf64(5, 5)
+	for i := 0; i < 5; i++ {
+		id.Set(i, i, 1)
+	}
+	vals, _ = id.TopEigen(3)
+	assertMatInDelta(t, Newf64(1, 3).SetAll(1), vals, 1e-12)
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { a.TopEigen(0) }, "should panic")
+	assert.Panics(t, func() { a.TopEigen(41) }, "should panic")
+	assert.Panics(t, func() { Matf64FromData([][]float64{{1, 2}, {0, 1}}).TopEigen(1) }, "should panic")
+}
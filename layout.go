@@ -0,0 +1,79 @@
+package matrix
+
+import "fmt"
+
+/*
+RawRowMajor returns the slice holding the elements of the receiver, in row
+major order, with a leading dimension (the distance between the starts of
+two rows) equal to the number of columns. The slice is not a copy: it
+aliases the storage of the Matf64, so changes to it are changes to the
+Matf64, and the other way around. This allows handing a Matf64 to BLAS
+through cgo, or to a GPU, without any copy:
+
+	r, c := m.Shape()
+	data := m.RawRowMajor()
+	// cblas_dgemm(CblasRowMajor, ..., &data[0], c, ...)
+
+The slice must not be appended to, and is no longer the storage of the
+Matf64 after an operation which grows it, such as AppendRow.
+*/
+func (m *Matf64) RawRowMajor() []float64 {
+	return m.vals[: m.r*m.c : m.r*m.c]
+}
+
+/*
+Matf64FromRowMajor returns an r by c Matf64 whose storage is the passed
+slice, which holds the elements in row major order, as returned by
+RawRowMajor. No copy is made, so changes to the slice are changes to the
+Matf64, and the other way around. This allows the data of another library to
+be used as a Matf64 without copying it. The slice must have r*c elements.
+*/
+func Matf64FromRowMajor(data []float64, r, c int) *Matf64 {
+	if r < 0 || c < 0 || len(data) != r*c {
+		s := "\nIn matrix.%s, a %d by %d Matf64 needs %d elements, however\n"
+		s += "%d were received."
+		s = fmt.Sprintf(s, "Matf64FromRowMajor()", r, c, r*c, len(data))
+		printErr(ErrShape, s)
+	}
+	m := Newf64()
+	m.r, m.c = r, c
+	m.vals = data[: r*c : r*c]
+	return m
+}
+
+/*
+ToColMajor returns a copy of the elements of the receiver in column major
+order, with a leading dimension equal to the number of rows, as expected by
+Fortran BLAS and LAPACK. Changes to the returned slice do not change the
+Matf64.
+*/
+func (m *Matf64) ToColMajor() []float64 {
+	s := make([]float64, m.r*m.c)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			s[j*m.r+i] = m.vals[i*m.c+j]
+		}
+	}
+	return s
+}
+
+/*
+Matf64FromColMajor returns an r by c Matf64 holding a copy of the passed
+elements, which are in column major order, as returned by ToColMajor or by
+Fortran BLAS and LAPACK. The slice must have r*c elements.
+*/
+func Matf64FromColMajor(data []float64, r, c int) *Matf64 {
+	if r < 0 || c < 0 || len(data) != r*c {
+		s := "\nIn matrix.%s, a %d by %d Matf64 needs %d elements, however\n"
+		s += "%d were received."
+		s = fmt.Sprintf(s, "Matf64FromColMajor()", r, c, r*c, len(data))
+		printErr(ErrShape, s)
+	}
+	m := Newf64(r, c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m.vals[i*c+j] = data[j*r+i]
+		}
+	}
+	return m
+}
@@ -0,0 +1,40 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawRowMajorf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	data := m.RawRowMajor()
+	assert.Equal(t, []float64{1, 2, 3, 4, 5, 6}, data, "should be equal")
+	assert.Equal(t, len(data), cap(data), "should not allow appending in place")
+	data[4] = 50
+	assert.Equal(t, 50.0, m.Get(1, 1), "should alias m")
+}
+
+func TestMatf64FromRowMajorf64(t *testing.T) {
+	t.Helper()
+	data := []float64{1, 2, 3, 4, 5, 6}
+	m := Matf64FromRowMajor(data, 2, 3)
+	assert.Equal(t, 3.0, m.Get(0, 2), "should be equal")
+	assert.Equal(t, 4.0, m.Get(1, 0), "should be equal")
+	data[4] = 50
+	assert.Equal(t, 50.0, m.Get(1, 1), "should alias data")
+	m.AppendRow([]float64{7, 8, 9})
+	assert.Equal(t, 50.0, data[4], "growing m should not change data")
+}
+
+func TestColMajorf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	data := m.ToColMajor()
+	assert.Equal(t, []float64{1, 4, 2, 5, 3, 6}, data, "should be equal")
+	data[0] = 10
+	assert.Equal(t, 1.0, m.Get(0, 0), "should be a copy")
+	data[0] = 1
+	assert.True(t, Matf64FromColMajor(data, 2, 3).Equals(m), "should round trip")
+}
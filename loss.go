@@ -0,0 +1,96 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+CrossEntropyf64 returns the softmax cross-entropy loss of the passed logits,
+with one sample per row and one class per column, against the passed target
+probabilities of the same shape, such as those returned by OneHotf64. The
+softmax of each row is fused with the logarithm, so that large logits do not
+overflow. The loss is averaged over the rows.
+
+If grad is not nil, it must have the same shape as the logits, and it is set
+to the gradient of the loss with respect to the logits, which is the softmax
+minus the target, divided by the number of rows:
+
+	grad := matrix.Newf64(n, k)
+	for epoch := 0; epoch < 100; epoch++ {
+		logits := x.Dot(w)
+		loss := matrix.CrossEntropyf64(logits, y, grad)
+		w.AddScaled(-rate, matrix.DotTf64(x, grad, true, false))
+	}
+*/
+func CrossEntropyf64(logits, target, grad *Matf64) float64 {
+	checkLossArgs("CrossEntropyf64()", logits, target, grad)
+	loss := 0.0
+	for i := 0; i < logits.r; i++ {
+		z := logits.vals[i*logits.c : (i+1)*logits.c]
+		t := target.vals[i*target.c : (i+1)*target.c]
+		max := math.Inf(-1)
+		for _, v := range z {
+			if v > max {
+				max = v
+			}
+		}
+		sum := 0.0
+		for _, v := range z {
+			sum += math.Exp(v - max)
+		}
+		lse := max + math.Log(sum)
+		for k, v := range z {
+			if t[k] != 0.0 {
+				loss -= t[k] * (v - lse)
+			}
+		}
+		if grad != nil {
+			g := grad.vals[i*grad.c : (i+1)*grad.c]
+			for k, v := range z {
+				g[k] = (math.Exp(v-lse) - t[k]) / float64(logits.r)
+			}
+		}
+	}
+	return loss / float64(logits.r)
+}
+
+/*
+MSEf64 returns the mean squared error between the passed predictions and
+targets, which must have the same shape, averaged over all elements. If grad
+is not nil, it must have the same shape as the predictions, and it is set to
+the gradient of the loss with respect to the predictions.
+*/
+func MSEf64(pred, target, grad *Matf64) float64 {
+	checkLossArgs("MSEf64()", pred, target, grad)
+	n := float64(len(pred.vals))
+	loss := 0.0
+	for i, p := range pred.vals {
+		d := p - target.vals[i]
+		loss += d * d
+		if grad != nil {
+			grad.vals[i] = 2.0 * d / n
+		}
+	}
+	return loss / n
+}
+
+func checkLossArgs(fn string, pred, target, grad *Matf64) {
+	if pred.r != target.r || pred.c != target.c {
+		s := "\nIn matrix.%s, the predictions are %d by %d, while the targets\n"
+		s += "are %d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, fn, pred.r, pred.c, target.r, target.c)
+		printHelperErr(ErrShape, s)
+	}
+	if grad != nil && (grad.r != pred.r || grad.c != pred.c) {
+		s := "\nIn matrix.%s, the gradient is %d by %d, while the predictions\n"
+		s += "are %d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, fn, grad.r, grad.c, pred.r, pred.c)
+		printHelperErr(ErrShape, s)
+	}
+	if pred.r == 0 || pred.c == 0 {
+		s := "\nIn matrix.%s, the predictions must not be empty."
+		s = fmt.Sprintf(s, fn)
+		printHelperErr(ErrArgument, s)
+	}
+}
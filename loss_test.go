@@ -0,0 +1,41 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossEntropyf64(t *testing.T) {
+	t.Helper()
+	logits := Matf64FromData([]float64{
+		1, 2, 3,
+		1000, 0, -1000,
+	}, 2, 3)
+	target := OneHotf64(Matf64FromData([]float64{2, 0}, 2, 1), 3)
+	grad := Newf64(2, 3)
+	loss := CrossEntropyf64(logits, target, grad)
+	lse := 3 + math.Log(math.Exp(-2)+math.Exp(-1)+1)
+	assert.InDelta(t, (lse-3)/2, loss, 1e-12, "should be equal")
+	assert.False(t, math.IsNaN(loss), "should not overflow")
+	// The gradient of each row sums to zero, and matches finite differences.
+	assert.InDelta(t, 0.0, grad.Row(0).Sum(), 1e-12, "should be equal")
+	assert.InDelta(t, 0.0, grad.Row(1).Sum(), 1e-12, "should be equal")
+	num := NumGradientf64(func(z *Matf64) float64 {
+		return CrossEntropyf64(z, target, nil)
+	}, logits.Copy().Mul(0.001), 1e-6)
+	exact := Newf64(2, 3)
+	CrossEntropyf64(logits.Copy().Mul(0.001), target, exact)
+	assertMatInDelta(t, exact, num, 1e-6)
+}
+
+func TestMSEf64(t *testing.T) {
+	t.Helper()
+	pred := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	target := Matf64FromData([]float64{1, 0, 3, 2}, 2, 2)
+	grad := Newf64(2, 2)
+	assert.Equal(t, 2.0, MSEf64(pred, target, grad), "should be equal")
+	assert.Equal(t, []float64{0, 1, 0, 1}, grad.ToSlice1D(), "should be equal")
+	assert.Equal(t, 2.0, MSEf64(pred, target, nil), "should be equal")
+}
@@ -0,0 +1,145 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+LowRank returns the best approximation of the receiver of rank at most k, in
+the sense of least squares, which is its truncated singular value
+decomposition. The factors are also returned: u is r by k with orthonormal
+columns, s is a 1 by k row vector of the singular values in descending order,
+and v is c by k with orthonormal columns, so that the approximation is equal
+to
+
+	u.Dot(matrix.NewDiagMatf64(s.ToSlice1D()).ToMatf64()).Dot(v.T())
+
+k must be between 1 and the smaller of the number of rows and of columns of
+the receiver, which is not changed. The decomposition is computed with the
+one-sided Jacobi method, which is accurate even for small singular values.
+*/
+func (m *Matf64) LowRank(k int) (approx, u, s, v *Matf64) {
+	if m.deferErr {
+		if !m.deferredDo(func() { approx, u, s, v = m.LowRank(k) }) {
+			return m, m, m, m
+		}
+		approx.deferErr, u.deferErr, s.deferErr, v.deferErr = true, true, true, true
+		return approx, u, s, v
+	}
+	if k < 1 || k > m.r || k > m.c {
+		s := "\nIn %s, the rank must be at least 1, and at most the number\n"
+		s += "of rows and of columns of the %d by %d receiver, however %d\n"
+		s += "was received."
+		s = fmt.Sprintf(s, "LowRank()", m.r, m.c, k)
+		printErr(ErrArgument, s)
+	}
+	uf, sf, vf := svdf64(m)
+	u, s, v = Newf64(m.r, k), Newf64(1, k), Newf64(m.c, k)
+	for j := 0; j < k; j++ {
+		s.vals[j] = sf[j]
+		for i := 0; i < m.r; i++ {
+			u.vals[i*k+j] = uf.vals[i*uf.c+j]
+		}
+		for i := 0; i < m.c; i++ {
+			v.vals[i*k+j] = vf.vals[i*vf.c+j]
+		}
+	}
+	approx = Newf64(m.r, m.c)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			sum := 0.0
+			for l := 0; l < k; l++ {
+				sum += u.vals[i*k+l] * s.vals[l] * v.vals[j*k+l]
+			}
+			approx.vals[i*m.c+j] = sum
+		}
+	}
+	return approx, u, s, v
+}
+
+// svdf64 returns the thin singular value decomposition of m, as u, which is
+// r by p, the p singular values in descending order, and v, which is c by p,
+// where p is the smaller of r and c.
+func svdf64(m *Matf64) (*Matf64, []float64, *Matf64) {
+	if m.r < m.c {
+		v, s, u := svdf64(m.Copy().T())
+		return u, s, v
+	}
+	// The columns of m are orthogonalized by plane rotations, which are
+	// accumulated in v. The columns are kept as the rows of w and of vt, so
+	// that they are contiguous.
+	r, c := m.r, m.c
+	w := m.Copy().T().vals
+	vt := make([]float64, c*c)
+	for i := 0; i < c; i++ {
+		vt[i*c+i] = 1.0
+	}
+	for sweep := 0; sweep < 60; sweep++ {
+		rotated := false
+		for p := 0; p < c-1; p++ {
+			for q := p + 1; q < c; q++ {
+				wp, wq := w[p*r:(p+1)*r], w[q*r:(q+1)*r]
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for i := range wp {
+					alpha += wp[i] * wp[i]
+					beta += wq[i] * wq[i]
+					gamma += wp[i] * wq[i]
+				}
+				if gamma == 0.0 || math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				zeta := (beta - alpha) / (2.0 * gamma)
+				t := 1.0 / (math.Abs(zeta) + math.Sqrt(1.0+zeta*zeta))
+				if zeta < 0.0 {
+					t = -t
+				}
+				cs := 1.0 / math.Sqrt(1.0+t*t)
+				sn := cs * t
+				rotatePair(wp, wq, cs, sn)
+				rotatePair(vt[p*c:(p+1)*c], vt[q*c:(q+1)*c], cs, sn)
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	sv := make([]float64, c)
+	order := make([]int, c)
+	for j := range sv {
+		sv[j] = math.Sqrt(dotSlices(w[j*r:(j+1)*r], w[j*r:(j+1)*r]))
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool { return sv[order[a]] > sv[order[b]] })
+	u, v, s := Newf64(r, c), Newf64(c, c), make([]float64, c)
+	for jj, j := range order {
+		s[jj] = sv[j]
+		for i := 0; i < r; i++ {
+			if sv[j] != 0.0 {
+				u.vals[i*c+jj] = w[j*r+i] / sv[j]
+			}
+		}
+		for i := 0; i < c; i++ {
+			v.vals[i*c+jj] = vt[j*c+i]
+		}
+	}
+	return u, s, v
+}
+
+func rotatePair(x, y []float64, c, s float64) {
+	for i := range x {
+		xi, yi := x[i], y[i]
+		x[i] = c*xi - s*yi
+		y[i] = s*xi + c*yi
+	}
+}
+
+func dotSlices(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
@@ -0,0 +1,42 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLowRankf64(t *testing.T) {
+	t.Helper()
+	// A rank 2 matrix is reproduced exactly with k = 2.
+	a := Matf64FromData([]float64{1, 2, 0, 1}, 4, 1).Dot(Matf64FromData([]float64{1, 0, 2}, 1, 3))
+	a.Add(Matf64FromData([]float64{0, 1, 1, -1}, 4, 1).Dot(Matf64FromData([]float64{0, 3, 1}, 1, 3)))
+	approx, u, s, v := a.LowRank(2)
+	assertMatInDelta(t, a, approx, 1e-12)
+	assertMatInDelta(t, u.Dot(matDiag(s)).Dot(v.T()), approx, 1e-12)
+	assertMatInDelta(t, identityf64(2), DotTf64(u, u, true, false), 1e-12)
+	assertMatInDelta(t, identityf64(2), DotTf64(v, v, true, false), 1e-12)
+	assert.True(t, s.Get(0, 0) >= s.Get(0, 1), "should be descending")
+
+	// With k = 1, the error is the second singular value, in the 2-norm,
+	// and the Frobenius error is smaller than for any other rank 1 matrix.
+	b := Matf64FromData([]float64{
+		3, 0,
+		0, 2,
+		0, 0,
+	}, 3, 2)
+	approx, _, s, _ = b.LowRank(1)
+	assertMatInDelta(t, Matf64FromData([]float64{3, 0, 0, 0, 0, 0}, 3, 2), approx, 1e-12)
+	assert.InDelta(t, 3.0, s.Get(0, 0), 1e-12, "should be equal")
+
+	// Wide matrices are decomposed through their transpose.
+	approx, u, _, v = b.Copy().T().LowRank(2)
+	assertMatInDelta(t, b.Copy().T(), approx, 1e-12)
+	ur, uc := u.Shape()
+	vr, vc := v.Shape()
+	assert.Equal(t, []int{2, 2, 3, 2}, []int{ur, uc, vr, vc}, "should be equal")
+}
+
+func matDiag(s *Matf64) *Matf64 {
+	return NewDiagMatf64(s.ToSlice1D()).ToMatf64()
+}
@@ -0,0 +1,138 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// stochasticTol is the tolerance of StepChainf64 on the sums of the rows of
+// the transition matrix.
+const stochasticTol = 1e-9
+
+/*
+StationaryDistributionf64 returns the stationary distribution of the Markov
+chain with the passed transition matrix, as a row vector pi such that
+pi.Dot(p) equals pi, and the elements of pi add up to 1:
+
+	p := matrix.Matf64FromData([][]float64{
+		{0.9, 0.1},
+		{0.5, 0.5},
+	})
+	pi := matrix.StationaryDistributionf64(p, 1e-9) // [0.8333 0.1667]
+
+The transition matrix must be row stochastic: square, with no negative
+elements, and with rows which add up to 1, within the passed tolerance. The
+distribution is found by solving the linear system, rather than by
+iteration, so periodic chains are handled, but the chain must have a single
+stationary distribution, which is the case if every state can be reached
+from every other. Otherwise, the system is singular, and the failure is an
+*ErrSingular.
+*/
+func StationaryDistributionf64(p *Matf64, tol float64) *Matf64 {
+	checkStochastic("StationaryDistributionf64()", p, tol)
+	n := p.r
+	// pi (P - I) = 0 is solved as (P - I)^T pi^T = 0, with the last of the
+	// equations, which depends on the others, replaced by sum(pi) = 1.
+	a := p.T()
+	for i := 0; i < n; i++ {
+		a.vals[i*n+i] -= 1.0
+	}
+	for j := 0; j < n; j++ {
+		a.vals[(n-1)*n+j] = 1.0
+	}
+	b := Newf64(n, 1)
+	b.vals[n-1] = 1.0
+	f, err := a.lu("StationaryDistributionf64()")
+	if err != nil {
+		s := "\nIn matrix.%s, the chain does not have a single stationary\n"
+		s += "distribution, as some of its states cannot be reached from the\n"
+		s += "others."
+		s = fmt.Sprintf(s, "StationaryDistributionf64()")
+		printErr(err, s)
+	}
+	pi := f.solve(b)
+	pi.r, pi.c = 1, n
+	return pi
+}
+
+/*
+StepChainf64 advances the distribution over the states of the Markov chain
+with the passed transition matrix by n steps, and returns the new
+distribution as a row vector. The state is a row or a column vector with one
+element per state, which is usually a distribution, or a vector with a 1 at
+the current state and 0 elsewhere:
+
+	state := matrix.Matf64FromData([]float64{1, 0}, 1, 2)
+	matrix.StepChainf64(p, state, 3) // the distribution after 3 steps
+
+The transition matrix is checked in the same manner as in
+StationaryDistributionf64, with a tolerance of 1e-9. The passed state is not
+changed.
+*/
+func StepChainf64(p, state *Matf64, n int) *Matf64 {
+	checkStochastic("StepChainf64()", p, stochasticTol)
+	checkVector("StepChainf64()", "the state", state)
+	if len(state.vals) != p.r || n < 0 {
+		s := "\nIn matrix.%s, the transition matrix is %d by %d, so the state\n"
+		s += "must have %d elements, and the number of steps must not be\n"
+		s += "negative, however the state has %d elements and %d steps were\n"
+		s += "requested."
+		s = fmt.Sprintf(s, "StepChainf64()", p.r, p.c, p.r, len(state.vals), n)
+		printErr(ErrShape, s)
+	}
+	cur := Newf64(1, p.r)
+	copy(cur.vals, state.vals)
+	next := Newf64(1, p.r)
+	for step := 0; step < n; step++ {
+		for j := range next.vals {
+			next.vals[j] = 0.0
+		}
+		for i, v := range cur.vals {
+			if v == 0.0 {
+				continue
+			}
+			row := p.vals[i*p.c : (i+1)*p.c]
+			for j, pij := range row {
+				next.vals[j] += v * pij
+			}
+		}
+		cur, next = next, cur
+	}
+	return cur
+}
+
+// checkStochastic checks that p is a row stochastic matrix, with rows which
+// add up to 1 within tol.
+func checkStochastic(fn string, p *Matf64, tol float64) {
+	if !(tol >= 0) {
+		s := "\nIn matrix.%s, the tolerance must not be negative, however %v\n"
+		s += "was received."
+		s = fmt.Sprintf(s, fn, tol)
+		printHelperErr(ErrArgument, s)
+	}
+	if p.r != p.c || p.r == 0 {
+		s := "\nIn matrix.%s, the transition matrix must be square and not\n"
+		s += "empty, however it is %d by %d."
+		s = fmt.Sprintf(s, fn, p.r, p.c)
+		printHelperErr(ErrShape, s)
+	}
+	for i := 0; i < p.r; i++ {
+		sum := 0.0
+		for j, v := range p.vals[i*p.c : (i+1)*p.c] {
+			if !(v >= 0) {
+				s := "\nIn matrix.%s, the elements of the transition matrix must\n"
+				s += "not be negative, however the element at row %d, column %d\n"
+				s += "is %v."
+				s = fmt.Sprintf(s, fn, i, j, v)
+				printHelperErr(ErrArgument, s)
+			}
+			sum += v
+		}
+		if math.Abs(sum-1.0) > tol {
+			s := "\nIn matrix.%s, the rows of the transition matrix must add up\n"
+			s += "to 1, however row %d adds up to %v."
+			s = fmt.Sprintf(s, fn, i, sum)
+			printHelperErr(ErrArgument, s)
+		}
+	}
+}
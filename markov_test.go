@@ -0,0 +1,51 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStationaryDistributionf64(t *testing.T) {
+	t.Helper()
+	p := Matf64FromData([][]float64{{0.9, 0.1}, {0.5, 0.5}})
+	pi := StationaryDistributionf64(p, 1e-9)
+	assertMatInDelta(t, Matf64FromData([]float64{5.0 / 6, 1.0 / 6}, 1, 2), pi, 1e-12)
+	assertMatInDelta(t, pi, pi.Dot(p), 1e-12)
+
+	// A periodic chain, on which power iteration does not converge.
+	q := Matf64FromData([][]float64{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}})
+	pi = StationaryDistributionf64(q, 1e-9)
+	assertMatInDelta(t, Matf64FromData([]float64{1.0 / 3, 1.0 / 3, 1.0 / 3}, 1, 3), pi, 1e-12)
+
+	defer SetPanicMode(SetPanicMode(true))
+	reducible := Matf64FromData([][]float64{{1, 0}, {0, 1}})
+	assert.Panics(t, func() { StationaryDistributionf64(reducible, 1e-9) }, "should panic")
+	err := Catch(func() { StationaryDistributionf64(reducible, 1e-9) })
+	var sing *ErrSingular
+	assert.True(t, errors.As(err, &sing), "should be singular")
+	assert.Equal(t, "StationaryDistributionf64()", sing.Op, "should be equal")
+	assert.Panics(t, func() { StationaryDistributionf64(Newf64(2, 3), 1e-9) }, "should panic")
+	notStochastic := Matf64FromData([][]float64{{0.5, 0.6}, {0.5, 0.5}})
+	assert.Panics(t, func() { StationaryDistributionf64(notStochastic, 1e-9) }, "should panic")
+	assert.NotPanics(t, func() { StationaryDistributionf64(notStochastic, 0.2) }, "should not panic")
+	negative := Matf64FromData([][]float64{{1.5, -0.5}, {0.5, 0.5}})
+	assert.Panics(t, func() { StationaryDistributionf64(negative, 1e-9) }, "should panic")
+}
+
+func TestStepChainf64(t *testing.T) {
+	t.Helper()
+	p := Matf64FromData([][]float64{{0.9, 0.1}, {0.5, 0.5}})
+	state := Matf64FromData([]float64{1, 0}, 2, 1)
+	assert.Equal(t, []float64{1, 0}, StepChainf64(p, state, 0).vals, "should be equal")
+	assertMatInDelta(t, Matf64FromData([]float64{0.9, 0.1}, 1, 2), StepChainf64(p, state, 1), 1e-12)
+	assertMatInDelta(t, Matf64FromData([]float64{0.86, 0.14}, 1, 2), StepChainf64(p, state, 2), 1e-12)
+	assertMatInDelta(t, StationaryDistributionf64(p, 1e-9), StepChainf64(p, state, 200), 1e-9)
+	assert.Equal(t, []float64{1, 0}, state.vals, "should not change the state")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { StepChainf64(p, Newf64(1, 3), 1) }, "should panic")
+	assert.Panics(t, func() { StepChainf64(p, state, -1) }, "should panic")
+	assert.Panics(t, func() { StepChainf64(p, Newf64(2, 2), 1) }, "should panic")
+}
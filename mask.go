@@ -0,0 +1,300 @@
+package matrix
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+)
+
+/*
+Mask is a matrix of booleans, such as the result of comparing the elements of
+a Matf64 to a value. Each element of a Mask is stored in a single bit, which
+takes 64 times less memory than storing the same information in a Matf64.
+
+Masks are created by the comparison methods of Matf64, such as Gt and Le, and
+can be combined with And, Or, Xor and Not:
+
+	m := matrix.RandMatf64(3, 3)
+	inRange := m.Ge(0.25).And(m.Lt(0.75))
+	vals := m.Select(inRange)  // the elements of m in [0.25, 0.75)
+	m.SetMask(inRange.Not(), 0.0)  // zero out all other elements
+
+As with Matf64, the fields of this struct are not directly accessible.
+*/
+type Mask struct {
+	r, c int
+	bits []uint64
+}
+
+/*
+NewMask returns an r by c Mask whose elements are all false.
+*/
+func NewMask(r, c int) *Mask {
+	if r < 0 || c < 0 {
+		s := "\nIn matrix.%s, the number of rows and columns cannot be\n"
+		s += "negative, however %d and %d were received."
+		s = fmt.Sprintf(s, "NewMask()", r, c)
+		printErr(ErrArgument, s)
+	}
+	return &Mask{r, c, make([]uint64, (r*c+63)/64)}
+}
+
+/*
+Shape returns the number of rows and columns of a Mask.
+*/
+func (k *Mask) Shape() (int, int) {
+	return k.r, k.c
+}
+
+/*
+Get returns the element of a Mask at the given row and column.
+*/
+func (k *Mask) Get(r, c int) bool {
+	i := r*k.c + c
+	return k.bits[i/64]&(1<<uint(i%64)) != 0
+}
+
+/*
+Set sets the element of a Mask at the given row and column.
+*/
+func (k *Mask) Set(r, c int, val bool) *Mask {
+	k.set(r*k.c+c, val)
+	return k
+}
+
+func (k *Mask) set(i int, val bool) {
+	if val {
+		k.bits[i/64] |= 1 << uint(i%64)
+	} else {
+		k.bits[i/64] &^= 1 << uint(i%64)
+	}
+}
+
+/*
+Count returns the number of elements of a Mask which are true.
+*/
+func (k *Mask) Count() int {
+	n := 0
+	for _, w := range k.bits {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+/*
+Copy returns a duplicate of a Mask.
+*/
+func (k *Mask) Copy() *Mask {
+	n := &Mask{k.r, k.c, make([]uint64, len(k.bits))}
+	copy(n.bits, k.bits)
+	return n
+}
+
+/*
+Equals checks if two Masks have the same shape and the same elements.
+*/
+func (k *Mask) Equals(n *Mask) bool {
+	if k.r != n.r || k.c != n.c {
+		return false
+	}
+	for i := range k.bits {
+		if k.bits[i] != n.bits[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (k *Mask) checkShape(fn string, n *Mask) {
+	if k.r != n.r || k.c != n.c {
+		s := "\nIn %s, the receiver is a %d by %d Mask, while the passed\n"
+		s += "Mask is %d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, fn, k.r, k.c, n.r, n.c)
+		printHelperErr(ErrShape, s)
+	}
+}
+
+/*
+And sets each element of the receiver to the logical and of itself and the
+corresponding element of the passed Mask.
+*/
+func (k *Mask) And(n *Mask) *Mask {
+	k.checkShape("And()", n)
+	for i := range k.bits {
+		k.bits[i] &= n.bits[i]
+	}
+	return k
+}
+
+/*
+Or sets each element of the receiver to the logical or of itself and the
+corresponding element of the passed Mask.
+*/
+func (k *Mask) Or(n *Mask) *Mask {
+	k.checkShape("Or()", n)
+	for i := range k.bits {
+		k.bits[i] |= n.bits[i]
+	}
+	return k
+}
+
+/*
+Xor sets each element of the receiver to the exclusive or of itself and the
+corresponding element of the passed Mask.
+*/
+func (k *Mask) Xor(n *Mask) *Mask {
+	k.checkShape("Xor()", n)
+	for i := range k.bits {
+		k.bits[i] ^= n.bits[i]
+	}
+	return k
+}
+
+/*
+Not negates each element of the receiver.
+*/
+func (k *Mask) Not() *Mask {
+	for i := range k.bits {
+		k.bits[i] = ^k.bits[i]
+	}
+	// Keep the unused bits of the last word cleared, so that Count and
+	// Equals are not affected by them.
+	if rem := (k.r * k.c) % 64; rem != 0 {
+		k.bits[len(k.bits)-1] &= 1<<uint(rem) - 1
+	}
+	return k
+}
+
+// compare returns a Mask which is true where f is true for an element of the
+// receiver, and the passed float64 or the corresponding element of the passed
+// Matf64.
+func (m *Matf64) compare(fn string, float64OrMatf64 interface{}, f func(a, b float64) bool) *Mask {
+	k := NewMask(m.r, m.c)
+	switch v := float64OrMatf64.(type) {
+	case float64:
+		for i := range m.vals[:m.r*m.c] {
+			if f(m.vals[i], v) {
+				k.set(i, true)
+			}
+		}
+	case *Matf64:
+		if v.r != m.r || v.c != m.c {
+			s := "\nIn %s, the receiver is a %d by %d Matf64, while the passed\n"
+			s += "Matf64 is %d by %d. They must have the same shape."
+			s = fmt.Sprintf(s, fn, m.r, m.c, v.r, v.c)
+			printHelperErr(ErrShape, s)
+		}
+		for i := range m.vals[:m.r*m.c] {
+			if f(m.vals[i], v.vals[i]) {
+				k.set(i, true)
+			}
+		}
+	default:
+		s := "\nIn %s, the passed value must be a float64 or *Matf64.\n"
+		s += "However, value of type  \"%v\" was received.\n"
+		s = fmt.Sprintf(s, fn, reflect.TypeOf(v))
+		printHelperErr(ErrArgument, s)
+	}
+	return k
+}
+
+/*
+Gt returns a Mask which is true where the elements of the receiver are greater
+than the passed value. The passed value can be a float64, or a Matf64 of the
+same shape as the receiver, in which case the elements are compared one by
+one:
+
+	m.Gt(0.0)  // true where m is positive
+	m.Gt(n)    // true where m is greater than n
+*/
+func (m *Matf64) Gt(float64OrMatf64 interface{}) *Mask {
+	return m.compare("Gt()", float64OrMatf64, func(a, b float64) bool { return a > b })
+}
+
+/*
+Ge returns a Mask which is true where the elements of the receiver are greater
+than or equal to the passed float64 or Matf64. See Gt for details.
+*/
+func (m *Matf64) Ge(float64OrMatf64 interface{}) *Mask {
+	return m.compare("Ge()", float64OrMatf64, func(a, b float64) bool { return a >= b })
+}
+
+/*
+Lt returns a Mask which is true where the elements of the receiver are less
+than the passed float64 or Matf64. See Gt for details.
+*/
+func (m *Matf64) Lt(float64OrMatf64 interface{}) *Mask {
+	return m.compare("Lt()", float64OrMatf64, func(a, b float64) bool { return a < b })
+}
+
+/*
+Le returns a Mask which is true where the elements of the receiver are less
+than or equal to the passed float64 or Matf64. See Gt for details.
+*/
+func (m *Matf64) Le(float64OrMatf64 interface{}) *Mask {
+	return m.compare("Le()", float64OrMatf64, func(a, b float64) bool { return a <= b })
+}
+
+/*
+Eq returns a Mask which is true where the elements of the receiver are equal
+to the passed float64 or Matf64. See Gt for details.
+*/
+func (m *Matf64) Eq(float64OrMatf64 interface{}) *Mask {
+	return m.compare("Eq()", float64OrMatf64, func(a, b float64) bool { return a == b })
+}
+
+/*
+Ne returns a Mask which is true where the elements of the receiver are not
+equal to the passed float64 or Matf64. See Gt for details.
+*/
+func (m *Matf64) Ne(float64OrMatf64 interface{}) *Mask {
+	return m.compare("Ne()", float64OrMatf64, func(a, b float64) bool { return a != b })
+}
+
+func (m *Matf64) checkMask(fn string, k *Mask) {
+	if k.r != m.r || k.c != m.c {
+		s := "\nIn %s, the receiver is a %d by %d Matf64, while the passed\n"
+		s += "Mask is %d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, fn, m.r, m.c, k.r, k.c)
+		printHelperErr(ErrShape, s)
+	}
+}
+
+/*
+Select returns a row vector containing the elements of the receiver for which
+the passed Mask is true, in row major order. The Mask must have the same shape
+as the receiver.
+*/
+func (m *Matf64) Select(k *Mask) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Select(k) })
+	}
+	m.checkMask("Select()", k)
+	v := Newf64(1, k.Count())
+	idx := 0
+	for i := range m.vals[:m.r*m.c] {
+		if k.bits[i/64]&(1<<uint(i%64)) != 0 {
+			v.vals[idx] = m.vals[i]
+			idx++
+		}
+	}
+	return v
+}
+
+/*
+SetMask sets the elements of the receiver for which the passed Mask is true
+to the passed value. The Mask must have the same shape as the receiver.
+*/
+func (m *Matf64) SetMask(k *Mask, val float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.SetMask(k, val) })
+	}
+	m.checkWritable("SetMask()")
+	m.checkMask("SetMask()", k)
+	for i := range m.vals[:m.r*m.c] {
+		if k.bits[i/64]&(1<<uint(i%64)) != 0 {
+			m.vals[i] = val
+		}
+	}
+	return m
+}
@@ -0,0 +1,84 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMask(t *testing.T) {
+	t.Helper()
+	k := NewMask(9, 10)
+	r, c := k.Shape()
+	assert.Equal(t, 9, r, "should be equal")
+	assert.Equal(t, 10, c, "should be equal")
+	assert.Equal(t, 2, len(k.bits), "should use one bit per element")
+	assert.Equal(t, 0, k.Count(), "should be all false")
+}
+
+func TestMaskGetSet(t *testing.T) {
+	t.Helper()
+	k := NewMask(9, 10)
+	k.Set(0, 0, true).Set(6, 5, true).Set(8, 9, true)
+	assert.True(t, k.Get(0, 0), "should be true")
+	assert.True(t, k.Get(6, 5), "should be true")
+	assert.True(t, k.Get(8, 9), "should be true")
+	assert.False(t, k.Get(6, 4), "should be false")
+	assert.Equal(t, 3, k.Count(), "should be equal")
+	k.Set(6, 5, false)
+	assert.False(t, k.Get(6, 5), "should be false")
+	assert.Equal(t, 2, k.Count(), "should be equal")
+}
+
+func TestMaskLogic(t *testing.T) {
+	t.Helper()
+	a := NewMask(1, 4).Set(0, 0, true).Set(0, 1, true)
+	b := NewMask(1, 4).Set(0, 1, true).Set(0, 2, true)
+	and := a.Copy().And(b)
+	or := a.Copy().Or(b)
+	xor := a.Copy().Xor(b)
+	not := a.Copy().Not()
+	for j, expected := range [][4]bool{
+		{false, true, false, false},
+		{true, true, true, false},
+		{true, false, true, false},
+		{false, false, true, true},
+	} {
+		k := []*Mask{and, or, xor, not}[j]
+		for i := range expected {
+			assert.Equal(t, expected[i], k.Get(0, i), "should be equal")
+		}
+	}
+	assert.Equal(t, 2, not.Count(), "unused bits should not be counted")
+	assert.True(t, not.Not().Equals(a), "should be equal")
+}
+
+func TestComparef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	n := Matf64FromData([]float64{4, 2, 3, 1}, 2, 2)
+	assert.Equal(t, 2, m.Gt(2.0).Count(), "should be equal")
+	assert.Equal(t, 3, m.Ge(2.0).Count(), "should be equal")
+	assert.Equal(t, 1, m.Lt(2.0).Count(), "should be equal")
+	assert.Equal(t, 2, m.Le(2.0).Count(), "should be equal")
+	assert.Equal(t, 1, m.Eq(2.0).Count(), "should be equal")
+	assert.Equal(t, 3, m.Ne(2.0).Count(), "should be equal")
+	assert.True(t, m.Gt(n).Get(1, 1), "should be true")
+	assert.False(t, m.Gt(n).Get(0, 0), "should be false")
+	assert.Equal(t, 2, m.Eq(n).Count(), "should be equal")
+}
+
+func TestSelectf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 5, 3, 8, 2, 7}, 2, 3)
+	v := m.Select(m.Gt(2.5))
+	assert.Equal(t, 1, v.r, "should be a row vector")
+	assert.Equal(t, []float64{5, 3, 8, 7}, v.vals, "should be equal")
+}
+
+func TestSetMaskf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 5, 3, 8, 2, 7}, 2, 3)
+	m.SetMask(m.Lt(4.0), 0.0)
+	assert.Equal(t, []float64{0, 5, 0, 8, 0, 7}, m.vals, "should be equal")
+}
@@ -0,0 +1,173 @@
+/*
+Package matassert provides assertions on the Matf64 of package matrix for
+tests, which report how two matrices differ, rather than only that they do:
+
+	func TestFit(t *testing.T) {
+		got := model.Predict(x)
+		matassert.ApproxEqual(t, want, got, 1e-9)
+	}
+
+Each assertion marks the test as failed with t.Errorf, and returns whether it
+passed, so that a test can stop early when later checks would be
+meaningless.
+*/
+package matassert
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/NDari/matrix"
+)
+
+// maxListed is the number of differing elements listed in a failure.
+const maxListed = 5
+
+/*
+T is the part of testing.TB which the assertions use, so that they can be
+used with *testing.T, *testing.B and test doubles alike.
+*/
+type T interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+/*
+DimsEqual asserts that two Matf64 have the same shape.
+*/
+func DimsEqual(t T, want, got *matrix.Matf64) bool {
+	t.Helper()
+	if wr, wc := want.Shape(); !sameShape(want, got) {
+		gr, gc := got.Shape()
+		t.Errorf("shapes differ: want %d by %d, got %d by %d", wr, wc, gr, gc)
+		return false
+	}
+	return true
+}
+
+/*
+Equal asserts that two Matf64 have the same shape and the same elements,
+where two NaNs are equal. On failure, the first differing elements are
+listed, along with a summary of all differences.
+*/
+func Equal(t T, want, got *matrix.Matf64) bool {
+	t.Helper()
+	if !DimsEqual(t, want, got) {
+		return false
+	}
+	if report := got.Diff(want); report.Count > 0 {
+		t.Errorf("matrices differ: %v\n%s", report, listDiffs(want, got, 0))
+		return false
+	}
+	return true
+}
+
+/*
+ApproxEqual asserts that two Matf64 have the same shape, and that each
+element of got is within tol of the corresponding element of want, where two
+NaNs, or two infinities of the same sign, are equal. On failure, the first
+elements which are too far apart are listed.
+*/
+func ApproxEqual(t T, want, got *matrix.Matf64, tol float64) bool {
+	t.Helper()
+	if !(tol >= 0) {
+		t.Errorf("the tolerance must not be negative, however %v was received", tol)
+		return false
+	}
+	if !DimsEqual(t, want, got) {
+		return false
+	}
+	r, c := want.Shape()
+	count, maxAbs := 0, 0.0
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			w, g := want.Get(i, j), got.Get(i, j)
+			if close(w, g, tol) {
+				continue
+			}
+			count++
+			if d := math.Abs(w - g); d > maxAbs || math.IsNaN(d) {
+				maxAbs = d
+			}
+		}
+	}
+	if count > 0 {
+		s := "%d of %d elements differ by more than %v, max abs %v\n%s"
+		t.Errorf(s, count, r*c, tol, maxAbs, listDiffs(want, got, tol))
+		return false
+	}
+	return true
+}
+
+/*
+AllFinite asserts that no element of a Matf64 is NaN or infinite. On
+failure, the first elements which are not finite are listed.
+*/
+func AllFinite(t T, m *matrix.Matf64) bool {
+	t.Helper()
+	r, c := m.Shape()
+	var b strings.Builder
+	count := 0
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := m.Get(i, j)
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				continue
+			}
+			if count < maxListed {
+				fmt.Fprintf(&b, "\t[%d, %d]: %v\n", i, j, v)
+			}
+			count++
+		}
+	}
+	if count > 0 {
+		t.Errorf("%d of %d elements are not finite\n%s", count, r*c, listed(&b, count))
+		return false
+	}
+	return true
+}
+
+func sameShape(a, b *matrix.Matf64) bool {
+	ar, ac := a.Shape()
+	br, bc := b.Shape()
+	return ar == br && ac == bc
+}
+
+// close reports whether w and g are equal within tol.
+func close(w, g, tol float64) bool {
+	if w == g || (math.IsNaN(w) && math.IsNaN(g)) {
+		return true
+	}
+	return math.Abs(w-g) <= tol
+}
+
+// listDiffs lists the first elements of want and got, which have the same
+// shape, that are not within tol of each other.
+func listDiffs(want, got *matrix.Matf64, tol float64) string {
+	r, c := want.Shape()
+	var b strings.Builder
+	count := 0
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			w, g := want.Get(i, j), got.Get(i, j)
+			if close(w, g, tol) {
+				continue
+			}
+			if count < maxListed {
+				fmt.Fprintf(&b, "\t[%d, %d]: want %v, got %v, diff %v\n", i, j, w, g, g-w)
+			}
+			count++
+		}
+	}
+	return listed(&b, count)
+}
+
+// listed returns the list in b, with a note of the number of elements which
+// were left out of it.
+func listed(b *strings.Builder, count int) string {
+	if count > maxListed {
+		fmt.Fprintf(b, "\t... and %d more\n", count-maxListed)
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package matassert
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/NDari/matrix"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a T which records the failures.
+type recorder struct {
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestDimsEqual(t *testing.T) {
+	t.Helper()
+	r := &recorder{}
+	assert.True(t, DimsEqual(r, matrix.Newf64(2, 3), matrix.Newf64(2, 3)), "should pass")
+	assert.False(t, DimsEqual(r, matrix.Newf64(2, 3), matrix.Newf64(3, 2)), "should fail")
+	assert.Equal(t, []string{"shapes differ: want 2 by 3, got 3 by 2"}, r.errs, "should be equal")
+}
+
+func TestEqual(t *testing.T) {
+	t.Helper()
+	r := &recorder{}
+	want := matrix.Matf64FromData([][]float64{{1, 2}, {3, math.NaN()}})
+	got := want.Copy()
+	assert.True(t, Equal(r, want, got), "should pass")
+	got.Set(1, 0, 4)
+	assert.False(t, Equal(r, want, got), "should fail")
+	assert.Equal(t, 1, len(r.errs), "should be equal")
+	assert.Contains(t, r.errs[0], "[1, 0]: want 3, got 4, diff 1", "should list the element")
+	assert.False(t, Equal(r, want, matrix.Newf64(3, 3)), "should fail")
+}
+
+func TestApproxEqual(t *testing.T) {
+	t.Helper()
+	r := &recorder{}
+	want := matrix.Newf64(3, 4)
+	got := want.Copy().Add(1e-10)
+	assert.True(t, ApproxEqual(r, want, got, 1e-9), "should pass")
+	assert.False(t, ApproxEqual(r, want, got.Add(1.0), 1e-9), "should fail")
+	assert.Equal(t, 1, len(r.errs), "should be equal")
+	assert.Contains(t, r.errs[0], "12 of 12 elements differ", "should count the elements")
+	assert.Contains(t, r.errs[0], "... and 7 more", "should list only the first elements")
+	assert.Equal(t, 5, strings.Count(r.errs[0], "want 0"), "should list the first elements")
+	inf := matrix.Newf64(1, 1).SetAll(math.Inf(1))
+	assert.True(t, ApproxEqual(r, inf, inf.Copy(), 0), "should pass")
+	assert.False(t, ApproxEqual(r, want, want, -1), "should fail")
+}
+
+func TestAllFinite(t *testing.T) {
+	t.Helper()
+	r := &recorder{}
+	m := matrix.Newf64(2, 2)
+	assert.True(t, AllFinite(r, m), "should pass")
+	m.Set(0, 1, math.NaN())
+	m.Set(1, 1, math.Inf(-1))
+	assert.False(t, AllFinite(r, m), "should fail")
+	assert.Contains(t, r.errs[0], "2 of 4 elements are not finite", "should be equal")
+	assert.Contains(t, r.errs[0], "[1, 1]: -Inf", "should list the element")
+}
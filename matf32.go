@@ -84,7 +84,7 @@ func Newf32(dims ...int) *Matf32 {
 	default:
 		s := "\nIn matrix.%s, expected 0 to 2 arguments, but received %d arguments."
 		s = fmt.Sprintf(s, "Newf32()", len(dims))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -173,7 +173,7 @@ func Matf32FromData(oneOrTwoDSlice interface{}, dims ...int) *Matf32 {
 		s := "\nIn matrix.%s, expected input data of type []float32 or\n"
 		s += "[][]float32, However, data of type \"%v\" was received."
 		s = fmt.Sprintf(s, "Matf32FromData()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return nil
 }
@@ -190,7 +190,7 @@ func matf32FromOneDSliceHelper(v []float32, dims []int) *Matf32 {
 			s := "\nIn matrix.%s, a 1D slice of data and a single int were passed.\n"
 			s += "However the int (%d) is not equal to the length of the data (%d)."
 			s = fmt.Sprintf(s, "Matf32FromData()", dims[0], len(v))
-			printHelperErr(s)
+			printHelperErr(ErrShape, s)
 		}
 		m.vals = make([]float32, dims[0], dims[0]*2)
 		copy(m.vals, v)
@@ -201,7 +201,7 @@ func matf32FromOneDSliceHelper(v []float32, dims []int) *Matf32 {
 			s += "However, the product of the two ints (%d, %d) does not equal\n"
 			s += "the number of elements in the data slice, %d. They must be equal."
 			s = fmt.Sprintf(s, "Matf32FromData()", dims[0]*dims[1], len(v))
-			printHelperErr(s)
+			printHelperErr(ErrShape, s)
 		}
 		m.vals = make([]float32, dims[0]*dims[1], dims[0]*dims[1]*2)
 		copy(m.vals, v)
@@ -212,7 +212,7 @@ func matf32FromOneDSliceHelper(v []float32, dims []int) *Matf32 {
 		s += "this function and adjust the number of integers based on the\n"
 		s += "desired output."
 		s = fmt.Sprintf(s, "Matf32FromData()", len(dims))
-		printHelperErr(s)
+		printHelperErr(ErrArgument, s)
 	}
 	return m
 }
@@ -238,7 +238,7 @@ func matf32FromTwoDSliceHelper(v [][]float32, dims []int) *Matf32 {
 			s += "%d elements."
 			s = fmt.Sprintf(s, "Matf32FromData()", dims[0], dims[0], dims[0], dims[0],
 				len(v)*len(v[0]), len(v[0]))
-			printHelperErr(s)
+			printHelperErr(ErrShape, s)
 		}
 		m.vals = make([]float32, dims[0]*dims[0], dims[0]*dims[0]*2)
 		for i := range v {
@@ -254,7 +254,7 @@ func matf32FromTwoDSliceHelper(v [][]float32, dims []int) *Matf32 {
 			s += "of the resultant Matf32 does not match the length and width of\n"
 			s += "the data slice (%d and %d)."
 			s = fmt.Sprintf(s, "Matf32FromData()", dims[0], dims[1], len(v), len(v[0]))
-			printHelperErr(s)
+			printHelperErr(ErrShape, s)
 		}
 		m.vals = make([]float32, dims[0]*dims[1], dims[0]*dims[1]*2)
 		for i := range v {
@@ -268,7 +268,7 @@ func matf32FromTwoDSliceHelper(v [][]float32, dims []int) *Matf32 {
 		s += "However, this function expects 0 to 2 ints. Review the docs for\n"
 		s += "this function and adjust the number of integers passed accordingly."
 		s = fmt.Sprintf(s, "Matf32FromData()", len(dims))
-		printHelperErr(s)
+		printHelperErr(ErrArgument, s)
 	} // switch len(dims) for case [][]float32
 	return m
 }
@@ -313,7 +313,7 @@ func RandMatf32(r, c int, args ...float32) *Matf32 {
 			s += "second argument, %f. The first argument must be strictly\n"
 			s += "less than the second.\n"
 			s = fmt.Sprintf(s, "RandMatf32()", from, to)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 		for i := 0; i < m.r*m.c; i++ {
 			m.vals[i] = rand.Float32()*(to-from) + from
@@ -321,7 +321,7 @@ func RandMatf32(r, c int, args ...float32) *Matf32 {
 	default:
 		s := "\nIn matrix.%s expected 0 to 2 arguments, but received %d."
 		s = fmt.Sprintf(s, "RandMatf32()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -337,7 +337,7 @@ func (m *Matf32) Reshape(rows, cols int) *Matf32 {
 		s += "must match. The Old Matf32 had a shape of row = %d, col = %d,\n"
 		s += "which is not equal to the requested shape of row, col = %d, %d\n"
 		s = fmt.Sprintf(s, "Reshape()", m.r, m.c, rows, cols)
-		printErr(s)
+		printErr(ErrShape, s)
 	} else {
 		m.r = rows
 		m.c = cols
@@ -466,7 +466,7 @@ func (m *Matf32) SetCol(col int, floatOrSlice interface{}) *Matf32 {
 		if (col >= m.c) || (col < -m.c) {
 			s := "\nIn %s the requested column %d is outside of bounds [%d, %d)\n"
 			s = fmt.Sprintf(s, "SetCol()", col, m.c, m.c)
-			printErr(s)
+			printErr(ErrBounds, s)
 		}
 		val32 := float32(val)
 		if col >= 0 {
@@ -483,7 +483,7 @@ func (m *Matf32) SetCol(col int, floatOrSlice interface{}) *Matf32 {
 			s := "\nIn %s the length of the passed slice is %d, which does\n"
 			s += "not match the number of rows in the receiver, %d."
 			s = fmt.Sprintf(s, "SetCol()", len(val), m.r)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		if col >= 0 {
 			for r := 0; r < m.r; r++ {
@@ -498,7 +498,7 @@ func (m *Matf32) SetCol(col int, floatOrSlice interface{}) *Matf32 {
 		s := "\nIn %s, the passed value must be a float32 or []float32.\n"
 		s += "However, value of type  %v was received.\n"
 		s = fmt.Sprintf(s, "SetCol()", reflect.TypeOf(val))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -525,7 +525,7 @@ func (m *Matf32) SetRow(row int, floatOrSlice interface{}) *Matf32 {
 		if (row >= m.r) || (row < -m.r) {
 			s := "\nIn %s, row %d is outside of the bounds [-%d, %d)\n"
 			s = fmt.Sprintf(s, "SetRow()", row, m.r, m.r)
-			printErr(s)
+			printErr(ErrBounds, s)
 		}
 		val32 := float32(val)
 		if row >= 0 {
@@ -542,7 +542,7 @@ func (m *Matf32) SetRow(row int, floatOrSlice interface{}) *Matf32 {
 			s := "\nIn %s the length of the passed slice is %d, which does\n"
 			s += "not match the number of columns in the receiver, %d."
 			s = fmt.Sprintf(s, "SetRow()", len(val), m.c)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		if row >= 0 {
 			for r := 0; r < m.c; r++ {
@@ -557,7 +557,7 @@ func (m *Matf32) SetRow(row int, floatOrSlice interface{}) *Matf32 {
 		s := "\nIn %s, the passed value must be a float32 or []float32.\n"
 		s += "However, value of type  %v was received.\n"
 		s = fmt.Sprintf(s, "SetRow()", reflect.TypeOf(val))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -577,7 +577,7 @@ func (m *Matf32) Col(x int) *Matf32 {
 	if (x >= m.c) || (x < -m.c) {
 		s := "\nIn %s the requested column %d is outside of bounds [-%d, %d)\n"
 		s = fmt.Sprintf(s, "Col()", x, m.c, m.c)
-		printErr(s)
+		printErr(ErrBounds, s)
 	}
 	v := Newf32(m.r, 1)
 	if x >= 0 {
@@ -607,7 +607,7 @@ func (m *Matf32) Row(x int) *Matf32 {
 	if (x >= m.r) || (x < -m.r) {
 		s := "\nIn %s, row %d is outside of the bounds [-%d, %d)\n"
 		s = fmt.Sprintf(s, "Row()", x, m.r, m.r)
-		printErr(s)
+		printErr(ErrBounds, s)
 	}
 	v := Newf32(1, m.c)
 	if x >= 0 {
@@ -656,7 +656,7 @@ func (m *Matf32) Min(args ...int) (index int, minVal float32) {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Min()", slice, m.r)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			minVal = m.vals[slice*m.c]
 			for i := 1; i < m.c; i++ {
@@ -669,7 +669,7 @@ func (m *Matf32) Min(args ...int) (index int, minVal float32) {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Min()", slice, m.c)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			minVal = m.vals[slice]
 			for i := 1; i < m.r; i++ {
@@ -682,12 +682,12 @@ func (m *Matf32) Min(args ...int) (index int, minVal float32) {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Min()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		} // Switch on axis
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Min()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	} // switch on len(args)
 	return
 }
@@ -726,7 +726,7 @@ func (m *Matf32) Max(args ...int) (index int, maxVal float32) {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Max()", slice, m.r)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			maxVal = m.vals[slice*m.c]
 			for i := 1; i < m.c; i++ {
@@ -739,7 +739,7 @@ func (m *Matf32) Max(args ...int) (index int, maxVal float32) {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Max()", slice, m.c)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			maxVal = m.vals[slice]
 			for i := 1; i < m.r; i++ {
@@ -752,12 +752,12 @@ func (m *Matf32) Max(args ...int) (index int, maxVal float32) {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Max()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		} // Switch on axis
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Max()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	} // switch on len(args)
 	return
 }
@@ -901,21 +901,21 @@ func (m *Matf32) Mul(float64OrMatf32 interface{}) *Matf32 {
 			s += "but the number of rows of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Mul()", m.r, v.r)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		if v.c != m.c {
 			s := "\nIn %s, the number of the columns of the receiver is %d\n"
 			s += "but the number of columns of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Mul()", m.c, v.c)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		vecf32.Mul(m.vals, v.vals)
 	default:
 		s := "\nIn %s, the passed value must be a float32 or *Matf32.\n"
 		s += "However, value of type  \"%v\" was received.\n"
 		s = fmt.Sprintf(s, "Mul()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -954,21 +954,21 @@ func (m *Matf32) Add(float64OrMatf32 interface{}) *Matf32 {
 			s += "but the number of rows of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Add()", m.r, v.r)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		if v.c != m.c {
 			s := "\nIn %s, the number of the columns of the receiver is %d\n"
 			s += "but the number of columns of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Add()", m.c, v.c)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		vecf32.Add(m.vals, v.vals)
 	default:
 		s := "\nIn %s, the passed value must be a float32 or *Matf32.\n"
 		s += "However, value of type  \"%v\" was received.\n"
 		s = fmt.Sprintf(s, "Add()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -1007,21 +1007,21 @@ func (m *Matf32) Sub(float64OrMatf32 interface{}) *Matf32 {
 			s += "but the number of rows of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Sub()", m.r, v.r)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		if v.c != m.c {
 			s := "\nIn %s, the number of the columns of the receiver is %d\n"
 			s += "but the number of columns of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Sub()", m.c, v.c)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		vecf32.Sub(m.vals, v.vals)
 	default:
 		s := "\nIn %s, the passed value must be a float32 or *Matf32.\n"
 		s += "However, value of type  \"%v\" was received.\n"
 		s = fmt.Sprintf(s, "Sub()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -1064,21 +1064,21 @@ func (m *Matf32) Div(float64OrMatf32 interface{}) *Matf32 {
 			s += "but the number of rows of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Div()", m.r, v.r)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		if v.c != m.c {
 			s := "\nIn %s, the number of the columns of the receiver is %d\n"
 			s += "but the number of columns of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Div()", m.c, v.c)
-			printErr(s)
+			printErr(ErrShape, s)
 		}
 		vecf32.Div(m.vals, v.vals)
 	default:
 		s := "\nIn %s, the passed value must be a float32 or *Matf32.\n"
 		s += "However, value of type  \"%v\" was received.\n"
 		s = fmt.Sprintf(s, "Div()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -1112,7 +1112,7 @@ func (m *Matf32) Sum(args ...int) float32 {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Sum()", slice, m.r)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			for i := 0; i < m.c; i++ {
 				sum += m.vals[slice*m.c+i]
@@ -1121,7 +1121,7 @@ func (m *Matf32) Sum(args ...int) float32 {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Sum()", slice, m.c)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			for i := 0; i < m.r; i++ {
 				sum += m.vals[i*m.c+slice]
@@ -1130,12 +1130,12 @@ func (m *Matf32) Sum(args ...int) float32 {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Sum()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Sum()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return sum
 }
@@ -1169,7 +1169,7 @@ func (m *Matf32) Avg(args ...int) float32 {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Avg()", slice, m.r)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			for i := 0; i < m.c; i++ {
 				sum += m.vals[slice*m.c+i]
@@ -1179,7 +1179,7 @@ func (m *Matf32) Avg(args ...int) float32 {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Avg()", slice, m.c)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			for i := 0; i < m.r; i++ {
 				sum += m.vals[i*m.c+slice]
@@ -1189,12 +1189,12 @@ func (m *Matf32) Avg(args ...int) float32 {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Avg()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Avg()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return sum
 }
@@ -1228,7 +1228,7 @@ func (m *Matf32) Prd(args ...int) float32 {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Prd()", slice, m.r)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			for i := 0; i < m.c; i++ {
 				prd *= m.vals[slice*m.c+i]
@@ -1237,7 +1237,7 @@ func (m *Matf32) Prd(args ...int) float32 {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Prd()", slice, m.c)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			for i := 0; i < m.r; i++ {
 				prd *= m.vals[i*m.c+slice]
@@ -1246,12 +1246,12 @@ func (m *Matf32) Prd(args ...int) float32 {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Prd()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Prd()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return prd
 }
@@ -1288,7 +1288,7 @@ func (m *Matf32) Std(args ...int) float32 {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Std()", slice, m.r)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			avg := m.Avg(axis, slice)
 			for i := 0; i < m.c; i++ {
@@ -1299,7 +1299,7 @@ func (m *Matf32) Std(args ...int) float32 {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Std()", slice, m.c)
-				printErr(s)
+				printErr(ErrBounds, s)
 			}
 			avg := m.Avg(axis, slice)
 			for i := 0; i < m.r; i++ {
@@ -1310,12 +1310,12 @@ func (m *Matf32) Std(args ...int) float32 {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Std()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 	default:
 		s := "\nIn %s, 0 or 2 arguments must be passed, but %d was received.\n"
 		s = fmt.Sprintf(s, "Std()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return std
 }
@@ -1341,7 +1341,7 @@ func (m *Matf32) Dot(n *Matf32) *Matf32 {
 		s += "which is not equal to the number of rows of the second mat,\n"
 		s += "which is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "Dot()", m.c, n.r)
-		printErr(s)
+		printErr(ErrShape, s)
 	}
 	o := Newf32(m.r, n.c)
 	m.vals = m.vals[:len(m.vals)]
@@ -1365,7 +1365,7 @@ func (m *Matf32) AppendCol(v []float32) *Matf32 {
 		s := "\nIn %s the number of rows of the receiver is %d, while\n"
 		s += "the number of rows of the vector is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "AppendCol()", m.r, len(v))
-		printErr(s)
+		printErr(ErrShape, s)
 	}
 	// TODO: redo this by hand, instead of taking this shortcut... or check if
 	// this is a huge bottleneck
@@ -1391,7 +1391,7 @@ func (m *Matf32) AppendRow(v []float32) *Matf32 {
 		s := "\nIn %s the number of cols of the receiver is %d, while\n"
 		s += "the number of rows of the vector is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "AppendRow()", m.c, len(v))
-		printErr(s)
+		printErr(ErrShape, s)
 	}
 	if cap(m.vals) < (len(m.vals) + len(v)) {
 		newVals := make([]float32, len(m.vals)+len(v), len(m.vals)+len(v)*2)
@@ -1427,7 +1427,7 @@ func (m *Matf32) Concat(n *Matf32) *Matf32 {
 		s := "\nIn %s the number of rows of the receiver is %d, while\n"
 		s += "the number of rows of the second Matf32 is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "Concat()", m.r, n.r)
-		printErr(s)
+		printErr(ErrShape, s)
 	}
 	q := m.ToSlice2D()
 	t := n.ToSlice1D()
@@ -1462,7 +1462,7 @@ func (m *Matf32) Append(n *Matf32) *Matf32 {
 		s := "\nIn %s the number of cols of the receiver is %d, while\n"
 		s += "the number of cols of the passed Matf32 is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "Append()", m.c, n.c)
-		printErr(s)
+		printErr(ErrShape, s)
 	}
 	m.vals = append(m.vals, n.vals...)
 	return m
@@ -42,6 +42,14 @@ change by the use of the various methods in this library.
 type Matf64 struct {
 	r, c int
 	vals []float64
+	// err is the first error of an operation on a Matf64 in deferred error
+	// mode, which deferErr is whether it is in. See DeferErrors.
+	err      error
+	deferErr bool
+	// readOnly is whether the methods which change a Matf64 must fail, as
+	// for the shared Matf64s of ZerosSharedf64 and EyeSharedf64, and the
+	// views of Labeledf64.
+	readOnly bool
 }
 
 /*
@@ -69,39 +77,35 @@ func Newf64(dims ...int) *Matf64 {
 	switch len(dims) {
 	case 0:
 		m = &Matf64{
-			0,
-			0,
-			make([]float64, 0),
+			r:    0,
+			c:    0,
+			vals: make([]float64, 0),
 		}
 	case 1:
 		m = &Matf64{
-			dims[0],
-			dims[0],
-			make([]float64, dims[0]*dims[0], 2*dims[0]*dims[0]),
+			r:    dims[0],
+			c:    dims[0],
+			vals: make([]float64, dims[0]*dims[0], 2*dims[0]*dims[0]),
 		}
 	case 2:
 		m = &Matf64{
-			dims[0],
-			dims[1],
-			make([]float64, dims[0]*dims[1], 2*dims[0]*dims[1]),
+			r:    dims[0],
+			c:    dims[1],
+			vals: make([]float64, dims[0]*dims[1], 2*dims[0]*dims[1]),
 		}
 	default:
 		s := "\nIn matrix.%s, expected 0 to 2 arguments, but received %d arguments."
 		s = fmt.Sprintf(s, "Newf64()", len(dims))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
 
 /*
-If64 returns the identity matrix
+If64 returns the x by x identity matrix. It is the same as Eyef64.
 */
 func If64(x int) *Matf64 {
-	m := Newf64(x)
-	for i := 1; i < x; i++ {
-		m.vals[i*i-1] = 1.0
-	}
-	return m
+	return Eyef64(x)
 }
 
 /*
@@ -177,7 +181,7 @@ func Matf64FromData(oneOrTwoDSlice interface{}, dims ...int) *Matf64 {
 		s := "\nIn matrix.%s, expected input data of type []float64 or\n"
 		s += "[][]float64, However, data of type \"%v\" was received."
 		s = fmt.Sprintf(s, "Matf64FromData()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return nil
 }
@@ -194,7 +198,7 @@ func matf64FromOneDSliceHelper(v []float64, dims []int) *Matf64 {
 			s := "\nIn matrix.%s, a 1D slice of data and a single int were passed.\n"
 			s += "However the int (%d) is not equal to the length of the data (%d)."
 			s = fmt.Sprintf(s, "Matf64FromData()", dims[0], len(v))
-			printHelperErr(s)
+			printHelperErr(ErrShape, s)
 		}
 		m.vals = make([]float64, dims[0], dims[0]*2)
 		copy(m.vals, v)
@@ -205,7 +209,7 @@ func matf64FromOneDSliceHelper(v []float64, dims []int) *Matf64 {
 			s += "However, the product of the two ints (%d, %d) does not equal\n"
 			s += "the number of elements in the data slice, %d. They must be equal."
 			s = fmt.Sprintf(s, "Matf64FromData()", dims[0]*dims[1], len(v))
-			printHelperErr(s)
+			printHelperErr(ErrShape, s)
 		}
 		m.vals = make([]float64, dims[0]*dims[1], dims[0]*dims[1]*2)
 		copy(m.vals, v)
@@ -216,7 +220,7 @@ func matf64FromOneDSliceHelper(v []float64, dims []int) *Matf64 {
 		s += "this function and adjust the number of integers based on the\n"
 		s += "desired output."
 		s = fmt.Sprintf(s, "Matf64FromData()", len(dims))
-		printHelperErr(s)
+		printHelperErr(ErrArgument, s)
 	}
 	return m
 }
@@ -242,7 +246,7 @@ func matf64FromTwoDSliceHelper(v [][]float64, dims []int) *Matf64 {
 			s += "%d elements."
 			s = fmt.Sprintf(s, "Matf64FromData()", dims[0], dims[0], dims[0], dims[0],
 				len(v)*len(v[0]), len(v[0]))
-			printHelperErr(s)
+			printHelperErr(ErrShape, s)
 		}
 		m.vals = make([]float64, dims[0]*dims[0], dims[0]*dims[0]*2)
 		for i := range v {
@@ -258,7 +262,7 @@ func matf64FromTwoDSliceHelper(v [][]float64, dims []int) *Matf64 {
 			s += "of the resultant Matf64 does not match the length and width of\n"
 			s += "the data slice (%d and %d)."
 			s = fmt.Sprintf(s, "Matf64FromData()", dims[0], dims[1], len(v), len(v[0]))
-			printHelperErr(s)
+			printHelperErr(ErrShape, s)
 		}
 		m.vals = make([]float64, dims[0]*dims[1], dims[0]*dims[1]*2)
 		for i := range v {
@@ -272,7 +276,7 @@ func matf64FromTwoDSliceHelper(v [][]float64, dims []int) *Matf64 {
 		s += "However, this function expects 0 to 2 ints. Review the docs for\n"
 		s += "this function and adjust the number of integers passed accordingly."
 		s = fmt.Sprintf(s, "Matf64FromData()", len(dims))
-		printHelperErr(s)
+		printHelperErr(ErrArgument, s)
 	} // switch len(dims) for case [][]float64
 	return m
 }
@@ -298,9 +302,13 @@ func Matf64FromCSV(filename string) *Matf64 {
 	if err != nil {
 		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
 		s = fmt.Sprintf(s, "Matf64FromCSV()", filename, err)
-		printErr(s)
+		printErr(ErrIO, s)
 	}
 	defer f.Close()
+	return matf64FromCSVReaderHelper(f, "Matf64FromCSV()", filename)
+}
+
+func matf64FromCSVReaderHelper(f io.Reader, fn, source string) *Matf64 {
 	r := csv.NewReader(f)
 	// I am going with the assumption that a mat loaded from a CSV is going to
 	// be large. So, we are going to read one line, and determine the number
@@ -310,8 +318,8 @@ func Matf64FromCSV(filename string) *Matf64 {
 	str, err := r.Read()
 	if err != nil {
 		s := "\nIn matrix.%s, cannot read from %s due to error: %v.\n"
-		s = fmt.Sprintf(s, "Matf64FromCSV()", filename, err)
-		printErr(s)
+		s = fmt.Sprintf(s, fn, source, err)
+		printHelperErr(ErrIO, s)
 	}
 	// Start with one row, and set the number of entries per row
 	m := Newf64()
@@ -323,8 +331,8 @@ func Matf64FromCSV(filename string) *Matf64 {
 			if err != nil {
 				s := "\nIn matrix.%s, item %d in line %d is %s, which cannot\n"
 				s += "be converted to a float64 due to: %v"
-				s = fmt.Sprintf(s, "Matf64FromCSV()", i, m.r, str[i], err)
-				printErr(s)
+				s = fmt.Sprintf(s, fn, i, m.r, str[i], err)
+				printHelperErr(ErrParse, s)
 			}
 		}
 		m.vals = append(m.vals, row...)
@@ -335,8 +343,8 @@ func Matf64FromCSV(filename string) *Matf64 {
 				break
 			}
 			s := "\nIn matrix.%s, cannot read from %s due to error: %v.\n"
-			s = fmt.Sprintf(s, "Matf64FromCSV()", filename, err)
-			printErr(s)
+			s = fmt.Sprintf(s, fn, source, err)
+			printHelperErr(ErrIO, s)
 		}
 		m.r++
 	}
@@ -383,7 +391,7 @@ func RandMatf64(r, c int, args ...float64) *Matf64 {
 			s += "second argument, %f. The first argument must be strictly\n"
 			s += "less than the second.\n"
 			s = fmt.Sprintf(s, "RandMatf64()", from, to)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 		for i := 0; i < m.r*m.c; i++ {
 			m.vals[i] = rand.Float64()*(to-from) + from
@@ -391,7 +399,7 @@ func RandMatf64(r, c int, args ...float64) *Matf64 {
 	default:
 		s := "\nIn matrix.%s expected 0 to 2 arguments, but received %d."
 		s = fmt.Sprintf(s, "RandMatf64()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -402,12 +410,16 @@ number of values contained in the mat object remains constant. The order and
 the values of the mat does not change with this function.
 */
 func (m *Matf64) Reshape(rows, cols int) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Reshape(rows, cols) })
+	}
+	m.checkWritable("Reshape()")
 	if rows*cols != m.r*m.c {
 		s := "\nIn %s, The total number of entries of the old and new shape\n"
 		s += "must match. The Old Matf64 had a shape of row = %d, col = %d,\n"
 		s += "which is not equal to the requested shape of row, col = %d, %d\n"
 		s = fmt.Sprintf(s, "Reshape()", m.r, m.c, rows, cols)
-		printErr(s)
+		printErr(&ErrDimMismatch{"Reshape()", m.r, m.c, rows, cols}, s)
 	} else {
 		m.r = rows
 		m.c = cols
@@ -455,7 +467,7 @@ func (m *Matf64) ToCSV(fileName string) {
 	if err != nil {
 		s := "\nIn %s, cannot open %s due to error: %v.\n"
 		s = fmt.Sprintf(s, "ToCSV()", fileName, err)
-		printErr(s)
+		printErr(ErrIO, s)
 	}
 	defer f.Close()
 	str := ""
@@ -476,7 +488,79 @@ func (m *Matf64) ToCSV(fileName string) {
 	if err != nil {
 		s := "\nIn %s, cannot write to %s due to error: %v.\n"
 		s = fmt.Sprintf(s, "ToCSV()", fileName, err)
-		printErr(s)
+		printErr(ErrIO, s)
+	}
+}
+
+/*
+AppendToCSV appends the rows of a mat object to the end of an existing CSV
+file, in the same format as ToCSV. This is useful for logging results which
+are computed incrementally, such as metrics which are computed every epoch:
+
+	for epoch := 0; epoch < 100; epoch++ {
+		// ...
+		metrics.AppendToCSV("metrics.csv")
+	}
+
+The number of entries in the first line of the file must be equal to the
+number of columns of the mat object. If the file does not exist or is empty,
+it is created, and this method behaves the same as ToCSV.
+*/
+func (m *Matf64) AppendToCSV(fileName string) {
+	f, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		s := "\nIn %s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "AppendToCSV()", fileName, err)
+		printErr(ErrIO, s)
+	}
+	defer f.Close()
+	size, err := f.Seek(0, io.SeekEnd)
+	if err == nil && size > 0 {
+		var first []string
+		if _, err = f.Seek(0, io.SeekStart); err == nil {
+			first, err = csv.NewReader(f).Read()
+		}
+		if err == nil && len(first) != m.c {
+			s := "\nIn %s, the first line of %s has %d entries, which does\n"
+			s += "not match the number of columns of the receiver, %d."
+			s = fmt.Sprintf(s, "AppendToCSV()", fileName, len(first), m.c)
+			printErr(ErrShape, s)
+		}
+		// ToCSV does not end the file with a newline, so one may be needed
+		// to separate the existing lines from the new ones.
+		last := make([]byte, 1)
+		if err == nil {
+			_, err = f.ReadAt(last, size-1)
+		}
+		if err == nil && last[0] != '\n' {
+			_, err = f.WriteAt([]byte("\n"), size)
+			size++
+		}
+	}
+	if err != nil {
+		s := "\nIn %s, cannot read from %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "AppendToCSV()", fileName, err)
+		printErr(ErrIO, s)
+	}
+	str := ""
+	idx := 0
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			str += strconv.FormatFloat(m.vals[idx], 'e', 14, 64)
+			if j+1 != m.c {
+				str += ","
+			}
+			idx++
+		}
+		if i+1 != m.r {
+			str += "\n"
+		}
+	}
+	_, err = f.WriteAt([]byte(str), size)
+	if err != nil {
+		s := "\nIn %s, cannot write to %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "AppendToCSV()", fileName, err)
+		printErr(ErrIO, s)
 	}
 }
 
@@ -492,6 +576,10 @@ Set sets the value of a mat at a given row and column to a given
 value.
 */
 func (m *Matf64) Set(r, c int, val float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Set(r, c, val) })
+	}
+	m.checkWritable("Set()")
 	m.vals[r*m.c+c] = val
 	return m
 }
@@ -500,6 +588,10 @@ func (m *Matf64) Set(r, c int, val float64) *Matf64 {
 SetAll sets all values of a mat to the passed float64 value.
 */
 func (m *Matf64) SetAll(val float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.SetAll(val) })
+	}
+	m.checkWritable("SetAll()")
 	for i := range m.vals {
 		m.vals[i] = val
 	}
@@ -517,6 +609,10 @@ following would do this:
 	})
 */
 func (m *Matf64) Map(f func(*float64)) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Map(f) })
+	}
+	m.checkWritable("Map()")
 	for i := range m.vals {
 		f(&m.vals[i])
 	}
@@ -540,12 +636,16 @@ in this case, the length of the passed slice must match exactly the number of
 elements in m's column, i.e. the number of rows of m.
 */
 func (m *Matf64) SetCol(col int, floatOrSlice interface{}) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.SetCol(col, floatOrSlice) })
+	}
+	m.checkWritable("SetCol()")
 	switch val := floatOrSlice.(type) {
 	case float64:
 		if (col >= m.c) || (col < -m.c) {
 			s := "\nIn %s the requested column %d is outside of bounds [%d, %d)\n"
 			s = fmt.Sprintf(s, "SetCol()", col, m.c, m.c)
-			printErr(s)
+			printErr(&ErrIndexOOB{"SetCol()", 1, col, m.c}, s)
 		}
 		if col >= 0 {
 			for r := 0; r < m.r; r++ {
@@ -561,7 +661,7 @@ func (m *Matf64) SetCol(col int, floatOrSlice interface{}) *Matf64 {
 			s := "\nIn %s the length of the passed slice is %d, which does\n"
 			s += "not match the number of rows in the receiver, %d."
 			s = fmt.Sprintf(s, "SetCol()", len(val), m.r)
-			printErr(s)
+			printErr(&ErrDimMismatch{"SetCol()", m.r, m.c, len(val), 1}, s)
 		}
 		if col >= 0 {
 			for r := 0; r < m.r; r++ {
@@ -576,7 +676,7 @@ func (m *Matf64) SetCol(col int, floatOrSlice interface{}) *Matf64 {
 		s := "\nIn %s, the passed value must be a float64 or []float64.\n"
 		s += "However, value of type  %v was received.\n"
 		s = fmt.Sprintf(s, "SetCol()", reflect.TypeOf(val))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -598,12 +698,16 @@ in this case, the length of the passed slice must match exactly the number of
 elements in m's row, i.e. the number of cols of m.
 */
 func (m *Matf64) SetRow(row int, floatOrSlice interface{}) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.SetRow(row, floatOrSlice) })
+	}
+	m.checkWritable("SetRow()")
 	switch val := floatOrSlice.(type) {
 	case float64:
 		if (row >= m.r) || (row < -m.r) {
 			s := "\nIn %s, row %d is outside of the bounds [-%d, %d)\n"
 			s = fmt.Sprintf(s, "SetRow()", row, m.r, m.r)
-			printErr(s)
+			printErr(&ErrIndexOOB{"SetRow()", 0, row, m.r}, s)
 		}
 		if row >= 0 {
 			for r := 0; r < m.c; r++ {
@@ -619,7 +723,7 @@ func (m *Matf64) SetRow(row int, floatOrSlice interface{}) *Matf64 {
 			s := "\nIn %s the length of the passed slice is %d, which does\n"
 			s += "not match the number of columns in the receiver, %d."
 			s = fmt.Sprintf(s, "SetRow()", len(val), m.c)
-			printErr(s)
+			printErr(&ErrDimMismatch{"SetRow()", m.r, m.c, 1, len(val)}, s)
 		}
 		if row >= 0 {
 			for r := 0; r < m.c; r++ {
@@ -634,7 +738,7 @@ func (m *Matf64) SetRow(row int, floatOrSlice interface{}) *Matf64 {
 		s := "\nIn %s, the passed value must be a float64 or []float64.\n"
 		s += "However, value of type  %v was received.\n"
 		s = fmt.Sprintf(s, "SetRow()", reflect.TypeOf(val))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return m
 }
@@ -651,10 +755,13 @@ This function supports negative indexing. For example,
 returns the last column of m.
 */
 func (m *Matf64) Col(x int) *Matf64 {
-	if (x >= m.c) || (x < -m.c) {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Col(x) })
+	}
+	if checks && ((x >= m.c) || (x < -m.c)) {
 		s := "\nIn %s the requested column %d is outside of bounds [-%d, %d)\n"
 		s = fmt.Sprintf(s, "Col()", x, m.c, m.c)
-		printErr(s)
+		printErr(&ErrIndexOOB{"Col()", 1, x, m.c}, s)
 	}
 	v := Newf64(m.r, 1)
 	if x >= 0 {
@@ -681,10 +788,13 @@ This function supports negative indexing. For example,
 returns the last row of m.
 */
 func (m *Matf64) Row(x int) *Matf64 {
-	if (x >= m.r) || (x < -m.r) {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Row(x) })
+	}
+	if checks && ((x >= m.r) || (x < -m.r)) {
 		s := "\nIn %s, row %d is outside of the bounds [-%d, %d)\n"
 		s = fmt.Sprintf(s, "Row()", x, m.r, m.r)
-		printErr(s)
+		printErr(&ErrIndexOOB{"Row()", 0, x, m.r}, s)
 	}
 	v := Newf64(1, m.c)
 	if x >= 0 {
@@ -734,7 +844,7 @@ func (m *Matf64) Min(args ...int) (index int, minVal float64) {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Min()", slice, m.r)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Min()", 0, slice, m.r}, s)
 			}
 			index = 0
 			minVal = m.vals[slice*m.c]
@@ -748,7 +858,7 @@ func (m *Matf64) Min(args ...int) (index int, minVal float64) {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Min()", slice, m.c)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Min()", 1, slice, m.c}, s)
 			}
 			index = 0
 			minVal = m.vals[slice]
@@ -762,12 +872,12 @@ func (m *Matf64) Min(args ...int) (index int, minVal float64) {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Min()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		} // Switch on axis
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Min()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	} // switch on len(args)
 	return index, minVal
 }
@@ -807,7 +917,7 @@ func (m *Matf64) Max(args ...int) (index int, maxVal float64) {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Max()", slice, m.r)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Max()", 0, slice, m.r}, s)
 			}
 			index = 0
 			maxVal = m.vals[slice*m.c]
@@ -821,7 +931,7 @@ func (m *Matf64) Max(args ...int) (index int, maxVal float64) {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Max()", slice, m.c)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Max()", 1, slice, m.c}, s)
 			}
 			index = 0
 			maxVal = m.vals[slice]
@@ -835,12 +945,12 @@ func (m *Matf64) Max(args ...int) (index int, maxVal float64) {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Max()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		} // Switch on axis
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Max()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	} // switch on len(args)
 	return index, maxVal
 }
@@ -870,6 +980,9 @@ Copy returns a duplicate of a mat object. The returned copy is "deep", meaning
 that the object can be manipulated without effecting the original mat object.
 */
 func (m *Matf64) Copy() *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Copy() })
+	}
 	n := Newf64(m.r, m.c)
 	copy(n.vals, m.vals)
 	return n
@@ -884,6 +997,9 @@ respectively. This method creates a new mat object, and the original is
 left intact.
 */
 func (m *Matf64) T() *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.T() })
+	}
 	if m.isRowVector() || m.isColVector() {
 		n := m.Copy()
 		n.r, n.c = n.c, n.r
@@ -918,7 +1034,7 @@ func (m *Matf64) isColVector() bool {
 All checks if a supplied function is true for all elements of a mat object.
 For instance, consider
 
-	m.All(matrix.Positivef64)
+	m.All(matrix.Positive)
 
 will return true if and only if all elements in m are positive.
 */
@@ -935,7 +1051,7 @@ func (m *Matf64) All(f func(*float64) bool) bool {
 Any checks if a supplied function is true for one elements of a mat object.
 For instance,
 
-	m.Any(matrix.Positivef64)
+	m.Any(matrix.Positive)
 
 would be true if at least one element of the mat object is positive.
 */
@@ -972,34 +1088,38 @@ This will result in each element of m being 100.0.
 Note: For the matrix cross product see the Dot() method.
 */
 func (m *Matf64) Mul(float64OrMatf64 interface{}) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Mul(float64OrMatf64) })
+	}
+	m.checkWritable("Mul()")
 	switch v := float64OrMatf64.(type) {
 	case float64:
 		for i := range m.vals {
 			m.vals[i] *= v
 		}
 	case *Matf64:
-		if v.r != m.r {
+		if checks && v.r != m.r {
 			s := "\nIn %s, the number of the rows of the receiver is %d\n"
 			s += "but the number of rows of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Mul()", m.r, v.r)
-			printErr(s)
+			printErr(&ErrDimMismatch{"Mul()", m.r, m.c, v.r, v.c}, s)
 		}
-		if v.c != m.c {
+		if checks && v.c != m.c {
 			s := "\nIn %s, the number of the columns of the receiver is %d\n"
 			s += "but the number of columns of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Mul()", m.c, v.c)
-			printErr(s)
+			printErr(&ErrDimMismatch{"Mul()", m.r, m.c, v.r, v.c}, s)
 		}
 		vecf64.Mul(m.vals, v.vals)
 	default:
 		s := "\nIn %s, the passed value must be a float64 or *Matf64.\n"
 		s += "However, value of type  \"%v\" was received.\n"
 		s = fmt.Sprintf(s, "Mul()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
-	return m
+	return m.checkFinite("Mul()")
 }
 
 /*
@@ -1024,34 +1144,38 @@ that the passed Matf64 must have the same shape as the receiver.
 This will result in each element of m being 20.0.
 */
 func (m *Matf64) Add(float64OrMatf64 interface{}) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Add(float64OrMatf64) })
+	}
+	m.checkWritable("Add()")
 	switch v := float64OrMatf64.(type) {
 	case float64:
 		for i := range m.vals {
 			m.vals[i] += v
 		}
 	case *Matf64:
-		if v.r != m.r {
+		if checks && v.r != m.r {
 			s := "\nIn %s, the number of the rows of the receiver is %d\n"
 			s += "but the number of rows of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Add()", m.r, v.r)
-			printErr(s)
+			printErr(&ErrDimMismatch{"Add()", m.r, m.c, v.r, v.c}, s)
 		}
-		if v.c != m.c {
+		if checks && v.c != m.c {
 			s := "\nIn %s, the number of the columns of the receiver is %d\n"
 			s += "but the number of columns of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Add()", m.c, v.c)
-			printErr(s)
+			printErr(&ErrDimMismatch{"Add()", m.r, m.c, v.r, v.c}, s)
 		}
 		vecf64.Add(m.vals, v.vals)
 	default:
 		s := "\nIn %s, the passed value must be a float64 or *Matf64.\n"
 		s += "However, value of type  \"%v\" was received.\n"
 		s = fmt.Sprintf(s, "Add()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
-	return m
+	return m.checkFinite("Add()")
 }
 
 /*
@@ -1076,34 +1200,38 @@ that the passed Matf64 must have the same shape as the receiver.
 This will result in each element of m being 0.0.
 */
 func (m *Matf64) Sub(float64OrMatf64 interface{}) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Sub(float64OrMatf64) })
+	}
+	m.checkWritable("Sub()")
 	switch v := float64OrMatf64.(type) {
 	case float64:
 		for i := range m.vals {
 			m.vals[i] -= v
 		}
 	case *Matf64:
-		if v.r != m.r {
+		if checks && v.r != m.r {
 			s := "\nIn %s, the number of the rows of the receiver is %d\n"
 			s += "but the number of rows of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Sub()", m.r, v.r)
-			printErr(s)
+			printErr(&ErrDimMismatch{"Sub()", m.r, m.c, v.r, v.c}, s)
 		}
-		if v.c != m.c {
+		if checks && v.c != m.c {
 			s := "\nIn %s, the number of the columns of the receiver is %d\n"
 			s += "but the number of columns of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Sub()", m.c, v.c)
-			printErr(s)
+			printErr(&ErrDimMismatch{"Sub()", m.r, m.c, v.r, v.c}, s)
 		}
 		vecf64.Sub(m.vals, v.vals)
 	default:
 		s := "\nIn %s, the passed value must be a float64 or *Matf64.\n"
 		s += "However, value of type  \"%v\" was received.\n"
 		s = fmt.Sprintf(s, "Sub()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
-	return m
+	return m.checkFinite("Sub()")
 }
 
 /*
@@ -1132,34 +1260,38 @@ contains any elements which are 0.0.
 This will result in each element of m being 1.0.
 */
 func (m *Matf64) Div(float64OrMatf64 interface{}) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Div(float64OrMatf64) })
+	}
+	m.checkWritable("Div()")
 	switch v := float64OrMatf64.(type) {
 	case float64:
 		for i := range m.vals {
 			m.vals[i] /= v
 		}
 	case *Matf64:
-		if v.r != m.r {
+		if checks && v.r != m.r {
 			s := "\nIn %s, the number of the rows of the receiver is %d\n"
 			s += "but the number of rows of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Div()", m.r, v.r)
-			printErr(s)
+			printErr(&ErrDimMismatch{"Div()", m.r, m.c, v.r, v.c}, s)
 		}
-		if v.c != m.c {
+		if checks && v.c != m.c {
 			s := "\nIn %s, the number of the columns of the receiver is %d\n"
 			s += "but the number of columns of the passed mat is %d. They must\n"
 			s += "match.\n"
 			s = fmt.Sprintf(s, "Div()", m.c, v.c)
-			printErr(s)
+			printErr(&ErrDimMismatch{"Div()", m.r, m.c, v.r, v.c}, s)
 		}
 		vecf64.Div(m.vals, v.vals)
 	default:
 		s := "\nIn %s, the passed value must be a float64 or *Matf64.\n"
 		s += "However, value of type  \"%v\" was received.\n"
 		s = fmt.Sprintf(s, "Div()", reflect.TypeOf(v))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
-	return m
+	return m.checkFinite("Div()")
 }
 
 /*
@@ -1191,7 +1323,7 @@ func (m *Matf64) Sum(args ...int) float64 {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Sum()", slice, m.r)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Sum()", 0, slice, m.r}, s)
 			}
 			for i := 0; i < m.c; i++ {
 				sum += m.vals[slice*m.c+i]
@@ -1200,7 +1332,7 @@ func (m *Matf64) Sum(args ...int) float64 {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Sum()", slice, m.c)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Sum()", 1, slice, m.c}, s)
 			}
 			for i := 0; i < m.r; i++ {
 				sum += m.vals[i*m.c+slice]
@@ -1209,12 +1341,12 @@ func (m *Matf64) Sum(args ...int) float64 {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Sum()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Sum()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return sum
 }
@@ -1248,7 +1380,7 @@ func (m *Matf64) Avg(args ...int) float64 {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Avg()", slice, m.r)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Avg()", 0, slice, m.r}, s)
 			}
 			for i := 0; i < m.c; i++ {
 				sum += m.vals[slice*m.c+i]
@@ -1258,7 +1390,7 @@ func (m *Matf64) Avg(args ...int) float64 {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Avg()", slice, m.c)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Avg()", 1, slice, m.c}, s)
 			}
 			for i := 0; i < m.r; i++ {
 				sum += m.vals[i*m.c+slice]
@@ -1268,12 +1400,12 @@ func (m *Matf64) Avg(args ...int) float64 {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Avg()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Avg()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return sum
 }
@@ -1307,7 +1439,7 @@ func (m *Matf64) Prd(args ...int) float64 {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Prd()", slice, m.r)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Prd()", 0, slice, m.r}, s)
 			}
 			for i := 0; i < m.c; i++ {
 				prd *= m.vals[slice*m.c+i]
@@ -1316,7 +1448,7 @@ func (m *Matf64) Prd(args ...int) float64 {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Prd()", slice, m.c)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Prd()", 1, slice, m.c}, s)
 			}
 			for i := 0; i < m.r; i++ {
 				prd *= m.vals[i*m.c+slice]
@@ -1325,12 +1457,12 @@ func (m *Matf64) Prd(args ...int) float64 {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Prd()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 	default:
 		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
 		s = fmt.Sprintf(s, "Prd()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return prd
 }
@@ -1367,7 +1499,7 @@ func (m *Matf64) Std(args ...int) float64 {
 			if (slice >= m.r) || (slice < 0) {
 				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Std()", slice, m.r)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Std()", 0, slice, m.r}, s)
 			}
 			avg := m.Avg(axis, slice)
 			sum := 0.0
@@ -1379,7 +1511,7 @@ func (m *Matf64) Std(args ...int) float64 {
 			if (slice >= m.c) || (slice < 0) {
 				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
 				s = fmt.Sprintf(s, "Std()", slice, m.c)
-				printErr(s)
+				printErr(&ErrIndexOOB{"Std()", 1, slice, m.c}, s)
 			}
 			avg := m.Avg(axis, slice)
 			sum := 0.0
@@ -1391,12 +1523,12 @@ func (m *Matf64) Std(args ...int) float64 {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
 			s = fmt.Sprintf(s, "Std()", axis)
-			printErr(s)
+			printErr(ErrArgument, s)
 		}
 	default:
 		s := "\nIn %s, 0 or 2 arguments must be passed, but %d was received.\n"
 		s = fmt.Sprintf(s, "Std()", len(args))
-		printErr(s)
+		printErr(ErrArgument, s)
 	}
 	return std
 }
@@ -1415,23 +1547,59 @@ then
 is a 5 by 10 mat whose element at row i and column j is given by:
 
 	Sum(m.Row(i).Mul(n.col(j))
+
+The product of two square mats of 2 by 2, 3 by 3 or 4 by 4 uses closed form
+expressions. Large products are split by rows between goroutines, up to the
+number set by SetMaxThreads. DiagMatf64 provides a faster product for
+diagonal matrices.
 */
 func (m *Matf64) Dot(n *Matf64) *Matf64 {
-	if m.c != n.r {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Dot(n) })
+	}
+	return m.dotHelper("Dot()", n, MaxThreads()).checkFinite("Dot()")
+}
+
+/*
+DotThreads does the same as Dot, but uses at most the passed number of
+goroutines, instead of the number set by SetMaxThreads. The result is the
+same for any number of goroutines.
+*/
+func (m *Matf64) DotThreads(n *Matf64, threads int) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.DotThreads(n, threads) })
+	}
+	return m.dotHelper("DotThreads()", n, threads).checkFinite("DotThreads()")
+}
+
+func (m *Matf64) dotHelper(fn string, n *Matf64, threads int) *Matf64 {
+	if checks && m.c != n.r {
 		s := "\nIn %s the number of columns of the first mat is %d\n"
 		s += "which is not equal to the number of rows of the second mat,\n"
-		s += "which is %d. They must be equal.\n"
-		s = fmt.Sprintf(s, "Dot()", m.c, n.r)
-		printErr(s)
+		s += "which is %d. They must be equal. The first mat is %d by %d,\n"
+		s += "and the second is %d by %d.\n"
+		s = fmt.Sprintf(s, fn, m.c, n.r, m.r, m.c, n.r, n.c)
+		printHelperErr(&ErrDimMismatch{fn, m.r, m.c, n.r, n.c}, s)
 	}
+	if m.tinySize() && n.tinySize() {
+		o := Newf64(m.r)
+		dotTiny(m.r, o.vals, m.vals, n.vals)
+		return o
+	}
+	return m.dot(n, threads)
+}
+
+func (m *Matf64) dot(n *Matf64, threads int) *Matf64 {
 	o := Newf64(m.r, n.c)
-	for i := 0; i < m.r; i++ {
-		for j := 0; j < n.c; j++ {
-			for k := 0; k < m.c; k++ {
-				o.vals[i*o.c+j] += (m.vals[i*m.c+k] * n.vals[k*n.c+j])
+	parallelRows(m.r, m.r*m.c*n.c, threads, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			for j := 0; j < n.c; j++ {
+				for k := 0; k < m.c; k++ {
+					o.vals[i*o.c+j] += (m.vals[i*m.c+k] * n.vals[k*n.c+j])
+				}
 			}
 		}
-	}
+	})
 	return o
 }
 
@@ -1466,11 +1634,15 @@ func (m *Matf64) String() string {
 AppendCol appends a column to the right side of a Matf64.
 */
 func (m *Matf64) AppendCol(v []float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.AppendCol(v) })
+	}
+	m.checkWritable("AppendCol()")
 	if m.r != len(v) {
 		s := "\nIn %s the number of rows of the receiver is %d, while\n"
 		s += "the number of rows of the vector is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "AppendCol()", m.r, len(v))
-		printErr(s)
+		printErr(&ErrDimMismatch{"AppendCol()", m.r, m.c, len(v), 1}, s)
 	}
 	// TODO: redo this by hand, instead of taking this shortcut... or check if
 	// this is a huge bottleneck
@@ -1492,11 +1664,15 @@ func (m *Matf64) AppendCol(v []float64) *Matf64 {
 AppendRow appends a row to the bottom of a Matf64.
 */
 func (m *Matf64) AppendRow(v []float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.AppendRow(v) })
+	}
+	m.checkWritable("AppendRow()")
 	if m.c != len(v) {
 		s := "\nIn %s the number of cols of the receiver is %d, while\n"
 		s += "the number of rows of the vector is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "AppendRow()", m.c, len(v))
-		printErr(s)
+		printErr(&ErrDimMismatch{"AppendRow()", m.r, m.c, 1, len(v)}, s)
 	}
 	if cap(m.vals) < (len(m.vals) + len(v)) {
 		newVals := make([]float64, len(m.vals)+len(v), len(m.vals)+len(v)*2)
@@ -1528,11 +1704,15 @@ For example:
 Note that in the current implementation this is a somewhat expensive function.
 */
 func (m *Matf64) Concat(n *Matf64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Concat(n) })
+	}
+	m.checkWritable("Concat()")
 	if m.r != n.r {
 		s := "\nIn %s the number of rows of the receiver is %d, while\n"
 		s += "the number of rows of the second Matf64 is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "Concat()", m.r, n.r)
-		printErr(s)
+		printErr(&ErrDimMismatch{"Concat()", m.r, m.c, n.r, n.c}, s)
 	}
 	q := m.ToSlice2D()
 	t := n.ToSlice1D()
@@ -1563,11 +1743,15 @@ For example:
 Note that in the current implementation this is a somewhat expensive function.
 */
 func (m *Matf64) Append(n *Matf64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Append(n) })
+	}
+	m.checkWritable("Append()")
 	if m.c != n.c {
 		s := "\nIn %s the number of cols of the receiver is %d, while\n"
 		s += "the number of cols of the passed Matf64 is %d. They must be equal.\n"
 		s = fmt.Sprintf(s, "Append()", m.c, n.c)
-		printErr(s)
+		printErr(&ErrDimMismatch{"Append()", m.r, m.c, n.r, n.c}, s)
 	}
 	m.vals = append(m.vals, n.vals...)
 	return m
@@ -10,6 +10,7 @@ import (
 
 func TestNewf64(t *testing.T) {
 	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
 	rows := 13
 	cols := 7
 	m := Newf64()
@@ -33,15 +34,16 @@ func TestNewf64(t *testing.T) {
 	assert.Equal(t, rows*cols, len(m.vals), "should be equal")
 	assert.Equal(t, 2*rows*cols, cap(m.vals), "should have twice the capacity")
 
-	// assert.Panics(t, func() { Newf64(1, 2, 3, 4) }, "should panic with 3+ args")
+	assert.Panics(t, func() { Newf64(1, 2, 3, 4) }, "should panic with 3+ args")
 }
 
 func TestMatf64FromData(t *testing.T) {
 	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
 	rows := 50
 	cols := 2
 
-	// assert.Panics(t, func() { Matf64FromData(1.0) }, "should panic with wrong arg")
+	assert.Panics(t, func() { Matf64FromData(1.0) }, "should panic with wrong arg")
 
 	v := make([]float64, rows*cols)
 	for i := range v {
@@ -85,9 +87,9 @@ func TestMatf64FromData(t *testing.T) {
 	m.vals[0] = 1201.0
 	assert.NotEqual(t, m.vals[0], v[0], "changing mat should not effect data")
 
-	// assert.Panics(t, func() { Matf64FromData(v, 12) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf64FromData(v, 11, 2) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf64FromData(v, 1, 2, 3) }, "too many args")
+	assert.Panics(t, func() { Matf64FromData(v, 12) }, "wrong expected size")
+	assert.Panics(t, func() { Matf64FromData(v, 11, 2) }, "wrong expected size")
+	assert.Panics(t, func() { Matf64FromData(v, 1, 2, 3) }, "too many args")
 
 	s := make([][]float64, rows)
 	for i := range s {
@@ -149,19 +151,20 @@ func TestMatf64FromData(t *testing.T) {
 	m.vals[0] = 1201.0
 	assert.NotEqual(t, m.vals[0], s[0][0], "changing mat should not effect data")
 
-	// assert.Panics(t, func() { Matf64FromData(s, 15) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf64FromData(s, 1, 2) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf64FromData(s, 12, 12, 4) }, "too many args")
+	assert.Panics(t, func() { Matf64FromData(s, 15) }, "wrong expected size")
+	assert.Panics(t, func() { Matf64FromData(s, 1, 2) }, "wrong expected size")
+	assert.Panics(t, func() { Matf64FromData(s, 12, 12, 4) }, "too many args")
 }
 
 func TestMatf64FromCSV(t *testing.T) {
 	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
 	rows := 3
 	cols := 4
 
 	filename := "non-exitant-file"
 
-	// assert.Panics(t, func() { Matf64FromCSV(filename) }, "should panic")
+	assert.Panics(t, func() { Matf64FromCSV(filename) }, "should panic")
 
 	filename = "test.csv"
 	str := "1.0,1.0,2.0,3.0\n5.0,8.0,13.0,21.0\n34.0,55.0,89.0,144.0"
@@ -199,6 +202,7 @@ func TestMatf64FromCSV(t *testing.T) {
 
 func TestRandf64(t *testing.T) {
 	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
 	rows := 31
 	cols := 42
 
@@ -221,12 +225,13 @@ func TestRandf64(t *testing.T) {
 		}
 	}
 
-	// assert.Panics(t, func() { RandMatf64(rows, cols, 12.0, 2.0, 13.0) }, "should panic")
-	// assert.Panics(t, func() { RandMatf64(rows, cols, 12.0, 2.0) }, "should panic")
+	assert.Panics(t, func() { RandMatf64(rows, cols, 12.0, 2.0, 13.0) }, "should panic")
+	assert.Panics(t, func() { RandMatf64(rows, cols, 12.0, 2.0) }, "should panic")
 }
 
 func TestReshapef64(t *testing.T) {
 	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
 	rows, cols := 10, 12
 	s := make([]float64, 120)
 	for i := 0; i < len(s); i++ {
@@ -239,7 +244,7 @@ func TestReshapef64(t *testing.T) {
 		assert.Equal(t, s[i], m.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.Reshape(rows, rows) }, "should panic")
+	assert.Panics(t, func() { m.Reshape(rows, rows) }, "should panic")
 }
 
 func TestShapef64(t *testing.T) {
@@ -301,6 +306,29 @@ func TestToCSVf64(t *testing.T) {
 	os.Remove(filename)
 }
 
+func TestAppendToCSVf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(3, 4)
+	for i := range m.vals {
+		m.vals[i] = float64(i)
+	}
+	n := Newf64(2, 4).SetAll(7.0)
+	filename := "appendtocsv_test.csv"
+	os.Remove(filename)
+	m.AppendToCSV(filename)
+	n.AppendToCSV(filename)
+	o := Matf64FromCSV(filename)
+	assert.Equal(t, 5, o.r, "should be equal")
+	assert.Equal(t, 4, o.c, "should be equal")
+	assert.Equal(t, append(m.ToSlice1D(), n.ToSlice1D()...), o.vals, "should be equal")
+
+	m.ToCSV(filename)
+	n.AppendToCSV(filename)
+	o = Matf64FromCSV(filename)
+	assert.Equal(t, append(m.ToSlice1D(), n.ToSlice1D()...), o.vals, "should be equal")
+	os.Remove(filename)
+}
+
 func TestGetf64(t *testing.T) {
 	t.Helper()
 	rows := 17
@@ -367,6 +395,7 @@ func TestSetf64(t *testing.T) {
 
 func TestSetColf64(t *testing.T) {
 	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
 	m := Newf64(3, 4)
 	m.SetCol(-1, 3.0)
 	n := m.Col(-1)
@@ -389,16 +418,17 @@ func TestSetColf64(t *testing.T) {
 		assert.Equal(t, 0.0, n.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.SetCol(-5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(-1, []float64{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(1, []float64{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(-1, 1) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-1, []float64{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(1, []float64{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(1, 1) }, "should panic")
 }
 
 func TestSetRowf64(t *testing.T) {
 	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
 	m := Newf64(3, 4)
 	m.SetRow(-1, 3.0)
 	n := m.Row(-1)
@@ -421,12 +451,12 @@ func TestSetRowf64(t *testing.T) {
 		assert.Equal(t, 0.0, n.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.SetRow(-5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(-1, []float64{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(1, []float64{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(-1, 1) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-1, []float64{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(1, []float64{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(1, 1) }, "should panic")
 }
 
 func TestColf64(t *testing.T) {
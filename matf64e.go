@@ -0,0 +1,73 @@
+package matrix
+
+// The functions below are the variants of the constructors and of the
+// input and output methods of Matf64 which return an error, instead of
+// exiting the program on failure. Other operations can be made to return an
+// error with Catch.
+
+/*
+Newf64E does the same as Newf64, but returns an error instead of exiting.
+*/
+func Newf64E(dims ...int) (m *Matf64, err error) {
+	err = Catch(func() { m = Newf64(dims...) })
+	return m, err
+}
+
+/*
+Matf64FromDataE does the same as Matf64FromData, but returns an error
+instead of exiting.
+*/
+func Matf64FromDataE(oneOrTwoDSlice interface{}, dims ...int) (m *Matf64, err error) {
+	err = Catch(func() { m = Matf64FromData(oneOrTwoDSlice, dims...) })
+	return m, err
+}
+
+/*
+Matf64FromCSVE does the same as Matf64FromCSV, but returns an error instead
+of exiting, for example when the file cannot be opened, or a line does not
+have the same number of elements as the others.
+*/
+func Matf64FromCSVE(filename string) (m *Matf64, err error) {
+	err = Catch(func() { m = Matf64FromCSV(filename) })
+	return m, err
+}
+
+/*
+RandMatf64E does the same as RandMatf64, but returns an error instead of
+exiting.
+*/
+func RandMatf64E(r, c int, args ...float64) (m *Matf64, err error) {
+	err = Catch(func() { m = RandMatf64(r, c, args...) })
+	return m, err
+}
+
+/*
+Loadf64E does the same as Loadf64, but returns an error instead of exiting,
+for example when the checksum of the file does not match.
+*/
+func Loadf64E(fileName string) (m *Matf64, err error) {
+	err = Catch(func() { m = Loadf64(fileName) })
+	return m, err
+}
+
+/*
+ToCSVE does the same as ToCSV, but returns an error instead of exiting.
+*/
+func (m *Matf64) ToCSVE(fileName string) error {
+	return Catch(func() { m.ToCSV(fileName) })
+}
+
+/*
+AppendToCSVE does the same as AppendToCSV, but returns an error instead of
+exiting.
+*/
+func (m *Matf64) AppendToCSVE(fileName string) error {
+	return Catch(func() { m.AppendToCSV(fileName) })
+}
+
+/*
+SaveE does the same as Save, but returns an error instead of exiting.
+*/
+func (m *Matf64) SaveE(fileName string) error {
+	return Catch(func() { m.Save(fileName) })
+}
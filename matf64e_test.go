@@ -0,0 +1,37 @@
+package matrix
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64E(t *testing.T) {
+	t.Helper()
+	_, err := Newf64E(1, 2, 3)
+	assert.Error(t, err, "should fail")
+	m, err := Newf64E(2, 3)
+	assert.NoError(t, err, "should not fail")
+	assert.True(t, m.Equals(Newf64(2, 3)), "should be equal")
+
+	_, err = Matf64FromDataE([]float64{1, 2, 3}, 2, 2)
+	assert.Error(t, err, "should fail")
+	_, err = Matf64FromCSVE("does_not_exist.csv")
+	assert.Error(t, err, "should fail")
+	_, err = Loadf64E("does_not_exist.bin")
+	assert.Error(t, err, "should fail")
+
+	filename := "matf64e_test.csv"
+	assert.NoError(t, m.ToCSVE(filename), "should not fail")
+	defer func() {
+		if err := os.Remove(filename); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	n, err := Matf64FromCSVE(filename)
+	assert.NoError(t, err, "should not fail")
+	assert.True(t, n.Equals(m), "should be equal")
+	assert.Error(t, m.SaveE("no_such_dir/m.bin"), "should fail")
+}
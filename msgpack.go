@@ -0,0 +1,304 @@
+package matrix
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+/*
+MarshalMsgpack encodes a Matf64 in the MessagePack format, as a map with the
+keys "rows", "cols" and "data", where "data" is an array of the elements of the
+Matf64 in row major order:
+
+	{"rows": 2, "cols": 3, "data": [1.0, 2.0, 3.0, 4.0, 5.0, 6.0]}
+
+Only standard MessagePack types are used, so the result can be decoded by any
+MessagePack library. MarshalMsgpack implements the Marshaler interface of
+github.com/vmihailenco/msgpack, so a Matf64 can also be embedded in larger
+messages encoded by that package. The returned error is always nil.
+*/
+func (m *Matf64) MarshalMsgpack() ([]byte, error) {
+	n := m.r * m.c
+	b := make([]byte, 0, 32+9*n)
+	b = append(b, 0x83)
+	b = appendMsgpackStr(b, "rows")
+	b = appendMsgpackUint(b, uint64(m.r))
+	b = appendMsgpackStr(b, "cols")
+	b = appendMsgpackUint(b, uint64(m.c))
+	b = appendMsgpackStr(b, "data")
+	switch {
+	case n < 16:
+		b = append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xdc, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0xdd)
+		b = appendUint32(b, uint32(n))
+	}
+	for i := 0; i < n; i++ {
+		b = append(b, 0xcb)
+		b = appendUint64(b, math.Float64bits(m.vals[i]))
+	}
+	return b, nil
+}
+
+/*
+UnmarshalMsgpack decodes a Matf64 which was encoded by MarshalMsgpack, and
+replaces the contents of the receiver with it:
+
+	b, _ := m.MarshalMsgpack()
+	n := matrix.Newf64()
+	if err := n.UnmarshalMsgpack(b); err != nil {
+		log.Fatal(err)
+	}
+
+The elements of "data" may be encoded as any MessagePack float or integer,
+and the keys of the map may appear in any order. An error is returned if the
+data is not a valid encoding of a Matf64, in which case the receiver is left
+unchanged.
+*/
+func (m *Matf64) UnmarshalMsgpack(b []byte) error {
+	if m.readOnly {
+		return errReadOnlyTarget
+	}
+	d := &msgpackDecoder{b: b}
+	n, err := d.mapLen()
+	if err != nil {
+		return err
+	}
+	// Every key and value takes at least one byte.
+	if n > (len(d.b)-d.i)/2 {
+		return errMsgpackShort
+	}
+	rows, cols := -1, -1
+	var vals []float64
+	for i := 0; i < n; i++ {
+		key, err := d.str()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "rows":
+			rows, err = d.int()
+		case "cols":
+			cols, err = d.int()
+		case "data":
+			var l int
+			l, err = d.arrayLen()
+			if err != nil {
+				return err
+			}
+			// Every element takes at least one byte.
+			if l > len(d.b)-d.i {
+				return errMsgpackShort
+			}
+			vals = make([]float64, l)
+			for j := range vals {
+				if vals[j], err = d.float(); err != nil {
+					return err
+				}
+			}
+		default:
+			err = fmt.Errorf("matrix: unexpected msgpack key %q", key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if rows < 0 || cols < 0 || vals == nil {
+		return errors.New("matrix: msgpack map must contain rows, cols and data")
+	}
+	// rows*cols may overflow, so the shape is checked by division first.
+	if (cols != 0 && rows > len(vals)/cols) || rows*cols != len(vals) {
+		return fmt.Errorf("matrix: msgpack data has %d elements, expected %d*%d",
+			len(vals), rows, cols)
+	}
+	m.r, m.c, m.vals = rows, cols, vals
+	return nil
+}
+
+func appendMsgpackStr(b []byte, s string) []byte {
+	// All keys used by this package are shorter than 32 bytes.
+	b = append(b, 0xa0|byte(len(s)))
+	return append(b, s...)
+}
+
+func appendMsgpackUint(b []byte, v uint64) []byte {
+	switch {
+	case v < 128:
+		return append(b, byte(v))
+	case v <= math.MaxUint8:
+		return append(b, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return append(b, 0xcd, byte(v>>8), byte(v))
+	case v <= math.MaxUint32:
+		b = append(b, 0xce)
+		return appendUint32(b, uint32(v))
+	}
+	b = append(b, 0xcf)
+	return appendUint64(b, v)
+}
+
+// appendUint32 appends v to b in big endian order.
+func appendUint32(b []byte, v uint32) []byte {
+	var p [4]byte
+	binary.BigEndian.PutUint32(p[:], v)
+	return append(b, p[:]...)
+}
+
+// appendUint64 appends v to b in big endian order.
+func appendUint64(b []byte, v uint64) []byte {
+	var p [8]byte
+	binary.BigEndian.PutUint64(p[:], v)
+	return append(b, p[:]...)
+}
+
+// largestInt is the largest int, as math.MaxInt, which needs Go 1.17.
+const largestInt = int(^uint(0) >> 1)
+
+var errMsgpackShort = errors.New("matrix: unexpected end of msgpack data")
+
+type msgpackDecoder struct {
+	b []byte
+	i int
+}
+
+func (d *msgpackDecoder) next(n int) ([]byte, error) {
+	if d.i+n > len(d.b) {
+		return nil, errMsgpackShort
+	}
+	p := d.b[d.i : d.i+n]
+	d.i += n
+	return p, nil
+}
+
+func (d *msgpackDecoder) readByte() (byte, error) {
+	p, err := d.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return p[0], nil
+}
+
+func (d *msgpackDecoder) length(code byte, fixMask, fixBase, c16, c32 byte) (int, error) {
+	switch {
+	case code&fixMask == fixBase:
+		return int(code &^ fixMask), nil
+	case code == c16:
+		p, err := d.next(2)
+		if err != nil {
+			return 0, err
+		}
+		return int(binary.BigEndian.Uint16(p)), nil
+	case code == c32:
+		p, err := d.next(4)
+		if err != nil {
+			return 0, err
+		}
+		return int(binary.BigEndian.Uint32(p)), nil
+	}
+	return 0, fmt.Errorf("matrix: unexpected msgpack type 0x%02x", code)
+}
+
+func (d *msgpackDecoder) mapLen() (int, error) {
+	code, err := d.readByte()
+	if err != nil {
+		return 0, err
+	}
+	return d.length(code, 0xf0, 0x80, 0xde, 0xdf)
+}
+
+func (d *msgpackDecoder) arrayLen() (int, error) {
+	code, err := d.readByte()
+	if err != nil {
+		return 0, err
+	}
+	return d.length(code, 0xf0, 0x90, 0xdc, 0xdd)
+}
+
+func (d *msgpackDecoder) str() (string, error) {
+	code, err := d.readByte()
+	if err != nil {
+		return "", err
+	}
+	var n int
+	if code == 0xd9 {
+		c, err := d.readByte()
+		if err != nil {
+			return "", err
+		}
+		n = int(c)
+	} else if n, err = d.length(code, 0xe0, 0xa0, 0xda, 0xdb); err != nil {
+		return "", err
+	}
+	p, err := d.next(n)
+	if err != nil {
+		return "", err
+	}
+	return string(p), nil
+}
+
+func (d *msgpackDecoder) int() (int, error) {
+	v, err := d.float()
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || v != math.Trunc(v) || v >= float64(largestInt) {
+		return 0, fmt.Errorf("matrix: expected a msgpack dimension, got %v", v)
+	}
+	return int(v), nil
+}
+
+// float decodes any msgpack number as a float64.
+func (d *msgpackDecoder) float() (float64, error) {
+	code, err := d.readByte()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case code < 0x80:
+		return float64(code), nil
+	case code >= 0xe0:
+		return float64(int8(code)), nil
+	}
+	var size int
+	switch code {
+	case 0xcc, 0xd0:
+		size = 1
+	case 0xcd, 0xd1:
+		size = 2
+	case 0xca, 0xce, 0xd2:
+		size = 4
+	case 0xcb, 0xcf, 0xd3:
+		size = 8
+	default:
+		return 0, fmt.Errorf("matrix: expected a msgpack number, got type 0x%02x", code)
+	}
+	p, err := d.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch code {
+	case 0xca:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(p))), nil
+	case 0xcb:
+		return math.Float64frombits(binary.BigEndian.Uint64(p)), nil
+	case 0xcc:
+		return float64(p[0]), nil
+	case 0xcd:
+		return float64(binary.BigEndian.Uint16(p)), nil
+	case 0xce:
+		return float64(binary.BigEndian.Uint32(p)), nil
+	case 0xcf:
+		return float64(binary.BigEndian.Uint64(p)), nil
+	case 0xd0:
+		return float64(int8(p[0])), nil
+	case 0xd1:
+		return float64(int16(binary.BigEndian.Uint16(p))), nil
+	case 0xd2:
+		return float64(int32(binary.BigEndian.Uint32(p))), nil
+	}
+	return float64(int64(binary.BigEndian.Uint64(p))), nil
+}
@@ -0,0 +1,65 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalMsgpackf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1.5, -2.0}, 2, 1)
+	b, err := m.MarshalMsgpack()
+	assert.Nil(t, err, "should be nil")
+	expected := []byte{0x83,
+		0xa4, 'r', 'o', 'w', 's', 0x02,
+		0xa4, 'c', 'o', 'l', 's', 0x01,
+		0xa4, 'd', 'a', 't', 'a', 0x92,
+		0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0,
+		0xcb, 0xc0, 0x00, 0, 0, 0, 0, 0, 0,
+	}
+	assert.Equal(t, expected, b, "should be equal")
+}
+
+func TestUnmarshalMsgpackf64(t *testing.T) {
+	t.Helper()
+	for _, dims := range [][2]int{{0, 0}, {3, 4}, {300, 300}} {
+		m := RandMatf64(dims[0], dims[1])
+		b, err := m.MarshalMsgpack()
+		assert.Nil(t, err, "should be nil")
+		n := Newf64()
+		assert.Nil(t, n.UnmarshalMsgpack(b), "should be nil")
+		assert.True(t, n.Equals(m), "should round trip")
+	}
+
+	// keys in a different order, with integers and a float32 as data.
+	b := []byte{0x83,
+		0xa4, 'd', 'a', 't', 'a', 0x93, 0x01, 0xff,
+		0xca, 0x3f, 0xc0, 0x00, 0x00,
+		0xa4, 'c', 'o', 'l', 's', 0x03,
+		0xa4, 'r', 'o', 'w', 's', 0x01,
+	}
+	n := Newf64()
+	assert.Nil(t, n.UnmarshalMsgpack(b), "should be nil")
+	assert.True(t, n.Equals(Matf64FromData([]float64{1.0, -1.0, 1.5})), "should be equal")
+
+	m := Newf64(2, 2)
+	assert.NotNil(t, m.UnmarshalMsgpack(b[:10]), "should fail on truncated data")
+	assert.NotNil(t, m.UnmarshalMsgpack(append([]byte{0x83}, b[1:20]...)), "should fail")
+	b[len(b)-1] = 0x02
+	assert.NotNil(t, m.UnmarshalMsgpack(b), "should fail on a shape mismatch")
+	assert.True(t, m.Equals(Newf64(2, 2)), "should be unchanged on failure")
+
+	// 2^32 * 2^32 overflows to 0, which must not match the empty data.
+	b = []byte{0x83,
+		0xa4, 'r', 'o', 'w', 's', 0xcf, 0, 0, 0, 1, 0, 0, 0, 0,
+		0xa4, 'c', 'o', 'l', 's', 0xcf, 0, 0, 0, 1, 0, 0, 0, 0,
+		0xa4, 'd', 'a', 't', 'a', 0x90,
+	}
+	assert.NotNil(t, m.UnmarshalMsgpack(b), "should fail on an overflowing shape")
+	b = []byte{0xa4, 'r', 'o', 'w', 's', 0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	assert.NotNil(t, m.UnmarshalMsgpack(append([]byte{0x81}, b...)), "should fail on a huge dimension")
+	assert.NotNil(t, m.UnmarshalMsgpack([]byte{0xdf, 0xff, 0xff, 0xff, 0xff, 0xa0}), "should fail on a huge map")
+	assert.NotNil(t, m.UnmarshalMsgpack([]byte{0x81, 0xa4, 'd', 'a', 't', 'a', 0xdd, 0xff, 0xff, 0xff, 0xff}), "should fail on a huge array")
+	assert.True(t, m.Equals(Newf64(2, 2)), "should be unchanged on failure")
+}
@@ -0,0 +1,51 @@
+package matrix
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+/*
+SampleMVNf64 draws n samples from the multivariate normal distribution with
+the passed mean, a row or column vector of length d, and d by d covariance
+matrix, and returns them as the rows of an n by d Matf64:
+
+	src := rand.NewSource(42)
+	x := matrix.SampleMVNf64(mean, cov, 1000, src)
+	// x.Cov() is close to cov
+
+Each sample is the mean plus L.Dot(z), where L is the factor of Cholesky of
+the covariance, and z holds independent standard normal numbers drawn from
+the passed source, so the samples are reproducible for a given seed. Only the
+upper triangle of the covariance is read, and it must be positive definite.
+*/
+func SampleMVNf64(mean, cov *Matf64, n int, src rand.Source) *Matf64 {
+	checkVector("SampleMVNf64()", "mean", mean)
+	d := len(mean.vals)
+	if cov.r != d || cov.c != d || n < 0 {
+		s := "\nIn matrix.%s, the mean has %d elements, so the covariance must\n"
+		s += "be %d by %d, and the number of samples must not be negative,\n"
+		s += "however the covariance is %d by %d and %d samples were requested."
+		s = fmt.Sprintf(s, "SampleMVNf64()", d, d, d, cov.r, cov.c, n)
+		printErr(ErrShape, s)
+	}
+	l := SymMatf64FromMatf64(cov).Cholesky()
+	rng := rand.New(src)
+	x := Newf64(n, d)
+	z := make([]float64, d)
+	for k := 0; k < n; k++ {
+		for j := range z {
+			z[j] = rng.NormFloat64()
+		}
+		row := x.vals[k*d : (k+1)*d]
+		for i := range row {
+			v := mean.vals[i]
+			li := l.vals[i*(i+1)/2:]
+			for j := 0; j <= i; j++ {
+				v += li[j] * z[j]
+			}
+			row[i] = v
+		}
+	}
+	return x
+}
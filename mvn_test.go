@@ -0,0 +1,28 @@
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleMVNf64(t *testing.T) {
+	t.Helper()
+	mean := Matf64FromData([]float64{1, -2, 3}, 1, 3)
+	cov := Matf64FromData([]float64{
+		4, 1, 0.5,
+		1, 2, -0.3,
+		0.5, -0.3, 1,
+	}, 3, 3)
+	x := SampleMVNf64(mean, cov, 20000, rand.NewSource(1))
+	r, c := x.Shape()
+	assert.Equal(t, 20000, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	for j := 0; j < 3; j++ {
+		assert.InDelta(t, mean.Get(0, j), x.Col(j).Sum()/20000, 0.05, "should be close to the mean")
+	}
+	assertMatInDelta(t, cov, x.Cov().ToMatf64(), 0.1)
+	y := SampleMVNf64(mean, cov, 5, rand.NewSource(1))
+	assert.True(t, y.Equals(SampleMVNf64(mean, cov, 5, rand.NewSource(1))), "should be reproducible")
+}
@@ -0,0 +1,435 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+NDArrayf64 is an N-dimensional array of float64s, with an arbitrary shape.
+Like numpy arrays, an NDArrayf64 is a view onto a flat slice of data, defined
+by a shape, a stride for each axis, and an offset. This allows operations such
+as Transpose and (in most cases) Reshape to be done without copying any data.
+
+Matf64 remains the optimized type for 2D data, and the two can be converted
+with NDArrayf64FromMatf64 and ToMatf64. The elementwise operations of
+NDArrayf64 follow the broadcasting rules of numpy:
+
+	a := matrix.NewNDArrayf64(4, 1, 3)
+	b := matrix.NewNDArrayf64(5, 1)
+	c := a.Add(b) // c has the shape (4, 5, 3)
+
+As with Matf64, the fields of this struct are not directly accessible.
+*/
+type NDArrayf64 struct {
+	shape   []int
+	strides []int
+	offset  int
+	data    []float64
+}
+
+/*
+NewNDArrayf64 returns an NDArrayf64 of the passed shape, whose elements are
+all zero. Calling it with no arguments results in a 0-dimensional array which
+holds a single element.
+*/
+func NewNDArrayf64(shape ...int) *NDArrayf64 {
+	size := 1
+	for _, d := range shape {
+		if d < 0 {
+			s := "\nIn matrix.%s, the dimensions cannot be negative, however\n"
+			s += "the shape %v was received."
+			s = fmt.Sprintf(s, "NewNDArrayf64()", shape)
+			printErr(ErrArgument, s)
+		}
+		size *= d
+	}
+	return newNDArrayf64(make([]float64, size), shape)
+}
+
+// newNDArrayf64 returns a contiguous, row major NDArrayf64 of the passed
+// shape, backed by data.
+func newNDArrayf64(data []float64, shape []int) *NDArrayf64 {
+	a := &NDArrayf64{
+		shape:   make([]int, len(shape)),
+		strides: make([]int, len(shape)),
+		data:    data,
+	}
+	copy(a.shape, shape)
+	stride := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		a.strides[i] = stride
+		stride *= shape[i]
+	}
+	return a
+}
+
+/*
+NDArrayf64FromData creates an NDArrayf64 of the passed shape from a slice of
+data, in row major order. The length of the slice must be equal to the product
+of the dimensions. The data is copied.
+*/
+func NDArrayf64FromData(data []float64, shape ...int) *NDArrayf64 {
+	a := NewNDArrayf64(shape...)
+	if len(data) != len(a.data) {
+		s := "\nIn matrix.%s, the shape %v has %d elements, however the\n"
+		s += "passed data has %d elements. They must be equal."
+		s = fmt.Sprintf(s, "NDArrayf64FromData()", shape, len(a.data), len(data))
+		printErr(ErrShape, s)
+	}
+	copy(a.data, data)
+	return a
+}
+
+/*
+NDArrayf64FromMatf64 returns a 2D NDArrayf64 with the same shape as the passed
+Matf64, which shares its data with it.
+*/
+func NDArrayf64FromMatf64(m *Matf64) *NDArrayf64 {
+	return newNDArrayf64(m.vals[:m.r*m.c], []int{m.r, m.c})
+}
+
+/*
+ToMatf64 returns a copy of a 2D NDArrayf64 as a Matf64.
+*/
+func (a *NDArrayf64) ToMatf64() *Matf64 {
+	if len(a.shape) != 2 {
+		s := "\nIn %s, only a 2D NDArrayf64 can be converted to a Matf64,\n"
+		s += "however the receiver has the shape %v."
+		s = fmt.Sprintf(s, "ToMatf64()", a.shape)
+		printErr(ErrShape, s)
+	}
+	m := Newf64(a.shape[0], a.shape[1])
+	a.each(func(i, off int) {
+		m.vals[i] = a.data[off]
+	})
+	return m
+}
+
+/*
+Shape returns the dimensions of an NDArrayf64.
+*/
+func (a *NDArrayf64) Shape() []int {
+	shape := make([]int, len(a.shape))
+	copy(shape, a.shape)
+	return shape
+}
+
+/*
+NDim returns the number of dimensions (axes) of an NDArrayf64.
+*/
+func (a *NDArrayf64) NDim() int {
+	return len(a.shape)
+}
+
+/*
+Size returns the number of elements of an NDArrayf64.
+*/
+func (a *NDArrayf64) Size() int {
+	size := 1
+	for _, d := range a.shape {
+		size *= d
+	}
+	return size
+}
+
+func (a *NDArrayf64) index(fn string, idx []int) int {
+	if len(idx) != len(a.shape) {
+		s := "\nIn %s, %d indices were passed to an NDArrayf64 with %d\n"
+		s += "dimensions. They must be equal."
+		s = fmt.Sprintf(s, fn, len(idx), len(a.shape))
+		printHelperErr(ErrShape, s)
+	}
+	off := a.offset
+	for i, j := range idx {
+		if j < 0 || j >= a.shape[i] {
+			s := "\nIn %s, index %d of axis %d is outside of bounds [0, %d)\n"
+			s = fmt.Sprintf(s, fn, j, i, a.shape[i])
+			printHelperErr(ErrBounds, s)
+		}
+		off += j * a.strides[i]
+	}
+	return off
+}
+
+/*
+Get returns the element of an NDArrayf64 at the passed indices, one for each
+axis.
+*/
+func (a *NDArrayf64) Get(idx ...int) float64 {
+	return a.data[a.index("Get()", idx)]
+}
+
+/*
+Set sets the element of an NDArrayf64 at the passed indices, one for each
+axis, to the passed value:
+
+	a.Set(1.0, 0, 2, 1) // a[0, 2, 1] = 1.0
+*/
+func (a *NDArrayf64) Set(val float64, idx ...int) *NDArrayf64 {
+	a.data[a.index("Set()", idx)] = val
+	return a
+}
+
+// each calls f for every element of a, in row major order, with the position
+// of the element in that order, and its offset in a.data.
+func (a *NDArrayf64) each(f func(i, off int)) {
+	size := a.Size()
+	if size == 0 {
+		return
+	}
+	idx := make([]int, len(a.shape))
+	off := a.offset
+	for i := 0; i < size; i++ {
+		f(i, off)
+		for ax := len(idx) - 1; ax >= 0; ax-- {
+			idx[ax]++
+			off += a.strides[ax]
+			if idx[ax] < a.shape[ax] {
+				break
+			}
+			off -= idx[ax] * a.strides[ax]
+			idx[ax] = 0
+		}
+	}
+}
+
+/*
+IsContiguous reports whether the elements of an NDArrayf64 are laid out in
+row major order without gaps in its underlying data, which is the case for
+newly created arrays, but not for the results of Transpose.
+*/
+func (a *NDArrayf64) IsContiguous() bool {
+	stride := 1
+	for i := len(a.shape) - 1; i >= 0; i-- {
+		if a.shape[i] != 1 && a.strides[i] != stride {
+			return false
+		}
+		stride *= a.shape[i]
+	}
+	return true
+}
+
+/*
+Copy returns a contiguous duplicate of an NDArrayf64, which shares no data
+with the original.
+*/
+func (a *NDArrayf64) Copy() *NDArrayf64 {
+	n := NewNDArrayf64(a.shape...)
+	a.each(func(i, off int) {
+		n.data[i] = a.data[off]
+	})
+	return n
+}
+
+/*
+Reshape returns an NDArrayf64 with the same elements as the receiver, in the
+same row major order, but with the passed shape. The number of elements of
+the new shape must be equal to that of the receiver. At most one dimension may
+be -1, in which case it is inferred from the number of elements, unless
+another dimension is 0:
+
+	a := matrix.NewNDArrayf64(4, 6)
+	b := a.Reshape(2, -1, 3) // b has the shape (2, 4, 3)
+
+If the receiver is contiguous, the returned NDArrayf64 shares its data with
+it. Otherwise, the data is copied.
+*/
+func (a *NDArrayf64) Reshape(shape ...int) *NDArrayf64 {
+	shape = append([]int(nil), shape...)
+	size, infer := 1, -1
+	for i, d := range shape {
+		switch {
+		case d == -1 && infer == -1:
+			infer = i
+		case d < 0:
+			s := "\nIn %s, the shape %v is not valid. Only one dimension may\n"
+			s += "be -1, and the others cannot be negative."
+			s = fmt.Sprintf(s, "Reshape()", shape)
+			printErr(ErrArgument, s)
+		default:
+			size *= d
+		}
+	}
+	if infer >= 0 && size == 0 {
+		s := "\nIn %s, the shape %v is not valid. A dimension of -1 cannot\n"
+		s += "be inferred when another dimension is 0."
+		s = fmt.Sprintf(s, "Reshape()", shape)
+		printErr(ErrArgument, s)
+	}
+	if infer >= 0 {
+		shape[infer] = a.Size() / size
+		size *= shape[infer]
+	}
+	if size != a.Size() {
+		s := "\nIn %s, the receiver has the shape %v, which cannot be\n"
+		s += "reshaped to %v, as the number of elements does not match."
+		s = fmt.Sprintf(s, "Reshape()", a.shape, shape)
+		printErr(ErrShape, s)
+	}
+	if !a.IsContiguous() {
+		a = a.Copy()
+	}
+	n := newNDArrayf64(a.data, shape)
+	n.offset = a.offset
+	return n
+}
+
+/*
+Transpose returns a view of an NDArrayf64 with its axes permuted, such that
+axis i of the result is axis axes[i] of the receiver. When no axes are passed,
+the order of the axes is reversed, which for a 2D array is the usual
+transpose:
+
+	a := matrix.NewNDArrayf64(2, 3, 4)
+	a.Transpose()        // shape (4, 3, 2)
+	a.Transpose(0, 2, 1) // shape (2, 4, 3)
+
+No data is copied, and the result shares its data with the receiver.
+*/
+func (a *NDArrayf64) Transpose(axes ...int) *NDArrayf64 {
+	n := len(a.shape)
+	if len(axes) == 0 {
+		axes = make([]int, n)
+		for i := range axes {
+			axes[i] = n - 1 - i
+		}
+	}
+	seen := make([]bool, n)
+	valid := len(axes) == n
+	for _, ax := range axes {
+		if !valid || ax < 0 || ax >= n || seen[ax] {
+			valid = false
+			break
+		}
+		seen[ax] = true
+	}
+	if !valid {
+		s := "\nIn %s, %v is not a permutation of the %d axes of the receiver."
+		s = fmt.Sprintf(s, "Transpose()", axes, n)
+		printErr(ErrArgument, s)
+	}
+	t := &NDArrayf64{
+		shape:   make([]int, n),
+		strides: make([]int, n),
+		offset:  a.offset,
+		data:    a.data,
+	}
+	for i, ax := range axes {
+		t.shape[i] = a.shape[ax]
+		t.strides[i] = a.strides[ax]
+	}
+	return t
+}
+
+/*
+BroadcastShapes returns the shape which results from broadcasting arrays of
+the passed shapes together, and whether they can be broadcast at all. The
+shapes are aligned at their last axis, and two dimensions are compatible when
+they are equal, or one of them is 1.
+*/
+func BroadcastShapes(shapes ...[]int) ([]int, bool) {
+	n := 0
+	for _, s := range shapes {
+		if len(s) > n {
+			n = len(s)
+		}
+	}
+	out := make([]int, n)
+	for i := range out {
+		out[i] = 1
+	}
+	for _, s := range shapes {
+		for i, d := range s {
+			j := n - len(s) + i
+			switch {
+			case out[j] == 1:
+				out[j] = d
+			case d != 1 && d != out[j]:
+				return nil, false
+			}
+		}
+	}
+	return out, true
+}
+
+// broadcastTo returns a view of a with the passed shape, which must be a
+// valid broadcast of the shape of a. Broadcast axes have a stride of zero.
+func (a *NDArrayf64) broadcastTo(shape []int) *NDArrayf64 {
+	b := &NDArrayf64{
+		shape:   shape,
+		strides: make([]int, len(shape)),
+		offset:  a.offset,
+		data:    a.data,
+	}
+	lead := len(shape) - len(a.shape)
+	for i := range a.shape {
+		if a.shape[i] != 1 {
+			b.strides[lead+i] = a.strides[i]
+		}
+	}
+	return b
+}
+
+func (a *NDArrayf64) broadcastOp(fn string, n *NDArrayf64, f func(x, y float64) float64) *NDArrayf64 {
+	shape, ok := BroadcastShapes(a.shape, n.shape)
+	if !ok {
+		s := "\nIn %s, the shapes %v and %v cannot be broadcast together."
+		s = fmt.Sprintf(s, fn, a.shape, n.shape)
+		printHelperErr(ErrShape, s)
+	}
+	out := NewNDArrayf64(shape...)
+	x, y := a.broadcastTo(shape), n.broadcastTo(shape)
+	offs := make([]int, len(out.data))
+	y.each(func(i, off int) {
+		offs[i] = off
+	})
+	x.each(func(i, off int) {
+		out.data[i] = f(x.data[off], y.data[offs[i]])
+	})
+	return out
+}
+
+/*
+Add returns a new NDArrayf64 holding the elementwise sum of the receiver and
+the passed NDArrayf64, which are broadcast together. Unlike the methods of
+Matf64, the receiver is not changed, as the shape of the result may differ
+from that of the receiver.
+*/
+func (a *NDArrayf64) Add(n *NDArrayf64) *NDArrayf64 {
+	return a.broadcastOp("Add()", n, func(x, y float64) float64 { return x + y })
+}
+
+/*
+Sub returns a new NDArrayf64 holding the elementwise difference of the
+receiver and the passed NDArrayf64, which are broadcast together.
+*/
+func (a *NDArrayf64) Sub(n *NDArrayf64) *NDArrayf64 {
+	return a.broadcastOp("Sub()", n, func(x, y float64) float64 { return x - y })
+}
+
+/*
+Mul returns a new NDArrayf64 holding the elementwise product of the receiver
+and the passed NDArrayf64, which are broadcast together.
+*/
+func (a *NDArrayf64) Mul(n *NDArrayf64) *NDArrayf64 {
+	return a.broadcastOp("Mul()", n, func(x, y float64) float64 { return x * y })
+}
+
+/*
+Div returns a new NDArrayf64 holding the elementwise quotient of the receiver
+and the passed NDArrayf64, which are broadcast together.
+*/
+func (a *NDArrayf64) Div(n *NDArrayf64) *NDArrayf64 {
+	return a.broadcastOp("Div()", n, func(x, y float64) float64 { return x / y })
+}
+
+/*
+ToSlice1D returns the elements of an NDArrayf64 in row major order.
+*/
+func (a *NDArrayf64) ToSlice1D() []float64 {
+	s := make([]float64, a.Size())
+	a.each(func(i, off int) {
+		s[i] = a.data[off]
+	})
+	return s
+}
@@ -0,0 +1,135 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seqNDArrayf64(shape ...int) *NDArrayf64 {
+	a := NewNDArrayf64(shape...)
+	for i := range a.data {
+		a.data[i] = float64(i)
+	}
+	return a
+}
+
+func TestNewNDArrayf64(t *testing.T) {
+	t.Helper()
+	a := NewNDArrayf64(2, 3, 4)
+	assert.Equal(t, []int{2, 3, 4}, a.Shape(), "should be equal")
+	assert.Equal(t, []int{12, 4, 1}, a.strides, "should be row major")
+	assert.Equal(t, 3, a.NDim(), "should be equal")
+	assert.Equal(t, 24, a.Size(), "should be equal")
+	assert.Equal(t, 1, NewNDArrayf64().Size(), "a scalar has one element")
+}
+
+func TestNDArrayf64FromData(t *testing.T) {
+	t.Helper()
+	data := []float64{1, 2, 3, 4, 5, 6}
+	a := NDArrayf64FromData(data, 3, 2)
+	assert.Equal(t, 4.0, a.Get(1, 1), "should be equal")
+	data[0] = 10.0
+	assert.Equal(t, 1.0, a.Get(0, 0), "should be a copy")
+}
+
+func TestNDArrayMatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	a := NDArrayf64FromMatf64(m)
+	assert.Equal(t, []int{2, 3}, a.Shape(), "should be equal")
+	a.Set(10.0, 1, 2)
+	assert.Equal(t, 10.0, m.Get(1, 2), "should share data")
+	assert.True(t, a.ToMatf64().Equals(m), "should be equal")
+	assert.True(t, a.Transpose().ToMatf64().Equals(m.T()), "should be equal")
+}
+
+func TestNDArrayAtSetf64(t *testing.T) {
+	t.Helper()
+	a := seqNDArrayf64(2, 3, 4)
+	assert.Equal(t, 23.0, a.Get(1, 2, 3), "should be equal")
+	assert.Equal(t, 6.0, a.Get(0, 1, 2), "should be equal")
+	a.Set(-1.0, 1, 0, 0)
+	assert.Equal(t, -1.0, a.data[12], "should be equal")
+}
+
+func TestNDArrayTransposef64(t *testing.T) {
+	t.Helper()
+	a := seqNDArrayf64(2, 3, 4)
+	b := a.Transpose(0, 2, 1)
+	assert.Equal(t, []int{2, 4, 3}, b.Shape(), "should be equal")
+	assert.False(t, b.IsContiguous(), "should not be contiguous")
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 4; k++ {
+				assert.Equal(t, a.Get(i, j, k), b.Get(i, k, j), "should be equal")
+			}
+		}
+	}
+	c := a.Transpose()
+	assert.Equal(t, []int{4, 3, 2}, c.Shape(), "should be reversed")
+	assert.Equal(t, a.Get(1, 2, 3), c.Get(3, 2, 1), "should be equal")
+	c.Set(100.0, 0, 0, 1)
+	assert.Equal(t, 100.0, a.Get(1, 0, 0), "should share data")
+}
+
+func TestNDArrayReshapef64(t *testing.T) {
+	t.Helper()
+	a := seqNDArrayf64(4, 6)
+	b := a.Reshape(2, -1, 3)
+	assert.Equal(t, []int{2, 4, 3}, b.Shape(), "should infer -1")
+	assert.Equal(t, a.ToSlice1D(), b.ToSlice1D(), "should keep the order")
+	b.Set(100.0, 0, 0, 0)
+	assert.Equal(t, 100.0, a.Get(0, 0), "contiguous reshape should share data")
+
+	c := a.Transpose().Reshape(24)
+	assert.Equal(t, a.Transpose().ToSlice1D(), c.ToSlice1D(), "should keep the order")
+	c.Set(-5.0, 0)
+	assert.Equal(t, 100.0, a.Get(0, 0), "non contiguous reshape should copy")
+
+	defer SetPanicMode(SetPanicMode(true))
+	e := NewNDArrayf64(0, 4)
+	assert.Equal(t, []int{4, 0}, e.Reshape(4, 0).Shape(), "should be equal")
+	assert.Panics(t, func() { e.Reshape(0, -1) }, "cannot infer -1 from 0")
+	assert.Panics(t, func() { e.Reshape(-1, 0, 2) }, "cannot infer -1 from 0")
+}
+
+func TestNDArrayCopyf64(t *testing.T) {
+	t.Helper()
+	a := seqNDArrayf64(3, 2).Transpose()
+	b := a.Copy()
+	assert.True(t, b.IsContiguous(), "should be contiguous")
+	assert.Equal(t, []float64{0, 2, 4, 1, 3, 5}, b.ToSlice1D(), "should be equal")
+}
+
+func TestBroadcastShapes(t *testing.T) {
+	t.Helper()
+	s, ok := BroadcastShapes([]int{4, 1, 3}, []int{5, 1})
+	assert.True(t, ok, "should broadcast")
+	assert.Equal(t, []int{4, 5, 3}, s, "should be equal")
+	s, ok = BroadcastShapes([]int{3}, []int{})
+	assert.True(t, ok, "should broadcast")
+	assert.Equal(t, []int{3}, s, "should be equal")
+	_, ok = BroadcastShapes([]int{2, 3}, []int{3, 2})
+	assert.False(t, ok, "should not broadcast")
+}
+
+func TestNDArrayBroadcastOpsf64(t *testing.T) {
+	t.Helper()
+	a := seqNDArrayf64(2, 1, 3)
+	b := NDArrayf64FromData([]float64{10, 20}, 2, 1)
+	c := a.Add(b)
+	assert.Equal(t, []int{2, 2, 3}, c.Shape(), "should be equal")
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			for k := 0; k < 3; k++ {
+				assert.Equal(t, a.Get(i, 0, k)+b.Get(j, 0), c.Get(i, j, k), "should be equal")
+			}
+		}
+	}
+	s := NDArrayf64FromData([]float64{2}, 1)
+	assert.Equal(t, []float64{-2, -1, 0, 1, 2, 3}, a.Sub(s).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{0, 2, 4, 6, 8, 10}, a.Mul(s).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{0, 0.5, 1, 1.5, 2, 2.5}, a.Div(s).ToSlice1D(), "should be equal")
+	assert.Equal(t, []int{2, 1, 3}, a.Shape(), "receiver should not change")
+}
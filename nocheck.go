@@ -0,0 +1,7 @@
+//go:build matnocheck
+// +build matnocheck
+
+package matrix
+
+// checks is false under the matnocheck tag. See check.go.
+const checks = false
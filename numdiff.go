@@ -0,0 +1,86 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+func checkEps(fn string, eps float64) {
+	if !(eps > 0) {
+		s := "\nIn matrix.%s, the step size must be positive, however %v was\n"
+		s += "received."
+		s = fmt.Sprintf(s, fn, eps)
+		printHelperErr(ErrArgument, s)
+	}
+}
+
+/*
+NumGradientf64 returns the gradient of the passed function at x, estimated
+with central differences of step eps:
+
+	df/dx[i] ~ (f(x + eps*e[i]) - f(x - eps*e[i])) / (2 * eps)
+
+The returned Matf64 has the same shape as x. This is mainly useful to check a
+hand written gradient:
+
+	f := func(x *matrix.Matf64) float64 { return x.Copy().Mul(x).Sum() }
+	g := matrix.NumGradientf64(f, x, 1e-6) // close to x.Copy().Mul(2.0)
+
+The elements of x are changed while the gradient is computed, and restored
+before returning, so f must not keep a reference to x. A step of around 1e-6
+times the magnitude of x is a good compromise between truncation and rounding
+errors.
+*/
+func NumGradientf64(f func(*Matf64) float64, x *Matf64, eps float64) *Matf64 {
+	checkEps("NumGradientf64()", eps)
+	g := Newf64(x.r, x.c)
+	for i := range g.vals {
+		orig := x.vals[i]
+		x.vals[i] = orig + eps
+		fp := f(x)
+		x.vals[i] = orig - eps
+		fm := f(x)
+		x.vals[i] = orig
+		g.vals[i] = (fp - fm) / (2 * eps)
+	}
+	return g
+}
+
+/*
+NumJacobianf64 returns the Jacobian of the passed function at x, estimated
+with central differences of step eps, in the same manner as NumGradientf64.
+The element at row i and column j of the result is the derivative of the i-th
+element of the output of f, with respect to the j-th element of x, where both
+are counted in row major order. The result therefore has as many rows as the
+output of f has elements, and as many columns as x has elements.
+
+The output of f must have the same number of elements for every input.
+*/
+func NumJacobianf64(f func(*Matf64) *Matf64, x *Matf64, eps float64) *Matf64 {
+	checkEps("NumJacobianf64()", eps)
+	n := x.r * x.c
+	var jac *Matf64
+	for j := 0; j < n; j++ {
+		orig := x.vals[j]
+		x.vals[j] = orig + eps
+		fp := f(x).ToSlice1D()
+		x.vals[j] = orig - eps
+		fm := f(x).ToSlice1D()
+		x.vals[j] = orig
+		if jac == nil {
+			jac = Newf64(len(fp), n)
+		}
+		if len(fp) != jac.r || len(fm) != jac.r {
+			s := "\nIn matrix.%s, the output of the function had %d elements,\n"
+			s += "and then %d and %d elements. It must not change size."
+			s = fmt.Sprintf(s, "NumJacobianf64()", jac.r, len(fp), len(fm))
+			printErr(ErrShape, s)
+		}
+		for i := range fp {
+			jac.vals[i*n+j] = (fp[i] - fm[i]) / (2 * eps)
+		}
+	}
+	if jac == nil {
+		jac = Newf64(len(f(x).ToSlice1D()), 0)
+	}
+	return jac
+}
@@ -0,0 +1,48 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumGradientf64(t *testing.T) {
+	t.Helper()
+	x := Matf64FromData([]float64{1, -2, 3, 0.5}, 2, 2)
+	orig := x.Copy()
+	f := func(m *Matf64) float64 {
+		return m.Copy().Mul(m).Sum()
+	}
+	g := NumGradientf64(f, x, 1e-6)
+	want := x.Copy().Mul(2.0).ToSlice1D()
+	for i, v := range g.ToSlice1D() {
+		assert.InDelta(t, want[i], v, 1e-6, "should be equal")
+	}
+	assert.True(t, x.Equals(orig), "should restore x")
+	r, c := g.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+}
+
+func TestNumJacobianf64(t *testing.T) {
+	t.Helper()
+	x := Matf64FromData([]float64{1, 2}, 1, 2)
+	// f(x, y) = [x*y, sin(x), y^2]
+	f := func(m *Matf64) *Matf64 {
+		a, b := m.Get(0, 0), m.Get(0, 1)
+		return Matf64FromData([]float64{a * b, math.Sin(a), b * b}, 3, 1)
+	}
+	jac := NumJacobianf64(f, x, 1e-6)
+	want := []float64{
+		2, 1,
+		math.Cos(1), 0,
+		0, 4,
+	}
+	r, c := jac.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	for i, v := range jac.ToSlice1D() {
+		assert.InDelta(t, want[i], v, 1e-6, "should be equal")
+	}
+}
@@ -0,0 +1,97 @@
+package matrix
+
+import (
+	"fmt"
+	"sort"
+)
+
+func checkLabelVector(fn string, labels *Matf64) {
+	if !labels.isRowVector() && !labels.isColVector() {
+		s := "\nIn matrix.%s, the labels must be a row or a column vector,\n"
+		s += "however a %d by %d Matf64 was received."
+		s = fmt.Sprintf(s, fn, labels.r, labels.c)
+		printHelperErr(ErrShape, s)
+	}
+}
+
+/*
+OneHotf64 encodes a vector of class labels, which are integers in
+[0, numClasses), as a Matf64 with one row per label and numClasses columns,
+where each row is zero except for a 1.0 in the column of its label:
+
+	labels := matrix.Matf64FromData([]float64{2, 0, 1}, 3, 1)
+	y := matrix.OneHotf64(labels, 3) // [[0, 0, 1], [1, 0, 0], [0, 1, 0]]
+
+The labels can be a row or a column vector.
+*/
+func OneHotf64(labels *Matf64, numClasses int) *Matf64 {
+	checkLabelVector("OneHotf64()", labels)
+	n := labels.r * labels.c
+	o := Newf64(n, numClasses)
+	for i, v := range labels.vals[:n] {
+		k := int(v)
+		if float64(k) != v || k < 0 || k >= numClasses {
+			s := "\nIn matrix.%s, label %d is %v, which is not an integer in\n"
+			s += "[0, %d)."
+			s = fmt.Sprintf(s, "OneHotf64()", i, v, numClasses)
+			printErr(ErrBounds, s)
+		}
+		o.vals[i*numClasses+k] = 1.0
+	}
+	return o
+}
+
+/*
+OneHotDecodef64 is the inverse of OneHotf64. It returns a column vector with
+the index of the largest element of each row of the passed Matf64, which is
+the label of the row if it is one-hot encoded. It also decodes the predicted
+class probabilities of a classifier into labels. In the case of ties, the
+first column is used.
+*/
+func OneHotDecodef64(m *Matf64) *Matf64 {
+	labels := Newf64(m.r, 1)
+	for i := 0; i < m.r; i++ {
+		idx, _ := m.Max(0, i)
+		labels.vals[i] = float64(idx)
+	}
+	return labels
+}
+
+/*
+BinarizeLabelsf64 one-hot encodes a vector of arbitrary labels, such as
+{-1, 1} or {10, 20, 30}. The classes are the distinct labels in ascending
+order, and the column of each label in the result is the index of its class.
+The classes are also returned, so that the same encoding can be applied to
+new labels, and so that decoded indices can be mapped back to labels:
+
+	y, classes := matrix.BinarizeLabelsf64(labels)
+	idx := int(matrix.OneHotDecodef64(y).Get(0, 0))
+	classes[idx] // the first label
+*/
+func BinarizeLabelsf64(labels *Matf64) (*Matf64, []float64) {
+	checkLabelVector("BinarizeLabelsf64()", labels)
+	n := labels.r * labels.c
+	seen := make(map[float64]bool)
+	var classes []float64
+	for _, v := range labels.vals[:n] {
+		if v != v {
+			s := "\nIn matrix.%s, the labels cannot be NaN."
+			s = fmt.Sprintf(s, "BinarizeLabelsf64()")
+			printErr(ErrArgument, s)
+		}
+		if !seen[v] {
+			seen[v] = true
+			classes = append(classes, v)
+		}
+	}
+	sort.Float64s(classes)
+	idx := make(map[float64]int, len(classes))
+	for i, v := range classes {
+		idx[v] = i
+	}
+	o := Newf64(n, len(classes))
+	for i, v := range labels.vals[:n] {
+		o.vals[i*o.c+idx[v]] = 1.0
+	}
+	return o, classes
+}
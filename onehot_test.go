@@ -0,0 +1,36 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOneHotf64(t *testing.T) {
+	t.Helper()
+	labels := Matf64FromData([]float64{2, 0, 1, 2}, 4, 1)
+	y := OneHotf64(labels, 3)
+	assert.Equal(t, []float64{0, 0, 1, 1, 0, 0, 0, 1, 0, 0, 0, 1}, y.ToSlice1D(), "should be equal")
+	r, c := OneHotf64(labels.T(), 4).Shape()
+	assert.Equal(t, 4, r, "should accept row vectors")
+	assert.Equal(t, 4, c, "should be equal")
+}
+
+func TestOneHotDecodef64(t *testing.T) {
+	t.Helper()
+	labels := Matf64FromData([]float64{2, 0, 1, 2}, 4, 1)
+	assert.True(t, OneHotDecodef64(OneHotf64(labels, 3)).Equals(labels), "should undo OneHotf64")
+	probs := Matf64FromData([]float64{0.1, 0.7, 0.2, 0.5, 0.5, 0}, 2, 3)
+	assert.Equal(t, []float64{1, 0}, OneHotDecodef64(probs).ToSlice1D(), "should be equal")
+}
+
+func TestBinarizeLabelsf64(t *testing.T) {
+	t.Helper()
+	labels := Matf64FromData([]float64{30, -1, 30, 10}, 1, 4)
+	y, classes := BinarizeLabelsf64(labels)
+	assert.Equal(t, []float64{-1, 10, 30}, classes, "should be sorted")
+	assert.Equal(t, []float64{0, 0, 1, 1, 0, 0, 0, 0, 1, 0, 1, 0}, y.ToSlice1D(), "should be equal")
+	for i, v := range OneHotDecodef64(y).ToSlice1D() {
+		assert.Equal(t, labels.Get(0, i), classes[int(v)], "should map back")
+	}
+}
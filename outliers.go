@@ -0,0 +1,117 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+OutlierMethod selects how Outliers decides that an element of a column is an
+outlier.
+*/
+type OutlierMethod int
+
+const (
+	// OutlierZScore flags the elements which are more than threshold standard
+	// deviations away from the mean of their column. 3.0 is a common choice.
+	OutlierZScore OutlierMethod = iota
+	// OutlierIQR flags the elements which are more than threshold times the
+	// interquartile range below the first quartile, or above the third
+	// quartile, of their column. 1.5 is a common choice.
+	OutlierIQR
+)
+
+/*
+Outliers returns a Mask with one row per row of the receiver, and a single
+column, which is true for the rows where at least one element is an outlier
+of its column, according to the passed method and threshold:
+
+	k := m.Outliers(matrix.OutlierIQR, 1.5)
+	k.Count() // the number of rows with outliers
+
+NaN elements are ignored when computing the statistics of each column, and
+are never outliers.
+*/
+func (m *Matf64) Outliers(method OutlierMethod, threshold float64) *Mask {
+	if !(threshold > 0) {
+		s := "\nIn %s, the threshold must be positive, however %v was\n"
+		s += "received."
+		s = fmt.Sprintf(s, "Outliers()", threshold)
+		printErr(ErrArgument, s)
+	}
+	k := NewMask(m.r, 1)
+	vals := make([]float64, 0, m.r)
+	for j := 0; j < m.c; j++ {
+		vals = vals[:0]
+		for i := 0; i < m.r; i++ {
+			if v := m.vals[i*m.c+j]; !math.IsNaN(v) {
+				vals = append(vals, v)
+			}
+		}
+		if len(vals) == 0 {
+			continue
+		}
+		var lo, hi float64
+		switch method {
+		case OutlierZScore:
+			mean := imputeMean(vals)
+			sum := 0.0
+			for _, v := range vals {
+				sum += (v - mean) * (v - mean)
+			}
+			std := math.Sqrt(sum / float64(len(vals)))
+			lo, hi = mean-threshold*std, mean+threshold*std
+		case OutlierIQR:
+			sort.Float64s(vals)
+			q1, q3 := quantileSorted(vals, 0.25), quantileSorted(vals, 0.75)
+			lo, hi = q1-threshold*(q3-q1), q3+threshold*(q3-q1)
+		default:
+			s := "\nIn %s, %d is not a known OutlierMethod."
+			s = fmt.Sprintf(s, "Outliers()", int(method))
+			printErr(ErrArgument, s)
+		}
+		for i := 0; i < m.r; i++ {
+			if v := m.vals[i*m.c+j]; v < lo || v > hi {
+				k.set(i, true)
+			}
+		}
+	}
+	return k
+}
+
+// quantileSorted returns the q-th quantile of the sorted, non-empty vals, by
+// linear interpolation between the closest elements.
+func quantileSorted(vals []float64, q float64) float64 {
+	pos := q * float64(len(vals)-1)
+	i := int(pos)
+	if i+1 >= len(vals) {
+		return vals[len(vals)-1]
+	}
+	frac := pos - float64(i)
+	return vals[i] + frac*(vals[i+1]-vals[i])
+}
+
+/*
+DropOutliers removes the rows of the receiver which have at least one
+outlier, as found by Outliers with the passed method and threshold. The
+receiver is changed in place, and returned.
+*/
+func (m *Matf64) DropOutliers(method OutlierMethod, threshold float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.DropOutliers(method, threshold) })
+	}
+	m.checkWritable("DropOutliers()")
+	k := m.Outliers(method, threshold)
+	rows := 0
+	for i := 0; i < m.r; i++ {
+		if k.Get(i, 0) {
+			continue
+		}
+		copy(m.vals[rows*m.c:(rows+1)*m.c], m.vals[i*m.c:(i+1)*m.c])
+		rows++
+	}
+	m.r = rows
+	m.vals = m.vals[:rows*m.c]
+	return m
+}
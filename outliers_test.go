@@ -0,0 +1,57 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutliersf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 10,
+		2, 11,
+		3, 12,
+		2, 100,
+		-50, 11,
+		2, math.NaN(),
+	}, 6, 2)
+	k := m.Outliers(OutlierIQR, 1.5)
+	r, c := k.Shape()
+	assert.Equal(t, 6, r, "should be equal")
+	assert.Equal(t, 1, c, "should be a row mask")
+	want := []bool{false, false, false, true, true, false}
+	for i, v := range want {
+		assert.Equal(t, v, k.Get(i, 0), "should be equal")
+	}
+	z := m.Outliers(OutlierZScore, 1.5)
+	assert.Equal(t, 2, z.Count(), "should be equal")
+	assert.True(t, z.Get(3, 0), "should be an outlier")
+	assert.True(t, z.Get(4, 0), "should be an outlier")
+	assert.Equal(t, 0, m.Outliers(OutlierZScore, 10.0).Count(), "should be equal")
+}
+
+func TestQuantileSorted(t *testing.T) {
+	t.Helper()
+	vals := []float64{1, 2, 3, 4}
+	assert.Equal(t, 1.75, quantileSorted(vals, 0.25), "should interpolate")
+	assert.Equal(t, 4.0, quantileSorted(vals, 1.0), "should be equal")
+	assert.Equal(t, 7.0, quantileSorted([]float64{7}, 0.5), "should be equal")
+}
+
+func TestDropOutliersf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 10,
+		2, 11,
+		3, 12,
+		2, 100,
+		-50, 11,
+	}, 5, 2)
+	m.DropOutliers(OutlierIQR, 1.5)
+	r, c := m.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	assert.Equal(t, []float64{1, 10, 2, 11, 3, 12}, m.ToSlice1D(), "should be equal")
+}
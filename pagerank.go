@@ -0,0 +1,84 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// pageRankMaxIter is the number of iterations after which PageRankf64 gives
+// up on reaching the tolerance.
+const pageRankMaxIter = 10000
+
+/*
+PageRankf64 returns the PageRank of each node of the graph with the passed
+adjacency matrix, as a column vector whose elements add up to 1:
+
+	rank := matrix.PageRankf64(adj, 0.85, 1e-10)
+
+The adjacency matrix is read as in DegreeMatrixf64, so the element at row i
+and column j is the weight of the link from node i to node j, and a node
+spreads its rank over its links in proportion to their weights. Nodes without
+links spread their rank over all nodes. The damping is the probability of
+following a link rather than jumping to a random node, and must be at least
+0 and less than 1.
+
+The ranks are found by power iteration, with a product by Dot in each
+iteration, which is split between goroutines for large graphs. The
+iteration stops when the sum of the absolute changes of the ranks is less
+than the passed tolerance, and fails with ErrNoConvergence if this is not
+reached in 10000 iterations, which can happen for a damping very close to 1.
+*/
+func PageRankf64(adj *Matf64, damping, tol float64) *Matf64 {
+	checkAdjacency("PageRankf64()", adj)
+	if !(damping >= 0 && damping < 1) || !(tol > 0) {
+		s := "\nIn matrix.%s, the damping must be in [0, 1), and the tolerance\n"
+		s += "must be positive, however %v and %v were received."
+		s = fmt.Sprintf(s, "PageRankf64()", damping, tol)
+		printErr(ErrArgument, s)
+	}
+	n := adj.r
+	// t is the transpose of the transition matrix, so that a step of the
+	// walk is t.Dot(x). The columns of nodes without links are left zero,
+	// and their rank is spread over all nodes separately.
+	t := Newf64(n, n)
+	deg := degrees(adj)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			v := adj.vals[i*n+j]
+			if v < 0 {
+				s := "\nIn matrix.%s, the weights of the links must not be negative,\n"
+				s += "however the weight at row %d, column %d is %v."
+				s = fmt.Sprintf(s, "PageRankf64()", i, j, v)
+				printErr(ErrArgument, s)
+			}
+			if v != 0 {
+				t.vals[j*n+i] = v / deg[i]
+			}
+		}
+	}
+	x := Newf64(n, 1).SetAll(1.0 / float64(n))
+	for iter := 0; iter < pageRankMaxIter; iter++ {
+		dangling := 0.0
+		for i, d := range deg {
+			if d == 0 {
+				dangling += x.vals[i]
+			}
+		}
+		next := t.Dot(x)
+		jump := (1-damping)/float64(n) + damping*dangling/float64(n)
+		diff := 0.0
+		for i := range next.vals {
+			next.vals[i] = damping*next.vals[i] + jump
+			diff += math.Abs(next.vals[i] - x.vals[i])
+		}
+		x = next
+		if diff < tol {
+			return x
+		}
+	}
+	s := "\nIn matrix.%s, the ranks did not converge to the tolerance %v\n"
+	s += "in %d iterations."
+	s = fmt.Sprintf(s, "PageRankf64()", tol, pageRankMaxIter)
+	printErr(ErrNoConvergence, s)
+	return x
+}
@@ -0,0 +1,43 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageRankf64(t *testing.T) {
+	t.Helper()
+	// A cycle, where every node has the same rank.
+	cycle := Matf64FromData([][]float64{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}})
+	rank := PageRankf64(cycle, 0.85, 1e-12)
+	assertMatInDelta(t, Newf64(3, 1).SetAll(1.0/3), rank, 1e-10)
+
+	// Node 2 has no links, and both other nodes link to it.
+	adj := Matf64FromData([][]float64{{0, 1, 1}, {0, 0, 1}, {0, 0, 0}})
+	rank = PageRankf64(adj, 0.85, 1e-12)
+	assert.InDelta(t, 1.0, rank.Sum(), 1e-10, "should add up to 1")
+	assert.True(t, rank.Get(2, 0) > rank.Get(1, 0), "should rank node 2 first")
+	assert.True(t, rank.Get(1, 0) > rank.Get(0, 0), "should rank node 0 last")
+
+	// The fixed point of the power iteration.
+	d := 0.85
+	x0, x1, x2 := rank.Get(0, 0), rank.Get(1, 0), rank.Get(2, 0)
+	jump := (1-d)/3 + d*x2/3
+	assert.InDelta(t, jump, x0, 1e-10, "should be equal")
+	assert.InDelta(t, jump+d*x0/2, x1, 1e-10, "should be equal")
+	assert.InDelta(t, jump+d*(x0/2+x1), x2, 1e-10, "should be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { PageRankf64(adj, 1, 1e-9) }, "should panic")
+	assert.Panics(t, func() { PageRankf64(adj, 0.85, 0) }, "should panic")
+	assert.Panics(t, func() { PageRankf64(Newf64(2, 3), 0.85, 1e-9) }, "should panic")
+	assert.Panics(t, func() { PageRankf64(adj.Copy().Neg(), 0.85, 1e-9) }, "should panic")
+
+	// A walk on a path alternates between its middle and its ends, which
+	// barely fades with a damping close to 1.
+	path := Matf64FromData([][]float64{{0, 1, 0}, {1, 0, 1}, {0, 1, 0}})
+	err := Catch(func() { PageRankf64(path, 0.999999, 1e-12) })
+	assert.True(t, errors.Is(err, ErrNoConvergence), "should not converge")
+}
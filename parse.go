@@ -0,0 +1,65 @@
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+ParseMatf64 returns the Matf64 represented by the passed string, as written by
+the String method, so that a mat printed in a log or kept in a golden file can
+be read back:
+
+	m := matrix.ParseMatf64("[[1, 2, 3]\n [4, 5, 6]]")
+
+Each row is enclosed in brackets, and the rows are enclosed in another pair of
+brackets. The elements of a row are separated by commas, white space, or both.
+All rows must have the same number of elements. Note that String writes 14
+digits after the decimal point, so very small elements do not survive the
+round trip exactly.
+*/
+func ParseMatf64(s string) *Matf64 {
+	str := strings.TrimSpace(s)
+	if !strings.HasPrefix(str, "[") || !strings.HasSuffix(str, "]") {
+		parseMatErr("the string must start with \"[\" and end with \"]\"")
+	}
+	str = strings.TrimSpace(str[1 : len(str)-1])
+	m := Newf64()
+	for str != "" {
+		if str[0] != '[' {
+			parseMatErr(fmt.Sprintf("row %d must start with \"[\"", m.r))
+		}
+		end := strings.IndexByte(str, ']')
+		if end < 0 {
+			parseMatErr(fmt.Sprintf("row %d must end with \"]\"", m.r))
+		}
+		fields := strings.FieldsFunc(str[1:end], func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		if m.r == 0 {
+			m.c = len(fields)
+		} else if len(fields) != m.c {
+			parseMatErr(fmt.Sprintf("row %d has %d elements, while the first row has %d", m.r, len(fields), m.c))
+		}
+		for _, f := range fields {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				parseMatErr(fmt.Sprintf("row %d has the element %q, which is not a number", m.r, f))
+			}
+			m.vals = append(m.vals, v)
+		}
+		m.r++
+		str = strings.TrimLeftFunc(str[end+1:], func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+	}
+	return m
+}
+
+func parseMatErr(reason string) {
+	s := "\nIn matrix.%s, the string could not be parsed: %s."
+	s = fmt.Sprintf(s, "ParseMatf64()", reason)
+	printHelperErr(ErrParse, s)
+}
@@ -0,0 +1,29 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1.5, -2, 3,
+		4, 0.25, 6e3,
+	}, 2, 3)
+	assert.True(t, ParseMatf64(m.String()).Equals(m), "should round trip")
+	n := ParseMatf64("[[1, 2]\n [3 4]]")
+	assert.Equal(t, []float64{1, 2, 3, 4}, n.ToSlice1D(), "should be equal")
+	r, c := n.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	k := ParseMatf64("[[NaN, -Inf]]")
+	assert.True(t, math.IsNaN(k.Get(0, 0)), "should be NaN")
+	assert.True(t, math.IsInf(k.Get(0, 1), -1), "should be -Inf")
+	e := ParseMatf64("[]")
+	r, c = e.Shape()
+	assert.Equal(t, 0, r, "should be equal")
+	assert.Equal(t, 0, c, "should be equal")
+}
@@ -0,0 +1,198 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Cov returns the covariance matrix of the columns of a Matf64, whose rows are
+taken to be observations, and whose columns are taken to be variables. The
+result is normalized by the number of rows minus one, and is returned as a
+SymMatf64, which stores each covariance once. The receiver must have at least
+two rows.
+*/
+func (m *Matf64) Cov() *SymMatf64 {
+	if m.r < 2 {
+		s := "\nIn %s, at least two rows are needed to compute a covariance,\n"
+		s += "however the receiver has %d."
+		s = fmt.Sprintf(s, "Cov()", m.r)
+		printErr(ErrArgument, s)
+	}
+	mean := columnMeans(m)
+	cov := NewSymMatf64(m.c)
+	for k := 0; k < m.r; k++ {
+		row := m.vals[k*m.c : (k+1)*m.c]
+		for j := 0; j < m.c; j++ {
+			dj := row[j] - mean[j]
+			for i := 0; i <= j; i++ {
+				cov.vals[i+j*(j+1)/2] += (row[i] - mean[i]) * dj
+			}
+		}
+	}
+	for i := range cov.vals {
+		cov.vals[i] /= float64(m.r - 1)
+	}
+	return cov
+}
+
+func columnMeans(m *Matf64) []float64 {
+	mean := make([]float64, m.c)
+	for k := 0; k < m.r; k++ {
+		for j := 0; j < m.c; j++ {
+			mean[j] += m.vals[k*m.c+j]
+		}
+	}
+	for j := range mean {
+		mean[j] /= float64(m.r)
+	}
+	return mean
+}
+
+/*
+PCAf64 is a fitted principal component analysis, which projects data onto
+the directions of largest variance of the data it was fitted on:
+
+	p := matrix.FitPCAf64(data, 2)
+	z := p.Transform(data)          // each row of data, as 2 coordinates
+	approx := p.InverseTransform(z) // back to the columns of data
+
+The fields of this struct are not directly accessible.
+*/
+type PCAf64 struct {
+	mean       []float64
+	components *Matf64
+	variance   []float64
+	ratio      []float64
+}
+
+/*
+FitPCAf64 fits a principal component analysis with nComponents components to
+the passed Matf64, whose rows are observations and whose columns are
+variables. The components are the eigenvectors of the covariance matrix of
+the columns with the largest eigenvalues. nComponents must be between 1 and
+the number of columns.
+
+The sign of each component is chosen such that its element with the largest
+magnitude is positive, so that the result is deterministic.
+*/
+func FitPCAf64(m *Matf64, nComponents int) *PCAf64 {
+	if nComponents < 1 || nComponents > m.c {
+		s := "\nIn matrix.%s, the number of components must be in [1, %d],\n"
+		s += "however %d was received."
+		s = fmt.Sprintf(s, "FitPCAf64()", m.c, nComponents)
+		printErr(ErrArgument, s)
+	}
+	vals, vecs := m.Cov().Eigen()
+	total := 0.0
+	for _, v := range vals.vals {
+		total += v
+	}
+	p := &PCAf64{
+		mean:       columnMeans(m),
+		components: Newf64(nComponents, m.c),
+		variance:   make([]float64, nComponents),
+		ratio:      make([]float64, nComponents),
+	}
+	for k := 0; k < nComponents; k++ {
+		// Eigen returns the eigenvalues in ascending order.
+		col := m.c - 1 - k
+		p.variance[k] = vals.vals[col]
+		if total > 0 {
+			p.ratio[k] = vals.vals[col] / total
+		}
+		row := p.components.vals[k*m.c : (k+1)*m.c]
+		big := 0
+		for j := range row {
+			row[j] = vecs.vals[j*m.c+col]
+			if math.Abs(row[j]) > math.Abs(row[big]) {
+				big = j
+			}
+		}
+		if row[big] < 0 {
+			for j := range row {
+				row[j] = -row[j]
+			}
+		}
+	}
+	return p
+}
+
+/*
+Components returns the principal components as the rows of a Matf64, in
+order of decreasing explained variance.
+*/
+func (p *PCAf64) Components() *Matf64 {
+	return p.components.Copy()
+}
+
+/*
+ExplainedVariance returns the variance of the data along each component.
+*/
+func (p *PCAf64) ExplainedVariance() []float64 {
+	v := make([]float64, len(p.variance))
+	copy(v, p.variance)
+	return v
+}
+
+/*
+ExplainedVarianceRatio returns the fraction of the total variance of the data
+along each component.
+*/
+func (p *PCAf64) ExplainedVarianceRatio() []float64 {
+	v := make([]float64, len(p.ratio))
+	copy(v, p.ratio)
+	return v
+}
+
+/*
+Mean returns the mean of each column of the data, which is subtracted before
+projecting onto the components, as a row vector.
+*/
+func (p *PCAf64) Mean() *Matf64 {
+	return Matf64FromData(p.mean, 1, len(p.mean))
+}
+
+/*
+Transform projects each row of the passed Matf64 onto the components, and
+returns a Matf64 with one row per row of the passed Matf64, and one column per
+component. The passed Matf64 must have as many columns as the data which was
+fitted.
+*/
+func (p *PCAf64) Transform(m *Matf64) *Matf64 {
+	if m.c != p.components.c {
+		s := "\nIn %s, the passed Matf64 has %d columns, while the PCA was\n"
+		s += "fitted on %d columns. They must be equal."
+		s = fmt.Sprintf(s, "Transform()", m.c, p.components.c)
+		printErr(ErrShape, s)
+	}
+	centered := m.Copy()
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			centered.vals[i*m.c+j] -= p.mean[j]
+		}
+	}
+	return centered.Dot(p.components.T())
+}
+
+/*
+InverseTransform maps each row of the passed Matf64, which holds one
+coordinate per component, back to the space of the fitted data. When all
+components are kept, this undoes Transform. Otherwise, it returns the closest
+approximation of the data which lies in the span of the components.
+*/
+func (p *PCAf64) InverseTransform(z *Matf64) *Matf64 {
+	if z.c != p.components.r {
+		s := "\nIn %s, the passed Matf64 has %d columns, while the PCA has\n"
+		s += "%d components. They must be equal."
+		s = fmt.Sprintf(s, "InverseTransform()", z.c, p.components.r)
+		printErr(ErrShape, s)
+	}
+	m := z.Dot(p.components)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			m.vals[i*m.c+j] += p.mean[j]
+		}
+	}
+	return m
+}
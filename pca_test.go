@@ -0,0 +1,61 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCovf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 2, 4, 3, 6, 4, 8}, 4, 2)
+	cov := m.Cov()
+	assert.InDelta(t, 5.0/3.0, cov.Get(0, 0), 1e-12, "should be equal")
+	assert.InDelta(t, 10.0/3.0, cov.Get(0, 1), 1e-12, "should be equal")
+	assert.InDelta(t, 20.0/3.0, cov.Get(1, 1), 1e-12, "should be equal")
+}
+
+func TestFitPCAf64(t *testing.T) {
+	t.Helper()
+	// Points on the line y = 2x, plus a small perpendicular spread.
+	m := Matf64FromData([]float64{
+		0, 0.1,
+		1, 2,
+		2, 3.9,
+		3, 6.1,
+		4, 8,
+	}, 5, 2)
+	p := FitPCAf64(m, 2)
+	c := p.Components()
+	assert.InDelta(t, 1/2.2360679775, c.Get(0, 0), 1e-2, "should follow the line")
+	assert.InDelta(t, 2/2.2360679775, c.Get(0, 1), 1e-2, "should follow the line")
+	v := p.ExplainedVariance()
+	assert.True(t, v[0] > v[1], "should be decreasing")
+	r := p.ExplainedVarianceRatio()
+	assert.InDelta(t, 1.0, r[0]+r[1], 1e-12, "should sum to one")
+	assert.True(t, r[0] > 0.99, "should explain most of the variance")
+	mean := p.Mean().ToSlice1D()
+	assert.InDelta(t, 2.0, mean[0], 1e-12, "should be equal")
+	assert.InDelta(t, 4.02, mean[1], 1e-12, "should be equal")
+}
+
+func TestPCAf64Transform(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(20, 4)
+	p := FitPCAf64(m, 4)
+	z := p.Transform(m)
+	r, c := z.Shape()
+	assert.Equal(t, 20, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+	back := p.InverseTransform(z).ToSlice1D()
+	for i, v := range m.ToSlice1D() {
+		assert.InDelta(t, v, back[i], 1e-10, "should undo Transform")
+	}
+	p2 := FitPCAf64(m, 2)
+	z2 := p2.Transform(m)
+	_, c = z2.Shape()
+	assert.Equal(t, 2, c, "should be equal")
+	// Std divides by the number of rows, while the covariance divides by one less.
+	std := z2.Col(0).Std()
+	assert.InDelta(t, p2.ExplainedVariance()[0], std*std*20/19, 1e-10, "should be equal")
+}
@@ -0,0 +1,90 @@
+package matrix
+
+import (
+	"math"
+	"sort"
+)
+
+/*
+EqualsUpToRowPermutationf64 reports whether two Matf64 have the same rows,
+in any order, where two rows are the same if each of their elements differ
+by at most tol. Repeated rows must appear the same number of times in both.
+This checks outputs which are sets of rows, such as the centroids of a
+clustering, whose order is arbitrary:
+
+	assert.True(t, matrix.EqualsUpToRowPermutationf64(got, want, 1e-9))
+
+Two Matf64 of different shapes are never equal. The rows are sorted first,
+so that the usual case takes O(n log n) comparisons, but when the tolerance
+makes the order of the sorted rows ambiguous, each row is matched against
+all others, which takes O(n^2) comparisons.
+*/
+func EqualsUpToRowPermutationf64(a, b *Matf64, tol float64) bool {
+	checkTol("matrix.EqualsUpToRowPermutationf64()", tol)
+	if a.r != b.r || a.c != b.c {
+		return false
+	}
+	ra, rb := sortedRows(a), sortedRows(b)
+	same := true
+	for i := range ra {
+		if !rowsClose(ra[i], rb[i], tol) {
+			same = false
+			break
+		}
+	}
+	if same {
+		return true
+	}
+	// Find a perfect matching between the rows of a and b, by augmenting
+	// paths, as a row of a may be close to several rows of b.
+	match := make([]int, len(rb))
+	for j := range match {
+		match[j] = -1
+	}
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j := range rb {
+			if seen[j] || !rowsClose(ra[i], rb[j], tol) {
+				continue
+			}
+			seen[j] = true
+			if match[j] < 0 || augment(match[j], seen) {
+				match[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range ra {
+		if !augment(i, make([]bool, len(rb))) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedRows returns the rows of m, in lexicographic order.
+func sortedRows(m *Matf64) [][]float64 {
+	rows := make([][]float64, m.r)
+	for i := range rows {
+		rows[i] = m.vals[i*m.c : (i+1)*m.c]
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		for k := range rows[i] {
+			if rows[i][k] != rows[j][k] {
+				return rows[i][k] < rows[j][k]
+			}
+		}
+		return false
+	})
+	return rows
+}
+
+func rowsClose(x, y []float64, tol float64) bool {
+	for k := range x {
+		if !(math.Abs(x[k]-y[k]) <= tol) && x[k] != y[k] {
+			return false
+		}
+	}
+	return true
+}
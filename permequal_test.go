@@ -0,0 +1,28 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualsUpToRowPermutationf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{1, 2}, {3, 4}, {1, 2}, {5, 6}})
+	b := Matf64FromData([][]float64{{5, 6}, {1, 2}, {3, 4}, {1, 2}})
+	assert.True(t, EqualsUpToRowPermutationf64(a, b, 0), "should be equal")
+	assert.True(t, EqualsUpToRowPermutationf64(a, a, 0), "should be equal")
+
+	c := Matf64FromData([][]float64{{5, 6}, {1, 2}, {3, 4}, {3, 4}})
+	assert.False(t, EqualsUpToRowPermutationf64(a, c, 0), "should count repeated rows")
+	assert.False(t, EqualsUpToRowPermutationf64(a, a.Copy().Reshape(2, 4), 0), "should not be equal")
+
+	// The rows sort in a different order, but match within the tolerance.
+	d := Matf64FromData([][]float64{{1, 9}, {1.05, 1}})
+	e := Matf64FromData([][]float64{{1.04, 9}, {1, 1}})
+	assert.True(t, EqualsUpToRowPermutationf64(d, e, 0.1), "should be equal")
+	assert.False(t, EqualsUpToRowPermutationf64(d, e, 0.01), "should not be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { EqualsUpToRowPermutationf64(a, b, -1) }, "should panic")
+}
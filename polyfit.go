@@ -0,0 +1,86 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Polyfitf64 returns the coefficients of the polynomial of the passed degree
+which best fits the points (x, y) in the sense of least squares, as a row
+vector with the coefficient of the highest power first, as in numpy:
+
+	p := matrix.Polyfitf64(x, y, 2) // y ~ p[0]*x^2 + p[1]*x + p[2]
+	fit := matrix.Polyvalf64(p, x)
+
+x and y must be row or column vectors of the same length, with more elements
+than the degree. The least squares problem is solved with a singular value
+decomposition, so that high degrees do not lose accuracy as with the normal
+equations.
+*/
+func Polyfitf64(x, y *Matf64, degree int) *Matf64 {
+	checkVector("Polyfitf64()", "x", x)
+	checkVector("Polyfitf64()", "y", y)
+	n := len(x.vals)
+	if len(y.vals) != n || degree < 0 || n <= degree {
+		s := "\nIn matrix.%s, x and y must have the same number of elements,\n"
+		s += "which must be larger than the degree, however they have %d and\n"
+		s += "%d elements, and the degree is %d."
+		s = fmt.Sprintf(s, "Polyfitf64()", n, len(y.vals), degree)
+		printErr(ErrShape, s)
+	}
+	// Each column of the Vandermonde matrix is scaled to unit norm, which
+	// improves the conditioning of the problem for large x.
+	d := degree + 1
+	v := Vandermondef64(x.vals, degree)
+	scale := make([]float64, d)
+	for j := range scale {
+		for i := 0; i < n; i++ {
+			scale[j] += v.vals[i*d+j] * v.vals[i*d+j]
+		}
+		scale[j] = math.Sqrt(scale[j])
+		if scale[j] == 0.0 {
+			scale[j] = 1.0
+		}
+		for i := 0; i < n; i++ {
+			v.vals[i*d+j] /= scale[j]
+		}
+	}
+	u, s, w := svdf64(v)
+	tol := float64(n) * 1e-16 * s[0]
+	coeffs := Newf64(1, d)
+	for k, sk := range s {
+		if sk <= tol {
+			continue
+		}
+		uy := 0.0
+		for i, yi := range y.vals {
+			uy += u.vals[i*d+k] * yi
+		}
+		for j := 0; j < d; j++ {
+			coeffs.vals[j] += w.vals[j*d+k] * uy / sk
+		}
+	}
+	for j := range coeffs.vals {
+		coeffs.vals[j] /= scale[j]
+	}
+	return coeffs
+}
+
+/*
+Polyvalf64 evaluates the polynomial with the passed coefficients, highest
+power first, as returned by Polyfitf64, at each element of x, by the method
+of Horner. The returned Matf64 has the shape of x.
+*/
+func Polyvalf64(coeffs, x *Matf64) *Matf64 {
+	checkVector("Polyvalf64()", "coeffs", coeffs)
+	o := x.Copy()
+	for i, xi := range o.vals {
+		v := 0.0
+		for _, c := range coeffs.vals {
+			v = v*xi + c
+		}
+		o.vals[i] = v
+	}
+	return o
+}
@@ -0,0 +1,25 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestPolyfitf64(t *testing.T) {
+	t.Helper()
+	x := Matf64FromData([]float64{-2, -1, 0, 1, 2, 3}, 1, 6)
+	p := Matf64FromData([]float64{2, -3, 1}, 1, 3)
+	y := Polyvalf64(p, x)
+	assertMatInDelta(t, Matf64FromData([]float64{15, 6, 1, 0, 3, 10}, 1, 6), y, 1e-12)
+	assertMatInDelta(t, p, Polyfitf64(x, y, 2), 1e-10)
+
+	// The line of least squares through points which are not collinear.
+	x = Matf64FromData([]float64{0, 1, 2, 3}, 4, 1)
+	y = Matf64FromData([]float64{1, 3, 2, 4}, 4, 1)
+	assertMatInDelta(t, Matf64FromData([]float64{0.8, 1.3}, 1, 2), Polyfitf64(x, y, 1), 1e-12)
+
+	// Large x does not spoil the fit of a cubic.
+	x = Matf64FromData([]float64{1000, 1001, 1002, 1003, 1004, 1005}, 1, 6)
+	p = Matf64FromData([]float64{1, -3000, 2, 5}, 1, 4)
+	fit := Polyvalf64(Polyfitf64(x, Polyvalf64(p, x), 3), x)
+	assertMatInDelta(t, Polyvalf64(p, x), fit, 1e-3)
+}
@@ -0,0 +1,92 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+MaxPool2Df64 returns the maximum of each kh by kw window of the passed
+Matf64, where the windows are taken every stride rows and columns. The shape
+of the result is given by ConvOutputSizef64 with no padding. For example, the
+common 2 by 2 pooling which halves the size of an image is:
+
+	pooled, idx := matrix.MaxPool2Df64(img, 2, 2, 2)
+
+The second returned value holds, for each element of the result in row major
+order, the index of the maximum in the passed Matf64, also in row major
+order. It can be passed to MaxUnpool2Df64 to route values back to the
+positions of the maxima, as is needed to backpropagate through a max-pool.
+If a window has several maxima, the first one is used.
+*/
+func MaxPool2Df64(m *Matf64, kh, kw, stride int) (*Matf64, []int) {
+	checkConvParams("matrix.MaxPool2Df64()", m.r, m.c, kh, kw, stride, 0)
+	outH, outW := convOutputSize(m.r, m.c, kh, kw, stride, 0)
+	o := Newf64(outH, outW)
+	idx := make([]int, outH*outW)
+	for oi := 0; oi < outH; oi++ {
+		for oj := 0; oj < outW; oj++ {
+			best := oi*stride*m.c + oj*stride
+			for i := oi * stride; i < oi*stride+kh; i++ {
+				for j := oj * stride; j < oj*stride+kw; j++ {
+					if m.vals[i*m.c+j] > m.vals[best] {
+						best = i*m.c + j
+					}
+				}
+			}
+			o.vals[oi*outW+oj] = m.vals[best]
+			idx[oi*outW+oj] = best
+		}
+	}
+	return o, idx
+}
+
+/*
+AvgPool2Df64 returns the average of each kh by kw window of the passed
+Matf64, where the windows are taken every stride rows and columns, in the same
+manner as MaxPool2Df64.
+*/
+func AvgPool2Df64(m *Matf64, kh, kw, stride int) *Matf64 {
+	checkConvParams("matrix.AvgPool2Df64()", m.r, m.c, kh, kw, stride, 0)
+	outH, outW := convOutputSize(m.r, m.c, kh, kw, stride, 0)
+	o := Newf64(outH, outW)
+	size := float64(kh * kw)
+	for oi := 0; oi < outH; oi++ {
+		for oj := 0; oj < outW; oj++ {
+			sum := 0.0
+			for i := oi * stride; i < oi*stride+kh; i++ {
+				for j := oj * stride; j < oj*stride+kw; j++ {
+					sum += m.vals[i*m.c+j]
+				}
+			}
+			o.vals[oi*outW+oj] = sum / size
+		}
+	}
+	return o
+}
+
+/*
+MaxUnpool2Df64 returns an h by w Matf64, where each element of the passed
+Matf64 is added to the position given by the corresponding index returned by
+MaxPool2Df64, and all other elements are zero. The passed Matf64 must have as
+many elements as there are indices, and h by w must be the shape of the
+Matf64 which was pooled.
+*/
+func MaxUnpool2Df64(m *Matf64, idx []int, h, w int) *Matf64 {
+	if m.r*m.c != len(idx) {
+		s := "\nIn matrix.%s, the passed Matf64 has %d elements, while %d\n"
+		s += "indices were passed. They must be equal."
+		s = fmt.Sprintf(s, "MaxUnpool2Df64()", m.r*m.c, len(idx))
+		printErr(ErrShape, s)
+	}
+	o := Newf64(h, w)
+	for i, k := range idx {
+		if k < 0 || k >= h*w {
+			s := "\nIn matrix.%s, index %d is %d, which is outside of the bounds\n"
+			s += "of a %d by %d Matf64."
+			s = fmt.Sprintf(s, "MaxUnpool2Df64()", i, k, h, w)
+			printErr(ErrBounds, s)
+		}
+		o.vals[k] += m.vals[i]
+	}
+	return o
+}
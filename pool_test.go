@@ -0,0 +1,43 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxPool2Df64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 5, 2, 0,
+		3, 4, 8, 1,
+		0, 2, 3, 3,
+		9, 1, 1, 2,
+	}, 4, 4)
+	o, idx := MaxPool2Df64(m, 2, 2, 2)
+	assert.Equal(t, []float64{5, 8, 9, 3}, o.ToSlice1D(), "should be equal")
+	assert.Equal(t, []int{1, 6, 12, 10}, idx, "should be the first maxima")
+
+	o, idx = MaxPool2Df64(m, 3, 3, 1)
+	assert.Equal(t, []float64{8, 8, 9, 8}, o.ToSlice1D(), "should be equal")
+	assert.Equal(t, []int{6, 6, 12, 6}, idx, "should be equal")
+}
+
+func TestAvgPool2Df64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6, 7, 8}, 2, 4)
+	assert.Equal(t, []float64{3.5, 5.5}, AvgPool2Df64(m, 2, 2, 2).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{3.5, 4.5, 5.5}, AvgPool2Df64(m, 2, 2, 1).ToSlice1D(), "should be equal")
+}
+
+func TestMaxUnpool2Df64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 5, 3, 4, 0, 2, 9, 1}, 2, 4)
+	o, idx := MaxPool2Df64(m, 2, 2, 2)
+	u := MaxUnpool2Df64(o, idx, 2, 4)
+	assert.Equal(t, []float64{0, 5, 0, 0, 0, 0, 9, 0}, u.ToSlice1D(), "should be equal")
+	grad := Newf64(1, 3).SetAll(1.0)
+	_, idx = MaxPool2Df64(m, 2, 2, 1)
+	u = MaxUnpool2Df64(grad, idx, 2, 4)
+	assert.Equal(t, []float64{0, 1, 0, 0, 0, 0, 2, 0}, u.ToSlice1D(), "should accumulate")
+}
@@ -0,0 +1,29 @@
+package matrix
+
+/*
+Float is the set of the types of the elements of the mats of this package. It
+allows a function to be written once for both Matf64 and Matf32, such as the
+predicates below.
+*/
+type Float interface {
+	~float32 | ~float64
+}
+
+/*
+The functions below are predicates on single elements, for use with the All,
+Any and Find methods, which take a pointer to the element. They are generic
+over Float, so the same name works for both Matf64 and Matf32:
+
+	m.All(matrix.Positive)
+	idx := m.Find(matrix.Negative)
+*/
+
+// Positive returns true if the passed element is larger than zero.
+func Positive[T Float](v *T) bool {
+	return *v > 0.0
+}
+
+// Negative returns true if the passed element is smaller than zero.
+func Negative[T Float](v *T) bool {
+	return *v < 0.0
+}
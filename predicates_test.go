@@ -0,0 +1,23 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredicatesElemf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, -2, 0, 4}, 2, 2)
+	assert.False(t, m.All(Positive), "should not all be positive")
+	assert.True(t, m.Any(Negative), "should have a negative")
+	assert.Equal(t, [][2]int{{0, 1}}, m.Find(Negative), "should be equal")
+	assert.Equal(t, [][2]int{{0, 0}, {1, 1}}, m.Find(Positive), "should be equal")
+}
+
+func TestPredicatesElemf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([]float32{1, 2, 3, 4}, 2, 2)
+	assert.True(t, m.All(Positive), "should all be positive")
+	assert.False(t, m.Any(Negative), "should not have a negative")
+}
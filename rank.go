@@ -0,0 +1,115 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+RankMethod selects how RankAxis ranks elements which are equal.
+*/
+type RankMethod int
+
+const (
+	// RankAverage gives equal elements the average of their ranks.
+	RankAverage RankMethod = iota
+	// RankMin gives equal elements the lowest of their ranks.
+	RankMin
+	// RankMax gives equal elements the highest of their ranks.
+	RankMax
+	// RankDense gives equal elements the same rank, like RankMin, but the
+	// next larger element gets the next rank, so that there are no gaps.
+	RankDense
+)
+
+/*
+RankAxis returns a new Matf64 of the shape of the receiver, in which each
+element is replaced by its rank within its row (axis 0) or column (axis 1),
+starting at 1 for the smallest. Equal elements are ranked by the passed
+method:
+
+	m := matrix.Matf64FromData([]float64{10, 20, 20, 5}, 1, 4)
+	m.RankAxis(0, matrix.RankAverage) // [[2, 3.5, 3.5, 1]]
+	m.RankAxis(0, matrix.RankDense)   // [[2, 3, 3, 1]]
+
+Ranking each column and then computing their correlation gives the rank
+correlation of Spearman. NaN elements are not ranked, and stay NaN.
+*/
+func (m *Matf64) RankAxis(axis int, method RankMethod) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.RankAxis(axis, method) })
+	}
+	o, _ := m.rankAxis("RankAxis()", axis, method)
+	return o
+}
+
+/*
+PercentRankAxis returns the ranks of RankAxis, divided by the number of
+elements which are not NaN in each row or column, so that they are in
+(0, 1]. For RankDense, they are divided by the largest rank instead.
+*/
+func (m *Matf64) PercentRankAxis(axis int, method RankMethod) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.PercentRankAxis(axis, method) })
+	}
+	o, top := m.rankAxis("PercentRankAxis()", axis, method)
+	count, n, step, stride := imputeLayout("PercentRankAxis()", o, axis)
+	for k := 0; k < count; k++ {
+		for i := 0; i < n; i++ {
+			o.vals[k*stride+i*step] /= top[k]
+		}
+	}
+	return o
+}
+
+// rankAxis returns the ranks, and the largest possible rank in each row or
+// column.
+func (m *Matf64) rankAxis(fn string, axis int, method RankMethod) (*Matf64, []float64) {
+	if method < RankAverage || method > RankDense {
+		s := "\nIn %s, %d is not a known RankMethod."
+		s = fmt.Sprintf(s, fn, int(method))
+		printHelperErr(ErrArgument, s)
+	}
+	count, n, step, stride := imputeLayout(fn, m, axis)
+	o := m.Copy()
+	top := make([]float64, count)
+	idx := make([]int, 0, n)
+	for k := 0; k < count; k++ {
+		idx = idx[:0]
+		for i := 0; i < n; i++ {
+			if !math.IsNaN(m.vals[k*stride+i*step]) {
+				idx = append(idx, k*stride+i*step)
+			}
+		}
+		sort.SliceStable(idx, func(a, b int) bool { return m.vals[idx[a]] < m.vals[idx[b]] })
+		dense := 0.0
+		for i := 0; i < len(idx); {
+			j := i
+			for j < len(idx) && m.vals[idx[j]] == m.vals[idx[i]] {
+				j++
+			}
+			dense++
+			var r float64
+			switch method {
+			case RankAverage:
+				r = float64(i+j+1) / 2.0
+			case RankMin:
+				r = float64(i + 1)
+			case RankMax:
+				r = float64(j)
+			case RankDense:
+				r = dense
+			}
+			for _, p := range idx[i:j] {
+				o.vals[p] = r
+			}
+			i = j
+		}
+		top[k] = float64(len(idx))
+		if method == RankDense {
+			top[k] = dense
+		}
+	}
+	return o, top
+}
@@ -0,0 +1,36 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankAxisf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{10, 20, 20, 5}, 1, 4)
+	assert.Equal(t, []float64{2, 3.5, 3.5, 1}, m.RankAxis(0, RankAverage).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{2, 3, 3, 1}, m.RankAxis(0, RankMin).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{2, 4, 4, 1}, m.RankAxis(0, RankMax).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{2, 3, 3, 1}, m.RankAxis(0, RankDense).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{10, 20, 20, 5}, m.ToSlice1D(), "should not change m")
+
+	c := Matf64FromData([]float64{
+		3, 1,
+		1, math.NaN(),
+		2, 1,
+	}, 3, 2)
+	r := c.RankAxis(1, RankMin)
+	assert.Equal(t, []float64{3, 1, 2}, r.Col(0).ToSlice1D(), "should be equal")
+	assert.Equal(t, 1.0, r.Get(0, 1), "should be equal")
+	assert.True(t, math.IsNaN(r.Get(1, 1)), "should stay NaN")
+	assert.Equal(t, 1.0, r.Get(2, 1), "should be equal")
+}
+
+func TestPercentRankAxisf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{10, 20, 20, 5}, 1, 4)
+	assert.Equal(t, []float64{0.5, 0.875, 0.875, 0.25}, m.PercentRankAxis(0, RankAverage).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{2.0 / 3, 1, 1, 1.0 / 3}, m.PercentRankAxis(0, RankDense).ToSlice1D(), "should be equal")
+}
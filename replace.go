@@ -0,0 +1,57 @@
+package matrix
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/*
+ReplaceWhere replaces each element of the receiver for which the supplied
+function is true. Based on the type of the passed object, the replacement
+changes:
+
+If the passed object is a float64, then the elements are replaced by it. For
+example, to zero out everything below 1e-9 in absolute value:
+
+	m.ReplaceWhere(func(v *float64) bool { return math.Abs(*v) < 1e-9 }, 0.0)
+
+The passed object can also be a Matf64, in which case the elements are
+replaced by the corresponding elements of the passed Matf64, which must have
+the same shape as the receiver:
+
+	m.ReplaceWhere(matrix.Negative, fallback)
+
+The function is of the same kind as for All, Any and Find.
+*/
+func (m *Matf64) ReplaceWhere(f func(*float64) bool, float64OrMatf64 interface{}) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.ReplaceWhere(f, float64OrMatf64) })
+	}
+	m.checkWritable("ReplaceWhere()")
+	switch v := float64OrMatf64.(type) {
+	case float64:
+		for i := range m.vals {
+			if f(&m.vals[i]) {
+				m.vals[i] = v
+			}
+		}
+	case *Matf64:
+		if v.r != m.r || v.c != m.c {
+			s := "\nIn %s, the receiver is %d by %d, while the passed Matf64 is\n"
+			s += "%d by %d. They must have the same shape."
+			s = fmt.Sprintf(s, "ReplaceWhere()", m.r, m.c, v.r, v.c)
+			printErr(ErrShape, s)
+		}
+		for i := range m.vals {
+			if f(&m.vals[i]) {
+				m.vals[i] = v.vals[i]
+			}
+		}
+	default:
+		s := "\nIn %s, the passed value must be a float64 or *Matf64.\n"
+		s += "However, value of type \"%v\" was received.\n"
+		s = fmt.Sprintf(s, "ReplaceWhere()", reflect.TypeOf(v))
+		printErr(ErrArgument, s)
+	}
+	return m
+}
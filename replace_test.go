@@ -0,0 +1,18 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceWheref64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 1e-12, -3, -1e-10}, 2, 2)
+	m.ReplaceWhere(func(v *float64) bool { return math.Abs(*v) < 1e-9 }, 0.0)
+	assert.Equal(t, []float64{1, 0, -3, 0}, m.ToSlice1D(), "should be equal")
+	n := Matf64FromData([]float64{10, 20, 30, 40}, 2, 2)
+	m.ReplaceWhere(Negative, n)
+	assert.Equal(t, []float64{1, 0, 30, 0}, m.ToSlice1D(), "should be equal")
+}
@@ -0,0 +1,193 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Rot2Df64 returns the 2 by 2 matrix which rotates column vectors in the plane
+counterclockwise by theta radians.
+*/
+func Rot2Df64(theta float64) *Matf64 {
+	s, c := math.Sincos(theta)
+	return Matf64FromData([]float64{
+		c, -s,
+		s, c,
+	}, 2, 2)
+}
+
+/*
+RotXf64 returns the 3 by 3 matrix which rotates column vectors about the x
+axis by theta radians, counterclockwise when looking from the positive x axis
+towards the origin. RotYf64 and RotZf64 do the same about the y and z axes.
+Rotations are composed with Dot, where the rightmost is applied first:
+
+	r := matrix.RotZf64(yaw).Dot(matrix.RotYf64(pitch)).Dot(matrix.RotXf64(roll))
+*/
+func RotXf64(theta float64) *Matf64 {
+	s, c := math.Sincos(theta)
+	return Matf64FromData([]float64{
+		1, 0, 0,
+		0, c, -s,
+		0, s, c,
+	}, 3, 3)
+}
+
+/*
+RotYf64 returns the 3 by 3 matrix which rotates column vectors about the y
+axis by theta radians. See RotXf64 for details.
+*/
+func RotYf64(theta float64) *Matf64 {
+	s, c := math.Sincos(theta)
+	return Matf64FromData([]float64{
+		c, 0, s,
+		0, 1, 0,
+		-s, 0, c,
+	}, 3, 3)
+}
+
+/*
+RotZf64 returns the 3 by 3 matrix which rotates column vectors about the z
+axis by theta radians. See RotXf64 for details.
+*/
+func RotZf64(theta float64) *Matf64 {
+	s, c := math.Sincos(theta)
+	return Matf64FromData([]float64{
+		c, -s, 0,
+		s, c, 0,
+		0, 0, 1,
+	}, 3, 3)
+}
+
+/*
+RotFromQuaternionf64 returns the 3 by 3 rotation matrix of the quaternion
+w + xi + yj + zk. The quaternion is normalized first, so it only needs to be
+non-zero. For example, the rotation by theta radians about the unit axis
+(ax, ay, az) is:
+
+	s, c := math.Sincos(theta / 2)
+	r := matrix.RotFromQuaternionf64(c, s*ax, s*ay, s*az)
+*/
+func RotFromQuaternionf64(w, x, y, z float64) *Matf64 {
+	n := math.Sqrt(w*w + x*x + y*y + z*z)
+	if n == 0.0 {
+		s := "\nIn matrix.%s, the quaternion cannot be zero."
+		s = fmt.Sprintf(s, "RotFromQuaternionf64()")
+		printErr(ErrArgument, s)
+	}
+	w, x, y, z = w/n, x/n, y/n, z/n
+	return Matf64FromData([]float64{
+		1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y),
+		2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x),
+		2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y),
+	}, 3, 3)
+}
+
+/*
+QuaternionFromRotf64 returns the unit quaternion w + xi + yj + zk of the
+passed 3 by 3 rotation matrix, with w >= 0. It is the inverse of
+RotFromQuaternionf64, up to the sign of the quaternion, since q and -q
+represent the same rotation.
+*/
+func QuaternionFromRotf64(m *Matf64) (w, x, y, z float64) {
+	if m.r != 3 || m.c != 3 {
+		s := "\nIn matrix.%s, the passed Matf64 is %d by %d, however it must\n"
+		s += "be 3 by 3."
+		s = fmt.Sprintf(s, "QuaternionFromRotf64()", m.r, m.c)
+		printErr(ErrShape, s)
+	}
+	r := m.vals
+	// Compute the largest component from the diagonal, for stability, and the
+	// others from the off-diagonal elements.
+	tr := r[0] + r[4] + r[8]
+	switch {
+	case tr > 0:
+		s := 2 * math.Sqrt(tr+1)
+		w, x, y, z = s/4, (r[7]-r[5])/s, (r[2]-r[6])/s, (r[3]-r[1])/s
+	case r[0] > r[4] && r[0] > r[8]:
+		s := 2 * math.Sqrt(1+r[0]-r[4]-r[8])
+		w, x, y, z = (r[7]-r[5])/s, s/4, (r[1]+r[3])/s, (r[2]+r[6])/s
+	case r[4] > r[8]:
+		s := 2 * math.Sqrt(1+r[4]-r[0]-r[8])
+		w, x, y, z = (r[2]-r[6])/s, (r[1]+r[3])/s, s/4, (r[5]+r[7])/s
+	default:
+		s := 2 * math.Sqrt(1+r[8]-r[0]-r[4])
+		w, x, y, z = (r[3]-r[1])/s, (r[2]+r[6])/s, (r[5]+r[7])/s, s/4
+	}
+	if w < 0 {
+		w, x, y, z = -w, -x, -y, -z
+	}
+	return w, x, y, z
+}
+
+/*
+Homogeneousf64 builds the homogeneous transform which applies the passed d by
+d rotation (or any linear map), followed by a translation by the passed
+vector of length d. The result is a d+1 by d+1 Matf64, so that 2D transforms
+are 3 by 3, and 3D transforms are 4 by 4. Homogeneous transforms are composed
+with Dot, where the rightmost is applied first:
+
+	cam := matrix.Homogeneousf64(matrix.RotZf64(yaw), []float64{1, 2, 0})
+	pts := matrix.TransformPointsf64(cam.Dot(obj), points)
+*/
+func Homogeneousf64(rot *Matf64, translation []float64) *Matf64 {
+	if rot.r != rot.c || len(translation) != rot.r {
+		s := "\nIn matrix.%s, the rotation must be a square Matf64 with as\n"
+		s += "many rows as the translation has elements, however a %d by %d\n"
+		s += "Matf64 and %d elements were received."
+		s = fmt.Sprintf(s, "Homogeneousf64()", rot.r, rot.c, len(translation))
+		printErr(ErrShape, s)
+	}
+	d := rot.r
+	h := Newf64(d+1, d+1)
+	for i := 0; i < d; i++ {
+		copy(h.vals[i*(d+1):], rot.vals[i*d:(i+1)*d])
+		h.vals[i*(d+1)+d] = translation[i]
+	}
+	h.vals[d*(d+1)+d] = 1.0
+	return h
+}
+
+/*
+TransformPointsf64 applies the passed d+1 by d+1 homogeneous transform to
+each row of the passed Matf64, in a single pass, and returns the transformed
+points in the same layout. The points can be given as N by d, with one
+point of dimension d per row, in which case the results are divided by their
+homogeneous coordinate if the transform has a projective part. They can also
+be given as N by d+1, already in homogeneous coordinates, in which case they
+are only multiplied by the transform:
+
+	cloud := matrix.TransformPointsf64(matrix.Translatef64(0, 0, -5), pts) // pts is N by 3
+*/
+func TransformPointsf64(t *Matf64, pts *Matf64) *Matf64 {
+	if t.r != t.c || (pts.c != t.c && pts.c+1 != t.c) {
+		s := "\nIn matrix.%s, the transform must be d+1 by d+1 for points\n"
+		s += "with d or d+1 columns, however it is %d by %d, and the points\n"
+		s += "have %d columns."
+		s = fmt.Sprintf(s, "TransformPointsf64()", t.r, t.c, pts.c)
+		printErr(ErrShape, s)
+	}
+	if pts.c == t.c {
+		return DotTf64(pts, t, false, true)
+	}
+	d := pts.c
+	o := Newf64(pts.r, d)
+	for k := 0; k < pts.r; k++ {
+		p := pts.vals[k*d : (k+1)*d]
+		out := o.vals[k*d : (k+1)*d]
+		w := t.vals[d*(d+1)+d]
+		for j := 0; j < d; j++ {
+			w += t.vals[d*(d+1)+j] * p[j]
+		}
+		for i := 0; i < d; i++ {
+			row := t.vals[i*(d+1) : (i+1)*(d+1)]
+			v := row[d]
+			for j := 0; j < d; j++ {
+				v += row[j] * p[j]
+			}
+			out[i] = v / w
+		}
+	}
+	return o
+}
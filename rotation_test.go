@@ -0,0 +1,80 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertMatInDelta(t *testing.T, want, got *Matf64, delta float64) {
+	t.Helper()
+	wr, wc := want.Shape()
+	gr, gc := got.Shape()
+	assert.Equal(t, wr, gr, "should have the same number of rows")
+	assert.Equal(t, wc, gc, "should have the same number of columns")
+	g := got.ToSlice1D()
+	for i, v := range want.ToSlice1D() {
+		assert.InDelta(t, v, g[i], delta, "should be equal")
+	}
+}
+
+func TestRot2Df64(t *testing.T) {
+	t.Helper()
+	v := Rot2Df64(math.Pi / 2).Dot(Matf64FromData([]float64{1, 0}, 2, 1))
+	assertMatInDelta(t, Matf64FromData([]float64{0, 1}, 2, 1), v, 1e-15)
+}
+
+func TestRotXYZf64(t *testing.T) {
+	t.Helper()
+	x := Matf64FromData([]float64{1, 0, 0}, 3, 1)
+	y := Matf64FromData([]float64{0, 1, 0}, 3, 1)
+	z := Matf64FromData([]float64{0, 0, 1}, 3, 1)
+	assertMatInDelta(t, z, RotXf64(math.Pi/2).Dot(y), 1e-15)
+	assertMatInDelta(t, x, RotYf64(math.Pi/2).Dot(z), 1e-15)
+	assertMatInDelta(t, y, RotZf64(math.Pi/2).Dot(x), 1e-15)
+}
+
+func TestRotFromQuaternionf64(t *testing.T) {
+	t.Helper()
+	s, c := math.Sincos(0.3)
+	assertMatInDelta(t, RotZf64(0.6), RotFromQuaternionf64(c, 0, 0, s), 1e-15)
+	assertMatInDelta(t, RotXf64(0.6), RotFromQuaternionf64(2*c, 2*s, 0, 0), 1e-15)
+}
+
+func TestQuaternionFromRotf64(t *testing.T) {
+	t.Helper()
+	rots := []*Matf64{
+		RotZf64(0.4).Dot(RotYf64(-1.1)).Dot(RotXf64(2.5)),
+		RotXf64(math.Pi),
+		RotYf64(math.Pi),
+		RotZf64(math.Pi),
+	}
+	for _, r := range rots {
+		w, x, y, z := QuaternionFromRotf64(r)
+		assert.True(t, w >= 0, "should have a non-negative real part")
+		assert.InDelta(t, 1.0, w*w+x*x+y*y+z*z, 1e-12, "should be a unit quaternion")
+		assertMatInDelta(t, r, RotFromQuaternionf64(w, x, y, z), 1e-12)
+	}
+}
+
+func TestHomogeneousf64(t *testing.T) {
+	t.Helper()
+	h := Homogeneousf64(Rot2Df64(math.Pi/2), []float64{1, 2})
+	want := Matf64FromData([]float64{0, -1, 1, 1, 0, 2, 0, 0, 1}, 3, 3)
+	assertMatInDelta(t, want, h, 1e-15)
+}
+
+func TestTransformPointsf64(t *testing.T) {
+	t.Helper()
+	h := Homogeneousf64(RotZf64(math.Pi/2), []float64{1, 0, 0})
+	pts := Matf64FromData([]float64{1, 0, 0, 0, 1, 5}, 2, 3)
+	want := Matf64FromData([]float64{1, 1, 0, 0, 0, 5}, 2, 3)
+	assertMatInDelta(t, want, TransformPointsf64(h, pts), 1e-15)
+	// Applying two transforms in turn is the same as applying their product.
+	g := Homogeneousf64(RotXf64(0.3), []float64{0, -2, 1})
+	assertMatInDelta(t, TransformPointsf64(g, TransformPointsf64(h, pts)), TransformPointsf64(g.Dot(h), pts), 1e-12)
+	// A projective transform divides by the homogeneous coordinate.
+	p := Matf64FromData([]float64{1, 0, 0, 0, 1, 0, 0, 1, 0}, 3, 3)
+	assertMatInDelta(t, Matf64FromData([]float64{2, 1}, 1, 2), TransformPointsf64(p, Matf64FromData([]float64{4, 2}, 1, 2)), 1e-15)
+}
@@ -0,0 +1,188 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+RunningStatsf64 accumulates the count, mean, variance, minimum and maximum of
+each column of a stream of rows, in a single pass, and without keeping the
+rows. The mean and variance are updated with Welford's algorithm, which is
+numerically stable. Combined with CSVChunksf64, this computes statistics of
+files which do not fit in memory:
+
+	it := matrix.CSVChunksf64("huge.csv", 10000)
+	defer it.Close()
+	var rs *matrix.RunningStatsf64
+	for it.Next() {
+		chunk := it.Chunk()
+		if rs == nil {
+			_, c := chunk.Shape()
+			rs = matrix.NewRunningStatsf64(c)
+		}
+		rs.Update(chunk)
+	}
+	fmt.Println(rs.Mean(), rs.Std())
+
+The fields of this struct are not directly accessible.
+*/
+type RunningStatsf64 struct {
+	n        int
+	mean, m2 []float64
+	min, max []float64
+}
+
+/*
+NewRunningStatsf64 returns an empty RunningStatsf64 for rows with the passed
+number of columns.
+*/
+func NewRunningStatsf64(cols int) *RunningStatsf64 {
+	if cols < 0 {
+		s := "\nIn matrix.%s, the number of columns cannot be negative,\n"
+		s += "however %d was received."
+		s = fmt.Sprintf(s, "NewRunningStatsf64()", cols)
+		printErr(ErrArgument, s)
+	}
+	rs := &RunningStatsf64{
+		mean: make([]float64, cols),
+		m2:   make([]float64, cols),
+		min:  make([]float64, cols),
+		max:  make([]float64, cols),
+	}
+	for j := range rs.min {
+		rs.min[j] = math.Inf(1)
+		rs.max[j] = math.Inf(-1)
+	}
+	return rs
+}
+
+/*
+UpdateRow adds a single row to a RunningStatsf64. The row must have as many
+elements as the RunningStatsf64 has columns.
+*/
+func (rs *RunningStatsf64) UpdateRow(row []float64) *RunningStatsf64 {
+	if len(row) != len(rs.mean) {
+		s := "\nIn %s, the row has %d elements, while the RunningStatsf64 has\n"
+		s += "%d columns. They must be equal."
+		s = fmt.Sprintf(s, "UpdateRow()", len(row), len(rs.mean))
+		printErr(ErrShape, s)
+	}
+	rs.update(row)
+	return rs
+}
+
+/*
+Update adds each row of the passed Matf64 to a RunningStatsf64. The Matf64
+must have as many columns as the RunningStatsf64.
+*/
+func (rs *RunningStatsf64) Update(m *Matf64) *RunningStatsf64 {
+	if m.c != len(rs.mean) {
+		s := "\nIn %s, the Matf64 has %d columns, while the RunningStatsf64\n"
+		s += "has %d. They must be equal."
+		s = fmt.Sprintf(s, "Update()", m.c, len(rs.mean))
+		printErr(ErrShape, s)
+	}
+	for i := 0; i < m.r; i++ {
+		rs.update(m.vals[i*m.c : (i+1)*m.c])
+	}
+	return rs
+}
+
+func (rs *RunningStatsf64) update(row []float64) {
+	rs.n++
+	for j, v := range row {
+		delta := v - rs.mean[j]
+		rs.mean[j] += delta / float64(rs.n)
+		rs.m2[j] += delta * (v - rs.mean[j])
+		if v < rs.min[j] {
+			rs.min[j] = v
+		}
+		if v > rs.max[j] {
+			rs.max[j] = v
+		}
+	}
+}
+
+/*
+Merge adds the rows accumulated by the passed RunningStatsf64 to the
+receiver, as if they had been passed to the receiver directly. This allows the
+statistics of separate parts of a dataset to be computed in parallel, and then
+combined.
+*/
+func (rs *RunningStatsf64) Merge(o *RunningStatsf64) *RunningStatsf64 {
+	if len(o.mean) != len(rs.mean) {
+		s := "\nIn %s, the receiver has %d columns, while the passed\n"
+		s += "RunningStatsf64 has %d. They must be equal."
+		s = fmt.Sprintf(s, "Merge()", len(rs.mean), len(o.mean))
+		printErr(ErrShape, s)
+	}
+	if o.n == 0 {
+		return rs
+	}
+	n := rs.n + o.n
+	for j := range rs.mean {
+		delta := o.mean[j] - rs.mean[j]
+		rs.mean[j] += delta * float64(o.n) / float64(n)
+		rs.m2[j] += o.m2[j] + delta*delta*float64(rs.n)*float64(o.n)/float64(n)
+		rs.min[j] = math.Min(rs.min[j], o.min[j])
+		rs.max[j] = math.Max(rs.max[j], o.max[j])
+	}
+	rs.n = n
+	return rs
+}
+
+/*
+Count returns the number of rows which were added to a RunningStatsf64.
+*/
+func (rs *RunningStatsf64) Count() int {
+	return rs.n
+}
+
+// rowVector returns a 1 by len(vals) Matf64 holding a copy of vals.
+func rowVector(vals []float64) *Matf64 {
+	return Matf64FromData(vals, 1, len(vals))
+}
+
+/*
+Mean returns the mean of each column as a row vector.
+*/
+func (rs *RunningStatsf64) Mean() *Matf64 {
+	return rowVector(rs.mean)
+}
+
+/*
+Var returns the variance of each column as a row vector. As with the Std
+method of Matf64, the variance is normalized by the number of rows.
+*/
+func (rs *RunningStatsf64) Var() *Matf64 {
+	v := rowVector(rs.m2)
+	if rs.n > 0 {
+		v.Div(float64(rs.n))
+	}
+	return v
+}
+
+/*
+Std returns the standard deviation of each column as a row vector, which is
+the square root of Var.
+*/
+func (rs *RunningStatsf64) Std() *Matf64 {
+	return rs.Var().Map(func(v *float64) { *v = math.Sqrt(*v) })
+}
+
+/*
+Min returns the minimum of each column as a row vector, which is +Inf for a
+RunningStatsf64 with no rows.
+*/
+func (rs *RunningStatsf64) Min() *Matf64 {
+	return rowVector(rs.min)
+}
+
+/*
+Max returns the maximum of each column as a row vector, which is -Inf for a
+RunningStatsf64 with no rows.
+*/
+func (rs *RunningStatsf64) Max() *Matf64 {
+	return rowVector(rs.max)
+}
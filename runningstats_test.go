@@ -0,0 +1,61 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunningStatsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 10,
+		2, 20,
+		3, 30,
+		6, -4,
+	}, 4, 2)
+	rs := NewRunningStatsf64(2)
+	rs.UpdateRow([]float64{1, 10}).Update(Matf64FromData([]float64{2, 20, 3, 30, 6, -4}, 3, 2))
+	assert.Equal(t, 4, rs.Count(), "should be equal")
+	assert.Equal(t, []float64{3, 14}, rs.Mean().ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{1, -4}, rs.Min().ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{6, 30}, rs.Max().ToSlice1D(), "should be equal")
+	for j := 0; j < 2; j++ {
+		col := m.Col(j)
+		assert.InDelta(t, col.Std(), rs.Std().Get(0, j), 1e-12, "should be equal")
+		assert.InDelta(t, col.Std()*col.Std(), rs.Var().Get(0, j), 1e-12, "should be equal")
+	}
+}
+
+func TestRunningStatsf64Empty(t *testing.T) {
+	t.Helper()
+	rs := NewRunningStatsf64(1)
+	assert.Equal(t, 0, rs.Count(), "should be empty")
+	assert.Equal(t, []float64{0}, rs.Var().ToSlice1D(), "should be zero")
+	assert.True(t, math.IsInf(rs.Min().Get(0, 0), 1), "should be +Inf")
+	assert.True(t, math.IsInf(rs.Max().Get(0, 0), -1), "should be -Inf")
+}
+
+func TestRunningStatsf64Merge(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(50, 3)
+	all := NewRunningStatsf64(3).Update(m)
+	a := NewRunningStatsf64(3)
+	b := NewRunningStatsf64(3)
+	for i := 0; i < 50; i++ {
+		if i%3 == 0 {
+			a.UpdateRow(m.Row(i).ToSlice1D())
+		} else {
+			b.UpdateRow(m.Row(i).ToSlice1D())
+		}
+	}
+	a.Merge(b).Merge(NewRunningStatsf64(3))
+	assert.Equal(t, 50, a.Count(), "should be equal")
+	for j := 0; j < 3; j++ {
+		assert.InDelta(t, all.Mean().Get(0, j), a.Mean().Get(0, j), 1e-12, "should be equal")
+		assert.InDelta(t, all.Var().Get(0, j), a.Var().Get(0, j), 1e-12, "should be equal")
+	}
+	assert.Equal(t, all.Min().ToSlice1D(), a.Min().ToSlice1D(), "should be equal")
+	assert.Equal(t, all.Max().ToSlice1D(), a.Max().ToSlice1D(), "should be equal")
+}
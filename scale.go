@@ -0,0 +1,59 @@
+package matrix
+
+import "fmt"
+
+/*
+Scale multiplies each element of the receiver by the passed float64, in a
+single pass. It is the same as Mul with a float64, without the type switch.
+*/
+func (m *Matf64) Scale(a float64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Scale(a) })
+	}
+	m.checkWritable("Scale()")
+	for i := range m.vals {
+		m.vals[i] *= a
+	}
+	return m.checkFinite("Scale()")
+}
+
+/*
+Neg negates each element of the receiver.
+*/
+func (m *Matf64) Neg() *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Neg() })
+	}
+	m.checkWritable("Neg()")
+	for i := range m.vals {
+		m.vals[i] = -m.vals[i]
+	}
+	return m
+}
+
+/*
+AddScaled adds the passed Matf64, multiplied by the passed float64, to the
+receiver, in a single pass and without changing the passed Matf64. This is
+the update at the core of most iterative solvers:
+
+	x.AddScaled(alpha, p) // x = x + alpha*p
+	r.AddScaled(-alpha, ap) // r = r - alpha*ap
+
+The passed Matf64 must have the same shape as the receiver.
+*/
+func (m *Matf64) AddScaled(a float64, n *Matf64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.AddScaled(a, n) })
+	}
+	m.checkWritable("AddScaled()")
+	if m.r != n.r || m.c != n.c {
+		s := "\nIn %s, the receiver is %d by %d, while the passed Matf64 is\n"
+		s += "%d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, "AddScaled()", m.r, m.c, n.r, n.c)
+		printErr(&ErrDimMismatch{"AddScaled()", m.r, m.c, n.r, n.c}, s)
+	}
+	for i, v := range n.vals {
+		m.vals[i] += a * v
+	}
+	return m.checkFinite("AddScaled()")
+}
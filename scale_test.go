@@ -0,0 +1,29 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, -2, 3, 4}, 2, 2)
+	assert.Equal(t, []float64{2, -4, 6, 8}, m.Scale(2).ToSlice1D(), "should be equal")
+	assert.True(t, m.Equals(Matf64FromData([]float64{1, -2, 3, 4}, 2, 2).Mul(2.0)), "should match Mul")
+}
+
+func TestNegf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, -2, 0, 4}, 2, 2)
+	assert.Equal(t, []float64{-1, 2, 0, -4}, m.Neg().ToSlice1D(), "should be equal")
+}
+
+func TestAddScaledf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	n := Matf64FromData([]float64{1, 1, 2, 2}, 2, 2)
+	m.AddScaled(-2, n)
+	assert.Equal(t, []float64{-1, 0, -1, 0}, m.ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{1, 1, 2, 2}, n.ToSlice1D(), "should not change n")
+}
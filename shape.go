@@ -0,0 +1,74 @@
+package matrix
+
+import "fmt"
+
+/*
+Shape holds the number of rows and columns of a Matf64 as a single value, so
+that the two cannot be swapped when they are passed around. Shapes can be
+compared with ==:
+
+	if m.ShapeV() == n.ShapeV() {
+		m.Add(n)
+	}
+*/
+type Shape struct {
+	R, C int
+}
+
+/*
+Size returns the number of elements of a matrix of the given Shape.
+*/
+func (s Shape) Size() int {
+	return s.R * s.C
+}
+
+/*
+T returns the Shape of the transpose of a matrix of the given Shape.
+*/
+func (s Shape) T() Shape {
+	return Shape{s.C, s.R}
+}
+
+/*
+String returns the Shape as "RxC".
+*/
+func (s Shape) String() string {
+	return fmt.Sprintf("%dx%d", s.R, s.C)
+}
+
+/*
+ShapeV returns the number of rows and columns of a Matf64 as a Shape value,
+while Shape returns them as two ints.
+*/
+func (m *Matf64) ShapeV() Shape {
+	return Shape{m.r, m.c}
+}
+
+/*
+Size returns the number of elements of a Matf64.
+*/
+func (m *Matf64) Size() int {
+	return m.r * m.c
+}
+
+/*
+IsSquare returns true if a Matf64 has as many rows as columns.
+*/
+func (m *Matf64) IsSquare() bool {
+	return m.r == m.c
+}
+
+/*
+IsVector returns true if a Matf64 is a row vector or a column vector, that is
+if it has a single row or a single column.
+*/
+func (m *Matf64) IsVector() bool {
+	return m.isRowVector() || m.isColVector()
+}
+
+/*
+IsEmpty returns true if a Matf64 has no elements.
+*/
+func (m *Matf64) IsEmpty() bool {
+	return m.r == 0 || m.c == 0
+}
@@ -0,0 +1,32 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShapeVf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 3)
+	s := m.ShapeV()
+	assert.Equal(t, Shape{2, 3}, s, "should be equal")
+	assert.Equal(t, 6, s.Size(), "should be equal")
+	assert.Equal(t, Shape{3, 2}, s.T(), "should be equal")
+	assert.Equal(t, m.T().ShapeV(), s.T(), "should be equal")
+	assert.Equal(t, "2x3", s.String(), "should be equal")
+	assert.True(t, s == Newf64(2, 3).ShapeV(), "should be equal")
+	assert.False(t, s == s.T(), "should not be equal")
+}
+
+func TestPredicatesf64(t *testing.T) {
+	t.Helper()
+	assert.Equal(t, 6, Newf64(2, 3).Size(), "should be equal")
+	assert.True(t, Newf64(3).IsSquare(), "should be square")
+	assert.False(t, Newf64(2, 3).IsSquare(), "should not be square")
+	assert.True(t, Newf64(1, 4).IsVector(), "should be a vector")
+	assert.True(t, Newf64(4, 1).IsVector(), "should be a vector")
+	assert.False(t, Newf64(2, 2).IsVector(), "should not be a vector")
+	assert.True(t, Newf64(0, 3).IsEmpty(), "should be empty")
+	assert.False(t, Newf64(1, 1).IsEmpty(), "should not be empty")
+}
@@ -0,0 +1,99 @@
+package matrix
+
+import (
+	"fmt"
+	"sync"
+)
+
+// sharedMats holds the constant matrices returned by ZerosSharedf64 and
+// EyeSharedf64, keyed by their kind and shape.
+var sharedMats struct {
+	sync.Mutex
+	m map[sharedKey]*Matf64
+}
+
+type sharedKey struct {
+	eye  bool
+	r, c int
+}
+
+/*
+ZerosSharedf64 returns an r by c Matf64 of zeros, which is shared by all the
+callers asking for the same shape. It is created on the first call, and later
+calls do not allocate, so it can be used as an operand in hot loops:
+
+	z := matrix.ZerosSharedf64(3, 3)
+	for ... {
+		if m.Equals(z) {
+			...
+		}
+	}
+
+The returned Matf64 is read-only, as a change would be seen by every other
+caller: the methods which change the receiver, such as Set, Add or Reshape,
+fail with ErrReadOnly, as do those of its row and column views. Only
+SetUnsafe, which does no checks, and the storage shared by RawRowMajor,
+gonumconv.AsGonum and tensorconv.ToTensor are not guarded, and must not be
+written to. Use Copy or Newf64 to get a Matf64 which can be changed. It is
+safe to call from multiple goroutines.
+*/
+func ZerosSharedf64(r, c int) *Matf64 {
+	return sharedMat(sharedKey{false, r, c})
+}
+
+/*
+EyeSharedf64 returns the n by n identity matrix, which is shared by all the
+callers asking for the same size, in the same manner as ZerosSharedf64. The
+returned Matf64 is read-only.
+*/
+func EyeSharedf64(n int) *Matf64 {
+	return sharedMat(sharedKey{true, n, n})
+}
+
+func sharedMat(k sharedKey) *Matf64 {
+	sharedMats.Lock()
+	defer sharedMats.Unlock()
+	if m, ok := sharedMats.m[k]; ok {
+		return m
+	}
+	m := Newf64(k.r, k.c)
+	// Drop the extra capacity of Newf64, so that an append to a shared
+	// Matf64 cannot write into the shared backing array.
+	m.vals = m.vals[:len(m.vals):len(m.vals)]
+	if k.eye {
+		for i := 0; i < k.r; i++ {
+			m.vals[i*k.c+i] = 1.0
+		}
+	}
+	m.readOnly = true
+	if sharedMats.m == nil {
+		sharedMats.m = make(map[sharedKey]*Matf64)
+	}
+	sharedMats.m[k] = m
+	return m
+}
+
+/*
+IsReadOnly returns true if the methods which change the receiver fail on it,
+as for the Matf64s returned by ZerosSharedf64, EyeSharedf64 and the Mat
+method of Labeledf64.
+*/
+func (m *Matf64) IsReadOnly() bool {
+	return m.readOnly
+}
+
+// errReadOnlyTarget is returned by the methods which decode into their
+// receiver, and return an error instead of reporting it, such as ReadFrom.
+var errReadOnlyTarget = fmt.Errorf("cannot decode into a read-only Matf64: %w", ErrReadOnly)
+
+// checkWritable reports an error if m is read-only. It is called by the
+// methods which change their receiver, with the name of the method.
+func (m *Matf64) checkWritable(fn string) {
+	if m.readOnly {
+		s := "\nIn %s, the receiver is a read-only %d by %d Matf64, such as\n"
+		s += "one of ZerosSharedf64, EyeSharedf64 or the Mat method of Labeledf64.\n"
+		s += "Use Copy to get a Matf64 which can be changed."
+		s = fmt.Sprintf(s, fn, m.r, m.c)
+		printHelperErr(ErrReadOnly, s)
+	}
+}
@@ -0,0 +1,67 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZerosSharedf64(t *testing.T) {
+	t.Helper()
+	z := ZerosSharedf64(2, 3)
+	assert.True(t, z.Equals(Newf64(2, 3)), "should be zeros")
+	assert.True(t, z == ZerosSharedf64(2, 3), "should be shared")
+	assert.False(t, z == ZerosSharedf64(3, 2), "should depend on the shape")
+}
+
+func TestEyeSharedf64(t *testing.T) {
+	t.Helper()
+	e := EyeSharedf64(3)
+	assert.True(t, e.Equals(Matf64FromData([]float64{
+		1, 0, 0,
+		0, 1, 0,
+		0, 0, 1,
+	}, 3, 3)), "should be the identity")
+	assert.True(t, e == EyeSharedf64(3), "should be shared")
+	assert.False(t, e == ZerosSharedf64(3, 3), "should not be zeros")
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	assert.True(t, m.Dot(e).Equals(m), "should be equal")
+}
+
+func TestSharedReadOnlyf64(t *testing.T) {
+	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
+	z := ZerosSharedf64(2, 2)
+	assert.True(t, z.IsReadOnly(), "should be read-only")
+	assert.True(t, EyeSharedf64(2).IsReadOnly(), "should be read-only")
+	assert.False(t, z.Copy().IsReadOnly(), "a copy should be writable")
+	assert.False(t, Newf64(2, 2).IsReadOnly(), "should be writable")
+
+	n := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	for name, f := range map[string]func(){
+		"Set":         func() { z.Set(0, 0, 1) },
+		"SetAll":      func() { z.SetAll(1) },
+		"Map":         func() { z.Map(func(v *float64) { *v = 1 }) },
+		"SetRow":      func() { z.SetRow(0, 1.0) },
+		"Add":         func() { z.Add(n) },
+		"Mul":         func() { z.Mul(2.0) },
+		"Scale":       func() { z.Scale(2) },
+		"Reshape":     func() { z.Reshape(1, 4) },
+		"AppendRow":   func() { z.AppendRow([]float64{1, 2}) },
+		"DeferErrors": func() { z.DeferErrors() },
+		"RowView":     func() { z.RowView(0).Set(0, 1) },
+		"ColView":     func() { z.ColView(1).SetAll(1) },
+	} {
+		err := Catch(f)
+		assert.True(t, errors.Is(err, ErrReadOnly), name+" should fail")
+	}
+	assert.True(t, z.Equals(Newf64(2, 2)), "should be unchanged")
+	assert.False(t, z.deferErr, "should not be in deferred error mode")
+
+	_, err := z.TryAdd(n)
+	assert.True(t, errors.Is(err, ErrReadOnly), "should fail")
+	b, _ := n.MarshalMsgpack()
+	assert.True(t, errors.Is(z.UnmarshalMsgpack(b), ErrReadOnly), "should fail")
+	assert.True(t, n.Add(z).Equals(n), "should be usable as an operand")
+}
@@ -0,0 +1,239 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+SolveStats holds diagnostics of the solution of a linear system, as returned
+by SolveWithStats, from which the quality of the solution can be judged.
+*/
+type SolveStats struct {
+	// Cond is an estimate of the condition number of the matrix in the
+	// 1-norm. Roughly, log10(Cond) digits of accuracy are lost in the
+	// solution, so values near 1/eps (about 1e16) mean that the solution
+	// cannot be trusted.
+	Cond float64
+	// PivotGrowth is the largest element of the factor U of the LU
+	// decomposition, divided by the largest element of the matrix. Large
+	// values mean that the elimination was unstable.
+	PivotGrowth float64
+	// Residual is the largest absolute element of b - A.Dot(x).
+	Residual float64
+}
+
+/*
+Solve returns the Matf64 x such that m.Dot(x) is equal to the passed Matf64,
+by an LU decomposition of the receiver with partial pivoting. The receiver
+must be square and not singular, and the passed Matf64 must have as many rows
+as the receiver. Each of its columns is solved for separately. Neither the
+receiver nor the passed Matf64 is changed.
+*/
+func (m *Matf64) Solve(b *Matf64) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Solve(b) })
+	}
+	return m.luf64("Solve()", b).solve(b)
+}
+
+/*
+SolveWithStats does the same as Solve, and also returns a SolveStats, so that
+the solutions of ill-conditioned systems can be detected and rejected:
+
+	x, stats := a.SolveWithStats(b)
+	if stats.Cond > 1e12 {
+		// the solution may only have a few correct digits
+	}
+
+Estimating the condition number costs a few more solves with the existing
+decomposition, which is much cheaper than the decomposition itself.
+*/
+func (m *Matf64) SolveWithStats(b *Matf64) (*Matf64, SolveStats) {
+	if m.deferErr {
+		var x *Matf64
+		var stats SolveStats
+		if !m.deferredDo(func() { x, stats = m.SolveWithStats(b) }) {
+			return m, SolveStats{}
+		}
+		x.deferErr = true
+		return x, stats
+	}
+	f := m.luf64("SolveWithStats()", b)
+	x := f.solve(b)
+	var stats SolveStats
+	maxA, maxU := 0.0, 0.0
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			maxA = math.Max(maxA, math.Abs(m.vals[i*m.c+j]))
+			if j >= i {
+				maxU = math.Max(maxU, math.Abs(f.lu.vals[i*m.c+j]))
+			}
+		}
+	}
+	if maxA > 0.0 {
+		stats.PivotGrowth = maxU / maxA
+	}
+	stats.Cond = norm1(m) * f.invNorm1()
+	ax := m.Dot(x)
+	for i, v := range b.vals {
+		stats.Residual = math.Max(stats.Residual, math.Abs(v-ax.vals[i]))
+	}
+	return x, stats
+}
+
+// luf64 is the LU decomposition with partial pivoting of a square matrix A,
+// such that the rows of A, swapped in turn with the rows in piv, are equal
+// to L.Dot(U). L has a unit diagonal, and both are stored in lu.
+type luf64 struct {
+	lu  *Matf64
+	piv []int
+}
+
+func (m *Matf64) luf64(fn string, b *Matf64) *luf64 {
+	if m.r != m.c {
+		s := "\nIn %s, the receiver is %d by %d. It must be square."
+		s = fmt.Sprintf(s, fn, m.r, m.c)
+		printHelperErr(&ErrDimMismatch{fn, m.r, m.c, b.r, b.c}, s)
+	}
+	if b.r != m.r {
+		s := "\nIn %s the number of rows of the receiver is %d, while the\n"
+		s += "number of rows of the passed Matf64 is %d. They must be equal."
+		s = fmt.Sprintf(s, fn, m.r, b.r)
+		printHelperErr(&ErrDimMismatch{fn, m.r, m.c, b.r, b.c}, s)
+	}
+	f, err := m.lu(fn)
+	if err != nil {
+		printHelperErr(err, "\n"+err.Error())
+	}
+	return f
+}
+
+// lu returns the LU decomposition of the square m, or an *ErrSingular.
+func (m *Matf64) lu(fn string) (*luf64, error) {
+	n := m.r
+	a := m.Copy()
+	piv := make([]int, n)
+	for k := 0; k < n; k++ {
+		p := pivotRow(a, k)
+		if a.vals[p*n+k] == 0.0 {
+			return nil, &ErrSingular{fn, k}
+		}
+		piv[k] = p
+		swapRows(a, p, k)
+		pivot := a.vals[k*n+k]
+		for i := k + 1; i < n; i++ {
+			f := a.vals[i*n+k] / pivot
+			a.vals[i*n+k] = f
+			for j := k + 1; j < n; j++ {
+				a.vals[i*n+j] -= f * a.vals[k*n+j]
+			}
+		}
+	}
+	return &luf64{a, piv}, nil
+}
+
+// solve returns x such that A.Dot(x) is equal to b.
+func (f *luf64) solve(b *Matf64) *Matf64 {
+	n, a := f.lu.r, f.lu.vals
+	x := b.Copy()
+	for k, p := range f.piv {
+		swapRows(x, p, k)
+	}
+	for i := 0; i < n; i++ {
+		xi := x.vals[i*x.c : (i+1)*x.c]
+		for j := 0; j < i; j++ {
+			v, xj := a[i*n+j], x.vals[j*x.c:(j+1)*x.c]
+			for k := range xi {
+				xi[k] -= v * xj[k]
+			}
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		xi := x.vals[i*x.c : (i+1)*x.c]
+		for j := i + 1; j < n; j++ {
+			v, xj := a[i*n+j], x.vals[j*x.c:(j+1)*x.c]
+			for k := range xi {
+				xi[k] -= v * xj[k]
+			}
+		}
+		for k := range xi {
+			xi[k] /= a[i*n+i]
+		}
+	}
+	return x
+}
+
+// solveT overwrites v with z such that A.T().Dot(z) is equal to v.
+func (f *luf64) solveT(v []float64) {
+	n, a := f.lu.r, f.lu.vals
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			v[i] -= a[j*n+i] * v[j]
+		}
+		v[i] /= a[i*n+i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			v[i] -= a[j*n+i] * v[j]
+		}
+	}
+	for k := n - 1; k >= 0; k-- {
+		p := f.piv[k]
+		v[k], v[p] = v[p], v[k]
+	}
+}
+
+// invNorm1 estimates the 1-norm of the inverse of A, by the method of Hager,
+// which needs a few solves instead of the whole inverse.
+func (f *luf64) invNorm1() float64 {
+	n := f.lu.r
+	if n == 0 {
+		return 0.0
+	}
+	x := Newf64(n, 1).SetAll(1.0 / float64(n))
+	est := 0.0
+	for iter := 0; iter < 5; iter++ {
+		y := f.solve(x)
+		norm := 0.0
+		z := make([]float64, n)
+		for i, v := range y.vals {
+			norm += math.Abs(v)
+			z[i] = 1.0
+			if v < 0.0 {
+				z[i] = -1.0
+			}
+		}
+		if norm <= est {
+			break
+		}
+		est = norm
+		f.solveT(z)
+		j, zx := 0, 0.0
+		for i, v := range z {
+			zx += v * x.vals[i]
+			if math.Abs(v) > math.Abs(z[j]) {
+				j = i
+			}
+		}
+		if math.Abs(z[j]) <= zx {
+			break
+		}
+		x.SetAll(0.0)
+		x.vals[j] = 1.0
+	}
+	return est
+}
+
+// norm1 returns the largest sum of the absolute values of a column of m.
+func norm1(m *Matf64) float64 {
+	max := 0.0
+	for j := 0; j < m.c; j++ {
+		sum := 0.0
+		for i := 0; i < m.r; i++ {
+			sum += math.Abs(m.vals[i*m.c+j])
+		}
+		max = math.Max(max, sum)
+	}
+	return max
+}
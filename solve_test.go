@@ -0,0 +1,52 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolvef64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{
+		0, 2, 1,
+		1, 1, 1,
+		2, 1, 3,
+	}, 3, 3)
+	x := Matf64FromData([]float64{
+		1, 2,
+		-1, 0,
+		3, 1,
+	}, 3, 2)
+	b := a.Dot(x)
+	assertMatInDelta(t, x, a.Solve(b), 1e-12)
+	assert.Equal(t, []float64{0, 2, 1, 1, 1, 1, 2, 1, 3}, a.ToSlice1D(), "should not change a")
+}
+
+func TestSolveWithStatsf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{
+		4, 1, 0, 0, 1,
+		1, 4, 1, 0, 0,
+		0, 1, 4, 1, 0,
+		0, 0, 1, 4, 1,
+		1, 0, 0, 1, 4,
+	}, 5, 5)
+	b := Matf64FromData([]float64{1, 2, 3, 4, 5}, 5, 1)
+	x, stats := a.SolveWithStats(b)
+	assertMatInDelta(t, b, a.Dot(x), 1e-12)
+	assert.True(t, stats.Residual < 1e-12, "should be small")
+	cond := norm1(a) * norm1(a.Inv())
+	assert.True(t, stats.Cond <= cond*(1+1e-12), "should not overestimate")
+	assert.True(t, stats.Cond >= cond/3, "should be close")
+	assert.True(t, stats.PivotGrowth >= 1 && stats.PivotGrowth < 2, "should be small")
+
+	h := Newf64(5)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			h.Set(i, j, 1/float64(i+j+1))
+		}
+	}
+	_, stats = h.SolveWithStats(b)
+	assert.True(t, stats.Cond > 1e5, "should detect the ill-conditioned Hilbert matrix")
+}
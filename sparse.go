@@ -0,0 +1,481 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+SparseFormat is the storage format of a Sparsef64.
+*/
+type SparseFormat int
+
+const (
+	// CSR (compressed sparse row) stores the non-zero elements row by row,
+	// which makes accessing rows and multiplying by vectors fast.
+	CSR SparseFormat = iota
+	// CSC (compressed sparse column) stores the non-zero elements column by
+	// column, which makes accessing columns fast.
+	CSC
+)
+
+func (f SparseFormat) String() string {
+	switch f {
+	case CSR:
+		return "CSR"
+	case CSC:
+		return "CSC"
+	}
+	return fmt.Sprintf("SparseFormat(%d)", int(f))
+}
+
+/*
+Sparsef64 is a sparse matrix, which only stores its non-zero elements. This is
+much more efficient than a Matf64 for matrices which are mostly zeros, such as
+the adjacency matrices of graphs, or the feature matrices of text documents.
+
+The non-zero elements are stored in either the CSR or the CSC format. In both
+formats, the elements of each row (for CSR) or column (for CSC) are stored
+contiguously, sorted by their column (or row) index. As with Matf64, the
+fields of this struct are not directly accessible.
+*/
+type Sparsef64 struct {
+	r, c   int
+	format SparseFormat
+	// ptr[i] is the index in idx and vals of the first element of row (or
+	// column) i, and ptr[len(ptr)-1] is the number of stored elements.
+	ptr  []int
+	idx  []int
+	vals []float64
+}
+
+/*
+NewSparsef64 creates an r by c Sparsef64 in the passed format from a list of
+triplets, such that the element at row rows[k] and column cols[k] is vals[k]:
+
+	// [[1.0, 0.0, 0.0],
+	//  [0.0, 0.0, 2.0]]
+	s := matrix.NewSparsef64(2, 3, []int{0, 1}, []int{0, 2}, []float64{1.0, 2.0}, matrix.CSR)
+
+The three slices must have the same length, and the triplets may be in any
+order. If the same row and column appear more than once, their values are
+summed. Passing empty slices results in an r by c Sparsef64 of all zeros.
+*/
+func NewSparsef64(r, c int, rows, cols []int, vals []float64, format SparseFormat) *Sparsef64 {
+	if len(rows) != len(vals) || len(cols) != len(vals) {
+		s := "\nIn matrix.%s, the number of rows (%d), columns (%d) and values\n"
+		s += "(%d) of the triplets must be equal."
+		s = fmt.Sprintf(s, "NewSparsef64()", len(rows), len(cols), len(vals))
+		printErr(ErrShape, s)
+	}
+	checkSparseFormat("NewSparsef64()", format)
+	for k := range vals {
+		if rows[k] < 0 || rows[k] >= r || cols[k] < 0 || cols[k] >= c {
+			s := "\nIn matrix.%s, triplet %d is at row %d and column %d, which is\n"
+			s += "outside of the bounds of a %d by %d matrix."
+			s = fmt.Sprintf(s, "NewSparsef64()", k, rows[k], cols[k], r, c)
+			printErr(ErrBounds, s)
+		}
+	}
+	if format == CSR {
+		return newSparsef64(r, c, CSR, rows, cols, vals)
+	}
+	return newSparsef64(r, c, CSC, cols, rows, vals)
+}
+
+/*
+SparseFromMatf64 creates a Sparsef64 in the passed format from a Matf64. Only
+the elements whose absolute value is greater than the passed threshold are
+stored, so that a threshold of 0.0 keeps all non-zero elements. NaNs are
+always stored:
+
+	s := matrix.SparseFromMatf64(m, 1e-12, matrix.CSR)
+*/
+func SparseFromMatf64(m *Matf64, threshold float64, format SparseFormat) *Sparsef64 {
+	checkSparseFormat("SparseFromMatf64()", format)
+	var rows, cols []int
+	var vals []float64
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if v := m.vals[i*m.c+j]; !(math.Abs(v) <= threshold) {
+				rows = append(rows, i)
+				cols = append(cols, j)
+				vals = append(vals, v)
+			}
+		}
+	}
+	if format == CSR {
+		return newSparsef64(m.r, m.c, CSR, rows, cols, vals)
+	}
+	return newSparsef64(m.r, m.c, CSC, cols, rows, vals)
+}
+
+func checkSparseFormat(fn string, format SparseFormat) {
+	if format != CSR && format != CSC {
+		s := "\nIn matrix.%s, the format must be CSR or CSC, however %v\n"
+		s += "was received."
+		s = fmt.Sprintf(s, fn, format)
+		printHelperErr(ErrArgument, s)
+	}
+}
+
+// newSparsef64 compresses a list of triplets, given as major (row for CSR,
+// column for CSC) and minor indices, into a Sparsef64. Duplicate triplets are
+// summed.
+func newSparsef64(r, c int, format SparseFormat, major, minor []int, vals []float64) *Sparsef64 {
+	nMajor := r
+	if format == CSC {
+		nMajor = c
+	}
+	s := &Sparsef64{
+		r:      r,
+		c:      c,
+		format: format,
+		ptr:    make([]int, nMajor+1),
+		idx:    make([]int, len(vals)),
+		vals:   make([]float64, len(vals)),
+	}
+	for _, i := range major {
+		s.ptr[i+1]++
+	}
+	for i := 0; i < nMajor; i++ {
+		s.ptr[i+1] += s.ptr[i]
+	}
+	next := make([]int, nMajor)
+	copy(next, s.ptr)
+	for k, i := range major {
+		s.idx[next[i]] = minor[k]
+		s.vals[next[i]] = vals[k]
+		next[i]++
+	}
+	// Sort each row (or column) by its minor index, and sum the duplicates.
+	out := 0
+	for i := 0; i < nMajor; i++ {
+		start, end := s.ptr[i], s.ptr[i+1]
+		sort.Sort(sparseSegment{s.idx[start:end], s.vals[start:end]})
+		s.ptr[i] = out
+		for k := start; k < end; k++ {
+			if out > s.ptr[i] && s.idx[out-1] == s.idx[k] {
+				s.vals[out-1] += s.vals[k]
+				continue
+			}
+			s.idx[out] = s.idx[k]
+			s.vals[out] = s.vals[k]
+			out++
+		}
+	}
+	s.ptr[nMajor] = out
+	s.idx = s.idx[:out]
+	s.vals = s.vals[:out]
+	return s
+}
+
+type sparseSegment struct {
+	idx  []int
+	vals []float64
+}
+
+func (s sparseSegment) Len() int           { return len(s.idx) }
+func (s sparseSegment) Less(i, j int) bool { return s.idx[i] < s.idx[j] }
+func (s sparseSegment) Swap(i, j int) {
+	s.idx[i], s.idx[j] = s.idx[j], s.idx[i]
+	s.vals[i], s.vals[j] = s.vals[j], s.vals[i]
+}
+
+// triplets returns the major and minor indices and the values of all stored
+// elements of a Sparsef64.
+func (s *Sparsef64) triplets() (major, minor []int, vals []float64) {
+	major = make([]int, len(s.vals))
+	for i := 0; i+1 < len(s.ptr); i++ {
+		for k := s.ptr[i]; k < s.ptr[i+1]; k++ {
+			major[k] = i
+		}
+	}
+	minor = make([]int, len(s.idx))
+	copy(minor, s.idx)
+	vals = make([]float64, len(s.vals))
+	copy(vals, s.vals)
+	return major, minor, vals
+}
+
+/*
+Shape returns the number of rows and columns of a Sparsef64.
+*/
+func (s *Sparsef64) Shape() (int, int) {
+	return s.r, s.c
+}
+
+/*
+Format returns the storage format of a Sparsef64.
+*/
+func (s *Sparsef64) Format() SparseFormat {
+	return s.format
+}
+
+/*
+NNZ returns the number of stored (non-zero) elements of a Sparsef64.
+*/
+func (s *Sparsef64) NNZ() int {
+	return len(s.vals)
+}
+
+/*
+Get returns the element at the given row and column of a Sparsef64, which is
+0.0 for elements which are not stored.
+*/
+func (s *Sparsef64) Get(r, c int) float64 {
+	if r < 0 || r >= s.r || c < 0 || c >= s.c {
+		str := "\nIn %s, row %d and column %d are outside of the bounds of\n"
+		str += "a %d by %d matrix."
+		str = fmt.Sprintf(str, "Get()", r, c, s.r, s.c)
+		printErr(ErrBounds, str)
+	}
+	major, minor := r, c
+	if s.format == CSC {
+		major, minor = c, r
+	}
+	start, end := s.ptr[major], s.ptr[major+1]
+	k := start + sort.SearchInts(s.idx[start:end], minor)
+	if k < end && s.idx[k] == minor {
+		return s.vals[k]
+	}
+	return 0.0
+}
+
+/*
+ToMatf64 returns a dense Matf64 with the same values as a Sparsef64.
+*/
+func (s *Sparsef64) ToMatf64() *Matf64 {
+	m := Newf64(s.r, s.c)
+	for i := 0; i+1 < len(s.ptr); i++ {
+		for k := s.ptr[i]; k < s.ptr[i+1]; k++ {
+			if s.format == CSR {
+				m.vals[i*m.c+s.idx[k]] = s.vals[k]
+			} else {
+				m.vals[s.idx[k]*m.c+i] = s.vals[k]
+			}
+		}
+	}
+	return m
+}
+
+/*
+Copy returns a duplicate of a Sparsef64, which shares no data with the
+original.
+*/
+func (s *Sparsef64) Copy() *Sparsef64 {
+	n := &Sparsef64{
+		r:      s.r,
+		c:      s.c,
+		format: s.format,
+		ptr:    make([]int, len(s.ptr)),
+		idx:    make([]int, len(s.idx)),
+		vals:   make([]float64, len(s.vals)),
+	}
+	copy(n.ptr, s.ptr)
+	copy(n.idx, s.idx)
+	copy(n.vals, s.vals)
+	return n
+}
+
+/*
+ToCSR returns a copy of a Sparsef64 in the CSR format.
+*/
+func (s *Sparsef64) ToCSR() *Sparsef64 {
+	return s.toFormat(CSR)
+}
+
+/*
+ToCSC returns a copy of a Sparsef64 in the CSC format.
+*/
+func (s *Sparsef64) ToCSC() *Sparsef64 {
+	return s.toFormat(CSC)
+}
+
+func (s *Sparsef64) toFormat(format SparseFormat) *Sparsef64 {
+	if s.format == format {
+		return s.Copy()
+	}
+	major, minor, vals := s.triplets()
+	return newSparsef64(s.r, s.c, format, minor, major, vals)
+}
+
+/*
+T returns the transpose of a Sparsef64. Since the transpose of a matrix in the
+CSR format has the same layout as the original in the CSC format (and vice
+versa), the returned Sparsef64 is in the other format, and the transpose is
+no more expensive than a copy.
+*/
+func (s *Sparsef64) T() *Sparsef64 {
+	n := s.Copy()
+	n.r, n.c = s.c, s.r
+	if s.format == CSR {
+		n.format = CSC
+	} else {
+		n.format = CSR
+	}
+	return n
+}
+
+/*
+Mul multiplies each element of a Sparsef64 by the passed float64. Multiplying
+by 0.0 removes all stored elements.
+*/
+func (s *Sparsef64) Mul(v float64) *Sparsef64 {
+	if v == 0.0 {
+		for i := range s.ptr {
+			s.ptr[i] = 0
+		}
+		s.idx = s.idx[:0]
+		s.vals = s.vals[:0]
+		return s
+	}
+	for i := range s.vals {
+		s.vals[i] *= v
+	}
+	return s
+}
+
+/*
+Add adds the passed Sparsef64 to the receiver, element by element. Both must
+have the same shape, but they may be in different formats, in which case the
+result is in the format of the receiver. Elements which cancel out to 0.0 are
+no longer stored.
+*/
+func (s *Sparsef64) Add(n *Sparsef64) *Sparsef64 {
+	if s.r != n.r || s.c != n.c {
+		str := "\nIn %s, the receiver is a %d by %d matrix, while the passed\n"
+		str += "matrix is %d by %d. They must have the same shape."
+		str = fmt.Sprintf(str, "Add()", s.r, s.c, n.r, n.c)
+		printErr(ErrShape, str)
+	}
+	if n.format != s.format {
+		n = n.toFormat(s.format)
+	}
+	ptr := make([]int, len(s.ptr))
+	idx := make([]int, 0, len(s.idx)+len(n.idx))
+	vals := make([]float64, 0, len(s.vals)+len(n.vals))
+	for i := 0; i+1 < len(s.ptr); i++ {
+		a, aEnd := s.ptr[i], s.ptr[i+1]
+		b, bEnd := n.ptr[i], n.ptr[i+1]
+		for a < aEnd || b < bEnd {
+			var j int
+			var v float64
+			switch {
+			case b == bEnd || (a < aEnd && s.idx[a] < n.idx[b]):
+				j, v = s.idx[a], s.vals[a]
+				a++
+			case a == aEnd || n.idx[b] < s.idx[a]:
+				j, v = n.idx[b], n.vals[b]
+				b++
+			default:
+				j, v = s.idx[a], s.vals[a]+n.vals[b]
+				a++
+				b++
+			}
+			if v != 0.0 {
+				idx = append(idx, j)
+				vals = append(vals, v)
+			}
+		}
+		ptr[i+1] = len(vals)
+	}
+	s.ptr, s.idx, s.vals = ptr, idx, vals
+	return s
+}
+
+/*
+Dot is the matrix multiplication of a Sparsef64 and a dense Matf64, returning
+a new dense Matf64. The number of columns of the receiver must be equal to the
+number of rows of the passed Matf64:
+
+	// adj is an n by n adjacency matrix, and features is an n by k Matf64
+	agg := adj.Dot(features)
+
+The cost of this method is proportional to the number of stored elements of
+the receiver times the number of columns of the passed Matf64, rather than to
+the full size of the receiver.
+*/
+func (s *Sparsef64) Dot(m *Matf64) *Matf64 {
+	if s.c != m.r {
+		str := "\nIn %s the number of columns of the first mat is %d\n"
+		str += "which is not equal to the number of rows of the second mat,\n"
+		str += "which is %d. They must be equal.\n"
+		str = fmt.Sprintf(str, "Dot()", s.c, m.r)
+		printErr(ErrShape, str)
+	}
+	o := Newf64(s.r, m.c)
+	for i := 0; i+1 < len(s.ptr); i++ {
+		for k := s.ptr[i]; k < s.ptr[i+1]; k++ {
+			row, col := i, s.idx[k]
+			if s.format == CSC {
+				row, col = col, row
+			}
+			v := s.vals[k]
+			out := o.vals[row*o.c : (row+1)*o.c]
+			in := m.vals[col*m.c : (col+1)*m.c]
+			for j := range out {
+				out[j] += v * in[j]
+			}
+		}
+	}
+	return o
+}
+
+/*
+DotSparse is the matrix multiplication of two Sparsef64s, returning a new
+Sparsef64 in the CSR format. The number of columns of the receiver must be
+equal to the number of rows of the passed Sparsef64. The cost of this method
+depends only on the number of stored elements of the two matrices, and the
+number of non-zero elements of the result.
+*/
+func (s *Sparsef64) DotSparse(n *Sparsef64) *Sparsef64 {
+	if s.c != n.r {
+		str := "\nIn %s the number of columns of the first mat is %d\n"
+		str += "which is not equal to the number of rows of the second mat,\n"
+		str += "which is %d. They must be equal.\n"
+		str = fmt.Sprintf(str, "DotSparse()", s.c, n.r)
+		printErr(ErrShape, str)
+	}
+	a, b := s, n
+	if a.format != CSR {
+		a = a.toFormat(CSR)
+	}
+	if b.format != CSR {
+		b = b.toFormat(CSR)
+	}
+	o := &Sparsef64{r: a.r, c: b.c, format: CSR, ptr: make([]int, a.r+1)}
+	// acc accumulates the current row of the result, and last[j] is the row
+	// which last wrote to acc[j], so that acc does not need to be cleared.
+	acc := make([]float64, b.c)
+	last := make([]int, b.c)
+	for j := range last {
+		last[j] = -1
+	}
+	var cols []int
+	for i := 0; i < a.r; i++ {
+		cols = cols[:0]
+		for k := a.ptr[i]; k < a.ptr[i+1]; k++ {
+			v, row := a.vals[k], a.idx[k]
+			for l := b.ptr[row]; l < b.ptr[row+1]; l++ {
+				j := b.idx[l]
+				if last[j] != i {
+					last[j] = i
+					acc[j] = 0.0
+					cols = append(cols, j)
+				}
+				acc[j] += v * b.vals[l]
+			}
+		}
+		sort.Ints(cols)
+		for _, j := range cols {
+			if acc[j] != 0.0 {
+				o.idx = append(o.idx, j)
+				o.vals = append(o.vals, acc[j])
+			}
+		}
+		o.ptr[i+1] = len(o.vals)
+	}
+	return o
+}
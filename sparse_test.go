@@ -0,0 +1,163 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSparsef64(t *testing.T) {
+	t.Helper()
+	rows := []int{1, 0, 2, 1, 0}
+	cols := []int{2, 0, 1, 2, 3}
+	vals := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
+	expected := Matf64FromData([]float64{
+		2, 0, 0, 5,
+		0, 0, 5, 0,
+		0, 3, 0, 0,
+	}, 3, 4)
+	for _, f := range []SparseFormat{CSR, CSC} {
+		s := NewSparsef64(3, 4, rows, cols, vals, f)
+		assert.Equal(t, f, s.Format(), "should be equal")
+		assert.Equal(t, 4, s.NNZ(), "duplicates should be summed")
+		assert.True(t, s.ToMatf64().Equals(expected), "should be equal")
+	}
+	s := NewSparsef64(3, 4, nil, nil, nil, CSR)
+	assert.Equal(t, 0, s.NNZ(), "should be empty")
+	assert.True(t, s.ToMatf64().Equals(Newf64(3, 4)), "should be all zeros")
+}
+
+func TestSparseFromMatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1e-15, 0, 3,
+		0, -4, 0,
+	}, 2, 3)
+	for _, f := range []SparseFormat{CSR, CSC} {
+		s := SparseFromMatf64(m, 0.0, f)
+		assert.Equal(t, 3, s.NNZ(), "should be equal")
+		assert.True(t, s.ToMatf64().Equals(m), "should be equal")
+		s = SparseFromMatf64(m, 1e-12, f)
+		assert.Equal(t, 2, s.NNZ(), "should drop small values")
+		assert.Equal(t, 0.0, s.Get(0, 0), "should be dropped")
+		assert.Equal(t, -4.0, s.Get(1, 1), "should be equal")
+	}
+	n := Matf64FromData([]float64{0, math.NaN(), 1e-15, 0}, 2, 2)
+	for _, f := range []SparseFormat{CSR, CSC} {
+		s := SparseFromMatf64(n, 1e-12, f)
+		assert.Equal(t, 1, s.NNZ(), "should keep the NaN")
+		assert.True(t, math.IsNaN(s.Get(0, 1)), "should be NaN")
+	}
+}
+
+func TestSparseGetf64(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(7, 5).Map(func(v *float64) {
+		if *v < 0.6 {
+			*v = 0.0
+		}
+	})
+	for _, f := range []SparseFormat{CSR, CSC} {
+		s := SparseFromMatf64(m, 0.0, f)
+		for i := 0; i < 7; i++ {
+			for j := 0; j < 5; j++ {
+				assert.Equal(t, m.Get(i, j), s.Get(i, j), "should be equal")
+			}
+		}
+	}
+}
+
+func TestSparseFormatsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{0, 1, 2, 0, 0, 3}, 3, 2)
+	s := SparseFromMatf64(m, 0.0, CSR)
+	c := s.ToCSC()
+	assert.Equal(t, CSC, c.Format(), "should be equal")
+	assert.True(t, c.ToMatf64().Equals(m), "should be equal")
+	r := c.ToCSR()
+	assert.Equal(t, CSR, r.Format(), "should be equal")
+	assert.Equal(t, s, r, "should be equal")
+	r.vals[0] = 100.0
+	assert.Equal(t, 1.0, s.vals[0], "should be a copy")
+}
+
+func TestSparseTf64(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(4, 6)
+	for _, f := range []SparseFormat{CSR, CSC} {
+		s := SparseFromMatf64(m, 0.5, f)
+		st := s.T()
+		assert.NotEqual(t, f, st.Format(), "should switch format")
+		assert.True(t, st.ToMatf64().Equals(s.ToMatf64().T()), "should be equal")
+	}
+}
+
+func TestSparseMulf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{0, 1, 2, 0}, 2, 2)
+	s := SparseFromMatf64(m, 0.0, CSR).Mul(3.0)
+	assert.True(t, s.ToMatf64().Equals(m.Copy().Mul(3.0)), "should be equal")
+	s.Mul(0.0)
+	assert.Equal(t, 0, s.NNZ(), "should be empty")
+	assert.True(t, s.ToMatf64().Equals(Newf64(2, 2)), "should be all zeros")
+}
+
+func TestSparseAddf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{1, 0, 2, 0, 0, 3}, 2, 3)
+	b := Matf64FromData([]float64{0, 4, -2, 0, 5, 0}, 2, 3)
+	for _, f := range []SparseFormat{CSR, CSC} {
+		for _, g := range []SparseFormat{CSR, CSC} {
+			s := SparseFromMatf64(a, 0.0, f).Add(SparseFromMatf64(b, 0.0, g))
+			assert.Equal(t, f, s.Format(), "should keep the receiver's format")
+			assert.Equal(t, 4, s.NNZ(), "cancelled values should be dropped")
+			assert.True(t, s.ToMatf64().Equals(a.Copy().Add(b)), "should be equal")
+		}
+	}
+}
+
+func TestSparseDotf64(t *testing.T) {
+	t.Helper()
+	a := RandMatf64(6, 4).Map(func(v *float64) {
+		if *v < 0.5 {
+			*v = 0.0
+		}
+	})
+	m := RandMatf64(4, 3)
+	expected := a.Dot(m)
+	for _, f := range []SparseFormat{CSR, CSC} {
+		o := SparseFromMatf64(a, 0.0, f).Dot(m)
+		assert.Equal(t, 6, o.r, "should be equal")
+		assert.Equal(t, 3, o.c, "should be equal")
+		for i := range o.vals {
+			assert.InDelta(t, expected.vals[i], o.vals[i], 1e-12, "should be equal")
+		}
+	}
+}
+
+func TestSparseDotSparsef64(t *testing.T) {
+	t.Helper()
+	sparsify := func(v *float64) {
+		if *v < 0.6 {
+			*v = 0.0
+		}
+	}
+	a := RandMatf64(5, 7).Map(sparsify)
+	b := RandMatf64(7, 4).Map(sparsify)
+	expected := a.Dot(b)
+	for _, f := range []SparseFormat{CSR, CSC} {
+		for _, g := range []SparseFormat{CSR, CSC} {
+			o := SparseFromMatf64(a, 0.0, f).DotSparse(SparseFromMatf64(b, 0.0, g))
+			assert.Equal(t, CSR, o.Format(), "should be equal")
+			d := o.ToMatf64()
+			for i := range d.vals {
+				assert.InDelta(t, expected.vals[i], d.vals[i], 1e-12, "should be equal")
+			}
+		}
+	}
+	// rows which cancel out should not be stored.
+	x := SparseFromMatf64(Matf64FromData([]float64{1, -1}, 1, 2), 0.0, CSR)
+	y := SparseFromMatf64(Matf64FromData([]float64{2, 2}, 2, 1), 0.0, CSR)
+	assert.Equal(t, 0, x.DotSparse(y).NNZ(), "should be empty")
+}
@@ -0,0 +1,62 @@
+package matrix
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+)
+
+/*
+Matf64FromSQLRows creates a Matf64 from the result set of a database query,
+and returns it along with the names of the columns of the result set. Each
+row of the result set becomes a row of the Matf64, and the columns of the
+Matf64 are in the same order as the returned column names:
+
+	rows, err := db.Query("SELECT price, volume FROM trades")
+	if err != nil {
+		log.Fatal(err)
+	}
+	m, names := matrix.Matf64FromSQLRows(rows)
+
+All columns of the result set must be numeric, or be convertible to a float64,
+such as strings containing numbers. NULL values are stored as NaN. The passed
+rows are read until exhausted, and closed before this function returns.
+*/
+func Matf64FromSQLRows(rows *sql.Rows) (*Matf64, []string) {
+	defer rows.Close()
+	names, err := rows.Columns()
+	if err != nil {
+		s := "\nIn matrix.%s, cannot read the columns due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromSQLRows()", err)
+		printErr(ErrIO, s)
+	}
+	m := Newf64()
+	m.c = len(names)
+	row := make([]sql.NullFloat64, len(names))
+	dest := make([]interface{}, len(names))
+	for i := range row {
+		dest[i] = &row[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			s := "\nIn matrix.%s, row %d cannot be converted to float64s\n"
+			s += "due to error: %v."
+			s = fmt.Sprintf(s, "Matf64FromSQLRows()", m.r, err)
+			printErr(ErrParse, s)
+		}
+		for i := range row {
+			if row[i].Valid {
+				m.vals = append(m.vals, row[i].Float64)
+			} else {
+				m.vals = append(m.vals, math.NaN())
+			}
+		}
+		m.r++
+	}
+	if err := rows.Err(); err != nil {
+		s := "\nIn matrix.%s, cannot read the rows due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromSQLRows()", err)
+		printErr(ErrIO, s)
+	}
+	return m, names
+}
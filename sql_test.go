@@ -0,0 +1,80 @@
+package matrix
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver is a minimal database/sql driver which answers every query with
+// the same fixed result set.
+type fakeDriver struct{}
+
+type fakeConn struct{}
+
+type fakeStmt struct{}
+
+type fakeRows struct {
+	idx int
+}
+
+var fakeRowsData = [][]driver.Value{
+	{1.0, int64(2), "3.5"},
+	{4.0, int64(5), nil},
+	{7.0, int64(8), []byte("9")},
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+func (fakeStmt) Close() error                                    { return nil }
+func (fakeStmt) NumInput() int                                   { return 0 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error)  { return &fakeRows{}, nil }
+
+func (*fakeRows) Columns() []string { return []string{"a", "b", "c"} }
+func (*fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.idx == len(fakeRowsData) {
+		return io.EOF
+	}
+	copy(dest, fakeRowsData[r.idx])
+	r.idx++
+	return nil
+}
+
+func init() {
+	sql.Register("matrixfake", fakeDriver{})
+}
+
+func TestMatf64FromSQLRows(t *testing.T) {
+	t.Helper()
+	db, err := sql.Open("matrixfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT a, b, c FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, names := Matf64FromSQLRows(rows)
+	assert.Equal(t, []string{"a", "b", "c"}, names, "should be equal")
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 3, m.c, "should be equal")
+	expected := []float64{1.0, 2.0, 3.5, 4.0, 5.0, 0.0, 7.0, 8.0, 9.0}
+	for i := range expected {
+		if i == 5 {
+			assert.True(t, math.IsNaN(m.vals[i]), "NULL should be NaN")
+			continue
+		}
+		assert.Equal(t, expected[i], m.vals[i], "should be equal")
+	}
+}
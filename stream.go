@@ -0,0 +1,132 @@
+package matrix
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// streamChunk is the number of elements which WriteTo and ReadFrom convert
+// at a time.
+const streamChunk = 512
+
+/*
+WriteTo writes a Matf64 to the passed io.Writer in the binary format of Save,
+without building the whole encoding in memory first, so that it can be
+written to a socket or a compressor directly:
+
+	zw := gzip.NewWriter(conn)
+	_, err := m.WriteTo(zw)
+
+It implements io.WriterTo, and returns the number of bytes written, and the
+first error from the writer, if any.
+*/
+func (m *Matf64) WriteTo(w io.Writer) (int64, error) {
+	h := crc32.NewIEEE()
+	out := io.MultiWriter(w, h)
+	var written int64
+	write := func(b []byte) error {
+		n, err := out.Write(b)
+		written += int64(n)
+		return err
+	}
+	b := make([]byte, 0, 8*streamChunk)
+	b = append(b, binaryMagic...)
+	b = binary.LittleEndian.AppendUint64(b, uint64(m.r))
+	b = binary.LittleEndian.AppendUint64(b, uint64(m.c))
+	for _, v := range m.vals[:m.r*m.c] {
+		if len(b)+8 > cap(b) {
+			if err := write(b); err != nil {
+				return written, err
+			}
+			b = b[:0]
+		}
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+	}
+	// The last chunk is written to w only, as it ends with the checksum,
+	// which the hash must not see.
+	h.Write(b)
+	b = binary.LittleEndian.AppendUint32(b, h.Sum32())
+	n, err := w.Write(b)
+	written += int64(n)
+	return written, err
+}
+
+/*
+ReadFrom reads a Matf64 in the binary format of Save from the passed
+io.Reader, and replaces the receiver with it, so that a Matf64 can be read
+from a socket or a decompressor without reading the whole stream first:
+
+	m := matrix.Newf64()
+	_, err := m.ReadFrom(gzipReader)
+
+It implements io.ReaderFrom, but unlike most implementations, it reads
+exactly one Matf64, and not up to the end of the stream, so that several can
+be read from one stream in turn. It returns the number of bytes read, and an
+error if the stream is not in the binary format, ends early, or does not
+match its checksum, in which case the receiver is not changed. The error is
+io.EOF if the stream ends before the first byte.
+*/
+func (m *Matf64) ReadFrom(r io.Reader) (int64, error) {
+	if m.readOnly {
+		return 0, errReadOnlyTarget
+	}
+	h := crc32.NewIEEE()
+	in := io.TeeReader(r, h)
+	var read int64
+	readFull := func(b []byte) error {
+		n, err := io.ReadFull(in, b)
+		read += int64(n)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	b := make([]byte, 8*streamChunk)
+	header := b[:binaryHeaderLen]
+	n, err := io.ReadFull(in, header)
+	read += int64(n)
+	if err != nil {
+		return read, err
+	}
+	if string(header[:len(binaryMagic)]) != binaryMagic {
+		return read, errors.New("not a stream written by WriteTo or Save")
+	}
+	rows := binary.LittleEndian.Uint64(header[len(binaryMagic):])
+	cols := binary.LittleEndian.Uint64(header[len(binaryMagic)+8:])
+	if rows > math.MaxInt32 || cols > math.MaxInt32 || rows*cols > math.MaxInt32 {
+		return read, fmt.Errorf("a %d by %d Matf64 is too large to read", rows, cols)
+	}
+	vals := make([]float64, rows*cols)
+	for i := 0; i < len(vals); i += streamChunk {
+		chunk := vals[i:]
+		if len(chunk) > streamChunk {
+			chunk = chunk[:streamChunk]
+		}
+		data := b[:8*len(chunk)]
+		if err := readFull(data); err != nil {
+			return read, err
+		}
+		for k := range chunk {
+			chunk[k] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*k:]))
+		}
+	}
+	got := h.Sum32()
+	sum := b[:4]
+	n, err = io.ReadFull(r, sum)
+	read += int64(n)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return read, err
+	}
+	if want := binary.LittleEndian.Uint32(sum); got != want {
+		return read, fmt.Errorf("the checksum is %08x, but %08x was expected, so the stream is corrupted", got, want)
+	}
+	m.r, m.c, m.vals = int(rows), int(cols), vals
+	return read, nil
+}
@@ -0,0 +1,46 @@
+package matrix
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTof64(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(37, 41)
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	assert.NoError(t, err, "should not fail")
+	assert.Equal(t, int64(buf.Len()), n, "should count the bytes")
+	assert.Equal(t, m.encodeBinary(), buf.Bytes(), "should be the format of Save")
+
+	// Two matrices in one stream are read in turn.
+	small := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	small.WriteTo(&buf)
+	total := int64(buf.Len())
+	got := Newf64()
+	read, err := got.ReadFrom(&buf)
+	assert.NoError(t, err, "should not fail")
+	assert.Equal(t, n, read, "should count the bytes")
+	assert.True(t, m.Equals(got), "should be equal")
+	read2, err := got.ReadFrom(&buf)
+	assert.NoError(t, err, "should not fail")
+	assert.Equal(t, total, read+read2, "should read exactly one Matf64")
+	assert.True(t, small.Equals(got), "should be equal")
+
+	_, err = got.ReadFrom(&buf)
+	assert.Equal(t, io.EOF, err, "should be at the end of the stream")
+
+	b := small.encodeBinary()
+	b[len(b)-6] ^= 1
+	_, err = got.ReadFrom(bytes.NewReader(b))
+	assert.Error(t, err, "should detect the corruption")
+	assert.True(t, small.Equals(got), "should not change the receiver")
+	_, err = got.ReadFrom(bytes.NewReader(b[:len(b)-10]))
+	assert.Equal(t, io.ErrUnexpectedEOF, err, "should be equal")
+	_, err = got.ReadFrom(bytes.NewReader([]byte("not a matrix at all, really")))
+	assert.Error(t, err, "should fail")
+}
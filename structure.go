@@ -0,0 +1,104 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+IsSymmetric returns true if a Matf64 is square, and each element differs from
+its mirror across the diagonal by at most tol:
+
+	if m.IsSymmetric(1e-12) {
+		a := matrix.SymMatf64FromMatf64(m)
+	}
+*/
+func (m *Matf64) IsSymmetric(tol float64) bool {
+	checkTol("IsSymmetric()", tol)
+	if m.r != m.c {
+		return false
+	}
+	for i := 0; i < m.r; i++ {
+		for j := i + 1; j < m.c; j++ {
+			if !(math.Abs(m.vals[i*m.c+j]-m.vals[j*m.c+i]) <= tol) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/*
+IsDiagonal returns true if a Matf64 is square, and all of its elements off the
+diagonal are at most tol in absolute value.
+*/
+func (m *Matf64) IsDiagonal(tol float64) bool {
+	checkTol("IsDiagonal()", tol)
+	if m.r != m.c {
+		return false
+	}
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if i != j && !(math.Abs(m.vals[i*m.c+j]) <= tol) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/*
+IsOrthogonal returns true if a Matf64 is square, and each element of the
+product of its transpose with itself differs from the identity matrix by at
+most tol. That is, its columns are orthonormal, and its inverse is its
+transpose.
+*/
+func (m *Matf64) IsOrthogonal(tol float64) bool {
+	checkTol("IsOrthogonal()", tol)
+	if m.r != m.c {
+		return false
+	}
+	n := m.c
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			dot := 0.0
+			for k := 0; k < n; k++ {
+				dot += m.vals[k*n+i] * m.vals[k*n+j]
+			}
+			if i == j {
+				dot -= 1.0
+			}
+			if !(math.Abs(dot) <= tol) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/*
+IsPosDef returns true if a Matf64 is symmetric, as checked by IsSymmetric with
+the passed tol, and positive definite. Positive definiteness is checked by
+attempting the factorization of Cholesky of its upper triangle, so a true
+result means that Cholesky of the SymMatf64 will succeed:
+
+	if m.IsPosDef(1e-12) {
+		l := matrix.SymMatf64FromMatf64(m).Cholesky()
+	}
+*/
+func (m *Matf64) IsPosDef(tol float64) bool {
+	if !m.IsSymmetric(tol) || m.r == 0 {
+		return false
+	}
+	_, row, _ := SymMatf64FromMatf64(m).cholesky()
+	return row < 0
+}
+
+func checkTol(fn string, tol float64) {
+	if !(tol >= 0) {
+		s := "\nIn %s, the tolerance must not be negative, however %v was\n"
+		s += "received."
+		s = fmt.Sprintf(s, fn, tol)
+		printHelperErr(ErrArgument, s)
+	}
+}
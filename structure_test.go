@@ -0,0 +1,64 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSymmetricf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 2, 3,
+		2, 4, 5,
+		3, 5 + 1e-10, 6,
+	}, 3, 3)
+	assert.True(t, m.IsSymmetric(1e-9), "should be symmetric")
+	assert.False(t, m.IsSymmetric(0), "should not be symmetric")
+	assert.False(t, Newf64(2, 3).IsSymmetric(1), "should not be symmetric")
+}
+
+func TestIsDiagonalf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		1, 1e-10,
+		0, 2,
+	}, 2, 2)
+	assert.True(t, m.IsDiagonal(1e-9), "should be diagonal")
+	assert.False(t, m.IsDiagonal(0), "should not be diagonal")
+	assert.True(t, Newf64(1).IsDiagonal(0), "should be diagonal")
+	assert.False(t, Newf64(1, 2).IsDiagonal(0), "should not be diagonal")
+}
+
+func TestIsOrthogonalf64(t *testing.T) {
+	t.Helper()
+	c, s := math.Cos(0.3), math.Sin(0.3)
+	m := Matf64FromData([]float64{
+		c, -s,
+		s, c,
+	}, 2, 2)
+	assert.True(t, m.IsOrthogonal(1e-12), "should be orthogonal")
+	assert.False(t, m.Mul(2.0).IsOrthogonal(1e-12), "should not be orthogonal")
+	assert.False(t, Newf64(2, 3).IsOrthogonal(1), "should not be orthogonal")
+}
+
+func TestIsPosDeff64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{
+		4, 2,
+		2, 3,
+	}, 2, 2)
+	assert.True(t, m.IsPosDef(0), "should be positive definite")
+	n := Matf64FromData([]float64{
+		1, 2,
+		2, 1,
+	}, 2, 2)
+	assert.False(t, n.IsPosDef(0), "should not be positive definite")
+	k := Matf64FromData([]float64{
+		4, 2,
+		1, 3,
+	}, 2, 2)
+	assert.False(t, k.IsPosDef(0), "should not be symmetric")
+	assert.False(t, Newf64(2).IsPosDef(0), "should not be positive definite")
+}
@@ -0,0 +1,159 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+SumMethod selects how SumWith, AvgWith and StdWith add up the elements of a
+Matf64.
+*/
+type SumMethod int
+
+const (
+	// SumNaive adds the elements one after the other, as Sum does. It is the
+	// fastest, but its error grows with the number of elements, and it loses
+	// small elements next to large ones.
+	SumNaive SumMethod = iota
+	// SumKahan adds the elements one after the other, and carries the
+	// rounding error of each addition into the next, by the variant of
+	// Neumaier, so that the error does not grow with the number of elements.
+	SumKahan
+	// SumPairwise adds the two halves of the elements separately, and then
+	// adds them, so that the error grows with the logarithm of the number of
+	// elements. It is nearly as fast as SumNaive.
+	SumPairwise
+)
+
+/*
+SumStable does the same as Sum, with compensated summation, which is the
+same as SumWith with SumKahan:
+
+	m.SumStable()     // the sum of all elements
+	m.SumStable(1, 0) // the sum of the first column
+*/
+func (m *Matf64) SumStable(args ...int) float64 {
+	return sumWith("SumStable()", m, SumKahan, args)
+}
+
+/*
+SumWith does the same as Sum, but adds the elements by the passed method.
+The arguments after the method select a row or a column in the same manner
+as for Sum:
+
+	m.SumWith(matrix.SumPairwise)
+	m.SumWith(matrix.SumKahan, 0, 2) // the sum of the 3rd row
+*/
+func (m *Matf64) SumWith(method SumMethod, args ...int) float64 {
+	return sumWith("SumWith()", m, method, args)
+}
+
+/*
+AvgWith does the same as Avg, but adds the elements by the passed method.
+*/
+func (m *Matf64) AvgWith(method SumMethod, args ...int) float64 {
+	vals, n, step := sumSlice("AvgWith()", m, method, args)
+	return sumStrided(vals, n, step, method) / float64(n)
+}
+
+/*
+StdWith does the same as Std, but adds the elements by the passed method,
+and corrects the mean for the rounding error of the deviations from it. The
+standard deviation of a row or a column is divided by the number of its
+elements.
+*/
+func (m *Matf64) StdWith(method SumMethod, args ...int) float64 {
+	vals, n, step := sumSlice("StdWith()", m, method, args)
+	mean := sumStrided(vals, n, step, method) / float64(n)
+	dev := make([]float64, n)
+	sq := make([]float64, n)
+	for i := range dev {
+		dev[i] = vals[i*step] - mean
+		sq[i] = dev[i] * dev[i]
+	}
+	// By the corrected two pass algorithm, the sum of the deviations, which
+	// is zero in exact arithmetic, removes the error of the mean.
+	d := sumStrided(dev, n, 1, method)
+	v := (sumStrided(sq, n, 1, method) - d*d/float64(n)) / float64(n)
+	return math.Sqrt(math.Max(v, 0))
+}
+
+func sumWith(fn string, m *Matf64, method SumMethod, args []int) float64 {
+	vals, n, step := sumSlice(fn, m, method, args)
+	return sumStrided(vals, n, step, method)
+}
+
+// sumSlice checks the method and the arguments of the methods above, and
+// returns the elements they select as n elements of vals, step apart.
+func sumSlice(fn string, m *Matf64, method SumMethod, args []int) ([]float64, int, int) {
+	if method < SumNaive || method > SumPairwise {
+		s := "\nIn %s, %d is not a known SumMethod."
+		s = fmt.Sprintf(s, fn, int(method))
+		printHelperErr(ErrArgument, s)
+	}
+	switch len(args) {
+	case 0:
+		return m.vals, len(m.vals), 1
+	case 2:
+		axis, slice := args[0], args[1]
+		switch axis {
+		case 0:
+			if (slice >= m.r) || (slice < 0) {
+				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
+				s = fmt.Sprintf(s, fn, slice, m.r)
+				printHelperErr(&ErrIndexOOB{fn, 0, slice, m.r}, s)
+			}
+			return m.vals[slice*m.c:], m.c, 1
+		case 1:
+			if (slice >= m.c) || (slice < 0) {
+				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
+				s = fmt.Sprintf(s, fn, slice, m.c)
+				printHelperErr(&ErrIndexOOB{fn, 1, slice, m.c}, s)
+			}
+			return m.vals[slice:], m.r, m.c
+		}
+		s := "\nIn %s, the first argument must be 0 or 1, however %d "
+		s += "was received.\n"
+		s = fmt.Sprintf(s, fn, axis)
+		printHelperErr(ErrArgument, s)
+	default:
+		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
+		s = fmt.Sprintf(s, fn, len(args))
+		printHelperErr(ErrArgument, s)
+	}
+	return nil, 0, 0
+}
+
+// pairwiseBlock is the number of elements below which sumStrided with
+// SumPairwise adds them naively.
+const pairwiseBlock = 128
+
+// sumStrided adds up the n elements of vals which are step apart, by the
+// passed method.
+func sumStrided(vals []float64, n, step int, method SumMethod) float64 {
+	switch {
+	case method == SumKahan:
+		sum, c := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			v := vals[i*step]
+			t := sum + v
+			if math.Abs(sum) >= math.Abs(v) {
+				c += (sum - t) + v
+			} else {
+				c += (v - t) + sum
+			}
+			sum = t
+		}
+		return sum + c
+	case method == SumPairwise && n > pairwiseBlock:
+		h := n / 2
+		return sumStrided(vals, h, step, method) +
+			sumStrided(vals[h*step:], n-h, step, method)
+	}
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += vals[i*step]
+	}
+	return sum
+}
@@ -0,0 +1,45 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumWithf64(t *testing.T) {
+	t.Helper()
+	// 1 followed by many elements which are each lost when added to it.
+	n := 10000
+	m := Newf64(1, n+1).SetAll(1e-16)
+	m.Set(0, 0, 1)
+	want := 1 + float64(n)*1e-16
+	assert.Equal(t, 1.0, m.Sum(), "should lose the small elements")
+	assert.InDelta(t, want, m.SumStable(), 1e-15, "should be close")
+	assert.InDelta(t, want, m.SumWith(SumKahan), 1e-15, "should be close")
+	assert.InDelta(t, want, m.SumWith(SumPairwise), 1e-13, "should be close")
+	assert.Equal(t, m.Sum(), m.SumWith(SumNaive), "should be equal")
+
+	a := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	for _, method := range []SumMethod{SumNaive, SumKahan, SumPairwise} {
+		assert.Equal(t, 21.0, a.SumWith(method), "should be equal")
+		assert.Equal(t, 15.0, a.SumWith(method, 0, 1), "should be equal")
+		assert.Equal(t, 9.0, a.SumWith(method, 1, 2), "should be equal")
+		assert.Equal(t, 3.5, a.AvgWith(method), "should be equal")
+		assert.Equal(t, 4.5, a.AvgWith(method, 1, 2), "should be equal")
+		assert.InDelta(t, a.Std(), a.StdWith(method), 1e-12, "should be equal")
+		assert.InDelta(t, 1.5, a.StdWith(method, 1, 0), 1e-12, "should be equal")
+	}
+
+	// A large offset ruins the naive standard deviation.
+	b := Newf64(1, 4)
+	for i, v := range []float64{4, 7, 13, 16} {
+		b.Set(0, i, 1e9+v)
+	}
+	assert.InDelta(t, 4.743416490252569, b.StdWith(SumKahan), 1e-6, "should be close")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { a.SumWith(SumMethod(7)) }, "should panic")
+	assert.Panics(t, func() { a.SumWith(SumKahan, 0, 2) }, "should panic")
+	assert.Panics(t, func() { a.AvgWith(SumKahan, 2, 0) }, "should panic")
+	assert.Panics(t, func() { a.StdWith(SumKahan, 1) }, "should panic")
+}
@@ -0,0 +1,292 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+SymMatf64 is an n by n symmetric matrix of float64s, such as a covariance or
+a kernel matrix. Only the upper triangle is stored, which takes n(n+1)/2
+float64s instead of the n*n used by a Matf64 of the same size. The upper
+triangle is packed column by column, so that element (i, j) with i <= j is
+stored at index i + j(j+1)/2, which is the layout used by LAPACK.
+
+Setting element (i, j) also sets element (j, i), so a SymMatf64 is always
+symmetric. As with Matf64, the fields of this struct are not directly
+accessible.
+*/
+type SymMatf64 struct {
+	n    int
+	vals []float64
+}
+
+/*
+NewSymMatf64 returns an n by n SymMatf64 whose elements are all zero.
+*/
+func NewSymMatf64(n int) *SymMatf64 {
+	if n < 0 {
+		s := "\nIn matrix.%s, the size cannot be negative, however %d was\n"
+		s += "received."
+		s = fmt.Sprintf(s, "NewSymMatf64()", n)
+		printErr(ErrArgument, s)
+	}
+	return &SymMatf64{n, make([]float64, n*(n+1)/2)}
+}
+
+/*
+SymMatf64FromMatf64 returns a SymMatf64 holding the upper triangle of the
+passed Matf64, which must be square. The lower triangle of the Matf64 is
+ignored, and is not checked for symmetry.
+*/
+func SymMatf64FromMatf64(m *Matf64) *SymMatf64 {
+	if m.r != m.c {
+		s := "\nIn matrix.%s, the passed Matf64 is %d by %d. It must be square."
+		s = fmt.Sprintf(s, "SymMatf64FromMatf64()", m.r, m.c)
+		printErr(ErrShape, s)
+	}
+	a := NewSymMatf64(m.r)
+	for j := 0; j < a.n; j++ {
+		for i := 0; i <= j; i++ {
+			a.vals[i+j*(j+1)/2] = m.vals[i*m.c+j]
+		}
+	}
+	return a
+}
+
+// idx returns the index of element (i, j) in the packed storage.
+func (a *SymMatf64) idx(i, j int) int {
+	if i > j {
+		i, j = j, i
+	}
+	return i + j*(j+1)/2
+}
+
+/*
+Size returns the number of rows, which is also the number of columns, of a
+SymMatf64.
+*/
+func (a *SymMatf64) Size() int {
+	return a.n
+}
+
+/*
+Get returns the element of a SymMatf64 at the given row and column.
+*/
+func (a *SymMatf64) Get(r, c int) float64 {
+	return a.vals[a.idx(r, c)]
+}
+
+/*
+Set sets the element of a SymMatf64 at the given row and column, and the
+element at the transposed position, to the passed value.
+*/
+func (a *SymMatf64) Set(r, c int, val float64) *SymMatf64 {
+	a.vals[a.idx(r, c)] = val
+	return a
+}
+
+/*
+ToMatf64 returns a Matf64 holding all the elements of a SymMatf64.
+*/
+func (a *SymMatf64) ToMatf64() *Matf64 {
+	m := Newf64(a.n, a.n)
+	for j := 0; j < a.n; j++ {
+		for i := 0; i <= j; i++ {
+			v := a.vals[i+j*(j+1)/2]
+			m.vals[i*a.n+j] = v
+			m.vals[j*a.n+i] = v
+		}
+	}
+	return m
+}
+
+/*
+Copy returns a duplicate of a SymMatf64, which shares no data with the
+original.
+*/
+func (a *SymMatf64) Copy() *SymMatf64 {
+	b := NewSymMatf64(a.n)
+	copy(b.vals, a.vals)
+	return b
+}
+
+/*
+Dot returns the product of a SymMatf64 and the passed Matf64, which must have
+as many rows as the SymMatf64 has columns. Each stored element is read once,
+and applied to both of the positions it represents.
+*/
+func (a *SymMatf64) Dot(m *Matf64) *Matf64 {
+	if a.n != m.r {
+		s := "\nIn %s the number of columns of the SymMatf64 is %d\n"
+		s += "which is not equal to the number of rows of the Matf64, which\n"
+		s += "is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Dot()", a.n, m.r)
+		printErr(ErrShape, s)
+	}
+	o := Newf64(a.n, m.c)
+	for j := 0; j < a.n; j++ {
+		mj := m.vals[j*m.c : (j+1)*m.c]
+		oj := o.vals[j*o.c : (j+1)*o.c]
+		for i := 0; i <= j; i++ {
+			v := a.vals[i+j*(j+1)/2]
+			mi := m.vals[i*m.c : (i+1)*m.c]
+			oi := o.vals[i*o.c : (i+1)*o.c]
+			for k := range oi {
+				oi[k] += v * mj[k]
+			}
+			if i != j {
+				for k := range oj {
+					oj[k] += v * mi[k]
+				}
+			}
+		}
+	}
+	return o
+}
+
+// jacobiMaxSweeps is the number of sweeps after which Eigen gives up. The
+// method converges quadratically, so a few sweeps are enough in practice.
+const jacobiMaxSweeps = 100
+
+/*
+Eigen returns the eigenvalues of a SymMatf64 as a row vector, in ascending
+order, and the corresponding eigenvectors as the columns of a Matf64:
+
+	vals, vecs := a.Eigen()
+	// a.Dot(vecs.Col(i)) is equal to vecs.Col(i).Mul(vals.Get(0, i))
+
+The eigenvalues of a symmetric matrix are real, and its eigenvectors are
+orthonormal. They are computed with the cyclic Jacobi method, which fails
+with ErrNoConvergence if the off-diagonal elements are not negligible after
+100 sweeps, as happens when the receiver holds a NaN or an Inf.
+*/
+func (a *SymMatf64) Eigen() (*Matf64, *Matf64) {
+	n := a.n
+	w := a.ToMatf64().vals
+	v := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1.0
+	}
+	for sweep := 0; ; sweep++ {
+		off := 0.0
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += w[p*n+q] * w[p*n+q]
+			}
+		}
+		if off == 0.0 || math.Sqrt(off) < 1e-15*frobeniusSym(w) {
+			break
+		}
+		if sweep == jacobiMaxSweeps {
+			s := "\nIn %s, the off-diagonal elements did not vanish in %d sweeps,\n"
+			s += "as their norm is still %v."
+			s = fmt.Sprintf(s, "Eigen()", jacobiMaxSweeps, math.Sqrt(off))
+			printErr(ErrNoConvergence, s)
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if w[p*n+q] == 0.0 {
+					continue
+				}
+				// Rotate rows and columns p and q, such that w[p][q] becomes zero.
+				theta := (w[q*n+q] - w[p*n+p]) / (2 * w[p*n+q])
+				t := 1.0 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1.0 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < n; k++ {
+					wkp, wkq := w[k*n+p], w[k*n+q]
+					w[k*n+p] = c*wkp - s*wkq
+					w[k*n+q] = s*wkp + c*wkq
+				}
+				for k := 0; k < n; k++ {
+					wpk, wqk := w[p*n+k], w[q*n+k]
+					w[p*n+k] = c*wpk - s*wqk
+					w[q*n+k] = s*wpk + c*wqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k*n+p], v[k*n+q]
+					v[k*n+p] = c*vkp - s*vkq
+					v[k*n+q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return w[order[i]*n+order[i]] < w[order[j]*n+order[j]]
+	})
+	vals := Newf64(1, n)
+	vecs := Newf64(n, n)
+	for j, k := range order {
+		vals.vals[j] = w[k*n+k]
+		for i := 0; i < n; i++ {
+			vecs.vals[i*n+j] = v[i*n+k]
+		}
+	}
+	return vals, vecs
+}
+
+func frobeniusSym(w []float64) float64 {
+	sum := 0.0
+	for _, x := range w {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
+
+/*
+Cholesky returns the lower triangular TriMatf64 L, such that L.Dot(L.T()) is
+equal to the receiver. The receiver must be positive definite, or the call
+fails with an *ErrNotPositiveDefinite. The factor can be used to solve linear
+systems with the receiver:
+
+	l := a.Cholesky()
+	x := l.T().Solve(l.Solve(b)) // a.Dot(x) is equal to b
+*/
+func (a *SymMatf64) Cholesky() *TriMatf64 {
+	l, row, pivot := a.cholesky()
+	if row >= 0 {
+		s := "\nIn %s, the receiver is not positive definite, as the\n"
+		s += "pivot of row %d is %v."
+		s = fmt.Sprintf(s, "Cholesky()", row, pivot)
+		printErr(&ErrNotPositiveDefinite{"Cholesky()", row, pivot}, s)
+	}
+	return l
+}
+
+// cholesky does the factorization of Cholesky. If the receiver is not
+// positive definite, it stops at the first row with a pivot which is not
+// positive, and returns that row and pivot. Otherwise the row is -1.
+func (a *SymMatf64) cholesky() (*TriMatf64, int, float64) {
+	n := a.n
+	l := NewTriMatf64(n, LowerTri)
+	for j := 0; j < n; j++ {
+		rj := l.vals[j*(j+1)/2:]
+		d := a.vals[j+j*(j+1)/2]
+		for k := 0; k < j; k++ {
+			d -= rj[k] * rj[k]
+		}
+		if !(d > 0.0) {
+			return l, j, d
+		}
+		d = math.Sqrt(d)
+		rj[j] = d
+		for i := j + 1; i < n; i++ {
+			ri := l.vals[i*(i+1)/2:]
+			sum := a.vals[j+i*(i+1)/2]
+			for k := 0; k < j; k++ {
+				sum -= ri[k] * rj[k]
+			}
+			ri[j] = sum / d
+		}
+	}
+	return l, -1, 0
+}
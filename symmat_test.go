@@ -0,0 +1,106 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSymMatf64(t *testing.T) {
+	t.Helper()
+	a := NewSymMatf64(4)
+	assert.Equal(t, 4, a.Size(), "should be equal")
+	assert.Equal(t, 10, len(a.vals), "should store only the upper triangle")
+}
+
+func TestSymMatf64GetSet(t *testing.T) {
+	t.Helper()
+	a := NewSymMatf64(3)
+	a.Set(2, 0, 5.0)
+	assert.Equal(t, 5.0, a.Get(0, 2), "should be symmetric")
+	assert.Equal(t, 5.0, a.Get(2, 0), "should be equal")
+}
+
+func TestSymMatf64FromMatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{4, 1, 2, 1, 3, 0, 2, 0, 5}, 3, 3)
+	a := SymMatf64FromMatf64(m)
+	assert.True(t, a.ToMatf64().Equals(m), "should be equal")
+	b := a.Copy()
+	b.Set(0, 0, 10.0)
+	assert.Equal(t, 4.0, a.Get(0, 0), "should be a copy")
+}
+
+func TestSymMatf64Dot(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{4, 1, 2, 1, 3, 0, 2, 0, 5}, 3, 3)
+	a := SymMatf64FromMatf64(m)
+	n := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 3, 2)
+	assert.True(t, a.Dot(n).Equals(m.Dot(n)), "should be equal")
+}
+
+func TestSymMatf64Eigen(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{4, 1, 2, 1, 3, 0, 2, 0, 5}, 3, 3)
+	a := SymMatf64FromMatf64(m)
+	vals, vecs := a.Eigen()
+	for i := 1; i < 3; i++ {
+		assert.True(t, vals.Get(0, i-1) <= vals.Get(0, i), "should be ascending")
+	}
+	for i := 0; i < 3; i++ {
+		v := vecs.Col(i)
+		av := m.Dot(v).ToSlice1D()
+		lv := v.Copy().Mul(vals.Get(0, i)).ToSlice1D()
+		for k := range av {
+			assert.InDelta(t, lv[k], av[k], 1e-10, "should be an eigenpair")
+		}
+	}
+	id := vecs.T().Dot(vecs)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			want := 0.0
+			if i == j {
+				want = 1.0
+			}
+			assert.InDelta(t, want, id.Get(i, j), 1e-10, "should be orthonormal")
+		}
+	}
+
+	m.Set(0, 2, math.NaN()).Set(2, 0, math.NaN())
+	err := Catch(func() { SymMatf64FromMatf64(m).Eigen() })
+	assert.True(t, errors.Is(err, ErrNoConvergence), "should not converge")
+}
+
+func TestSymMatf64Cholesky(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{4, 2, 2, 2, 5, 3, 2, 3, 6}, 3, 3)
+	l := SymMatf64FromMatf64(m).Cholesky()
+	assert.Equal(t, LowerTri, l.Kind(), "should be lower triangular")
+	got := l.Dot(l.T().ToMatf64()).ToSlice1D()
+	for k, v := range m.ToSlice1D() {
+		assert.InDelta(t, v, got[k], 1e-12, "should be equal")
+	}
+
+	defer SetPanicMode(SetPanicMode(true))
+	n := Matf64FromData([]float64{4, 2, 2, math.NaN()}, 2, 2)
+	assert.Panics(t, func() { SymMatf64FromMatf64(n).Cholesky() }, "a NaN pivot is not positive")
+	n = Matf64FromData([]float64{1, 2, 2, 1}, 2, 2)
+	err := Catch(func() { SymMatf64FromMatf64(n).Cholesky() })
+	var npd *ErrNotPositiveDefinite
+	assert.True(t, errors.As(err, &npd), "should wrap an *ErrNotPositiveDefinite")
+	assert.Equal(t, ErrNotPositiveDefinite{"Cholesky()", 1, -3}, *npd, "should hold the pivot")
+	assert.True(t, errors.Is(err, ErrArgument), "should be an argument error")
+}
+
+func TestSymMatf64CholeskySolve(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{4, 2, 2, 2, 5, 3, 2, 3, 6}, 3, 3)
+	b := Matf64FromData([]float64{1, 2, 3}, 3, 1)
+	l := SymMatf64FromMatf64(m).Cholesky()
+	got := m.Dot(l.T().Solve(l.Solve(b))).ToSlice1D()
+	for k, v := range b.ToSlice1D() {
+		assert.InDelta(t, v, got[k], 1e-12, "should solve the system")
+	}
+}
@@ -0,0 +1,177 @@
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+TableOptions controls how ToMarkdown and ToLaTeX format a Matf64.
+
+Precision is the number of digits printed after the decimal point of each
+element. When Precision is zero, the smallest number of digits necessary to
+represent each element exactly is used instead.
+
+RowLabels and ColLabels are optional labels for the rows and columns of the
+table. When they are set, their length must match the number of rows and
+columns of the Matf64, respectively.
+*/
+type TableOptions struct {
+	Precision int
+	RowLabels []string
+	ColLabels []string
+}
+
+/*
+ToMarkdown returns a markdown (GitHub flavored) table of the receiver. For
+example:
+
+	m := matrix.Matf64FromData([]float64{1.0, 2.5, 3.0, 4.25}, 2, 2)
+	fmt.Println(m.ToMarkdown(matrix.TableOptions{
+		Precision: 2,
+		RowLabels: []string{"x", "y"},
+		ColLabels: []string{"min", "max"},
+	}))
+
+prints:
+
+	|   | min | max |
+	|---|---:|---:|
+	| x | 1.00 | 2.50 |
+	| y | 3.00 | 4.25 |
+
+Since markdown tables require a header, the header cells are left empty
+when no column labels are passed.
+*/
+func (m *Matf64) ToMarkdown(opts TableOptions) string {
+	m.checkTableOptions("ToMarkdown()", opts)
+	var b strings.Builder
+	b.WriteString("|")
+	if opts.RowLabels != nil {
+		b.WriteString("   |")
+	}
+	for j := 0; j < m.c; j++ {
+		b.WriteString(" ")
+		if opts.ColLabels != nil {
+			b.WriteString(escapeMarkdown(opts.ColLabels[j]))
+		}
+		b.WriteString(" |")
+	}
+	b.WriteString("\n|")
+	if opts.RowLabels != nil {
+		b.WriteString("---|")
+	}
+	for j := 0; j < m.c; j++ {
+		b.WriteString("---:|")
+	}
+	for i := 0; i < m.r; i++ {
+		b.WriteString("\n|")
+		if opts.RowLabels != nil {
+			b.WriteString(" " + escapeMarkdown(opts.RowLabels[i]) + " |")
+		}
+		for j := 0; j < m.c; j++ {
+			b.WriteString(" " + formatTableCell(m.vals[i*m.c+j], opts.Precision) + " |")
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+/*
+ToLaTeX returns a LaTeX tabular environment containing the receiver. For
+example:
+
+	m := matrix.Matf64FromData([]float64{1.0, 2.5, 3.0, 4.25}, 2, 2)
+	fmt.Println(m.ToLaTeX(matrix.TableOptions{
+		Precision: 2,
+		RowLabels: []string{"x", "y"},
+		ColLabels: []string{"min", "max"},
+	}))
+
+prints:
+
+	\begin{tabular}{l|rr}
+	 & min & max \\
+	\hline
+	x & 1.00 & 2.50 \\
+	y & 3.00 & 4.25 \\
+	\end{tabular}
+
+The characters of the labels which have special meaning in LaTeX, such as
+"_" and "%", are escaped.
+*/
+func (m *Matf64) ToLaTeX(opts TableOptions) string {
+	m.checkTableOptions("ToLaTeX()", opts)
+	var b strings.Builder
+	b.WriteString("\\begin{tabular}{")
+	if opts.RowLabels != nil {
+		b.WriteString("l|")
+	}
+	b.WriteString(strings.Repeat("r", m.c) + "}\n")
+	if opts.ColLabels != nil {
+		cells := make([]string, 0, m.c+1)
+		if opts.RowLabels != nil {
+			cells = append(cells, "")
+		}
+		for j := range opts.ColLabels {
+			cells = append(cells, escapeLaTeX(opts.ColLabels[j]))
+		}
+		b.WriteString(strings.Join(cells, " & ") + " \\\\\n\\hline\n")
+	}
+	for i := 0; i < m.r; i++ {
+		cells := make([]string, 0, m.c+1)
+		if opts.RowLabels != nil {
+			cells = append(cells, escapeLaTeX(opts.RowLabels[i]))
+		}
+		for j := 0; j < m.c; j++ {
+			cells = append(cells, formatTableCell(m.vals[i*m.c+j], opts.Precision))
+		}
+		b.WriteString(strings.Join(cells, " & ") + " \\\\\n")
+	}
+	b.WriteString("\\end{tabular}\n")
+	return b.String()
+}
+
+func (m *Matf64) checkTableOptions(fn string, opts TableOptions) {
+	if opts.RowLabels != nil && len(opts.RowLabels) != m.r {
+		s := "\nIn %s, the number of row labels is %d, which does not\n"
+		s += "match the number of rows in the receiver, %d."
+		s = fmt.Sprintf(s, fn, len(opts.RowLabels), m.r)
+		printHelperErr(ErrShape, s)
+	}
+	if opts.ColLabels != nil && len(opts.ColLabels) != m.c {
+		s := "\nIn %s, the number of column labels is %d, which does not\n"
+		s += "match the number of columns in the receiver, %d."
+		s = fmt.Sprintf(s, fn, len(opts.ColLabels), m.c)
+		printHelperErr(ErrShape, s)
+	}
+}
+
+func formatTableCell(v float64, precision int) string {
+	if precision == 0 {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(v, 'f', precision, 64)
+}
+
+func escapeMarkdown(s string) string {
+	return strings.Replace(s, "|", "\\|", -1)
+}
+
+var latexEscaper = strings.NewReplacer(
+	"\\", "\\textbackslash{}",
+	"&", "\\&",
+	"%", "\\%",
+	"$", "\\$",
+	"#", "\\#",
+	"_", "\\_",
+	"{", "\\{",
+	"}", "\\}",
+	"~", "\\textasciitilde{}",
+	"^", "\\textasciicircum{}",
+)
+
+func escapeLaTeX(s string) string {
+	return latexEscaper.Replace(s)
+}
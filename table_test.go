@@ -0,0 +1,57 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToMarkdownf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1.0, 2.5, 3.0, 4.25}, 2, 2)
+	expected := "|   | min | max |\n"
+	expected += "|---|---:|---:|\n"
+	expected += "| x | 1.00 | 2.50 |\n"
+	expected += "| y | 3.00 | 4.25 |\n"
+	s := m.ToMarkdown(TableOptions{
+		Precision: 2,
+		RowLabels: []string{"x", "y"},
+		ColLabels: []string{"min", "max"},
+	})
+	assert.Equal(t, expected, s, "should be equal")
+
+	expected = "|  |  |\n"
+	expected += "|---:|---:|\n"
+	expected += "| 1 | 2.5 |\n"
+	expected += "| 3 | 4.25 |\n"
+	assert.Equal(t, expected, m.ToMarkdown(TableOptions{}), "should be equal")
+
+	s = Newf64(1, 1).ToMarkdown(TableOptions{ColLabels: []string{"a|b"}})
+	assert.Equal(t, "| a\\|b |\n|---:|\n| 0 |\n", s, "should be escaped")
+}
+
+func TestToLaTeXf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1.0, 2.5, 3.0, 4.25}, 2, 2)
+	expected := "\\begin{tabular}{l|rr}\n"
+	expected += " & min & max \\\\\n"
+	expected += "\\hline\n"
+	expected += "x & 1.00 & 2.50 \\\\\n"
+	expected += "y & 3.00 & 4.25 \\\\\n"
+	expected += "\\end{tabular}\n"
+	s := m.ToLaTeX(TableOptions{
+		Precision: 2,
+		RowLabels: []string{"x", "y"},
+		ColLabels: []string{"min", "max"},
+	})
+	assert.Equal(t, expected, s, "should be equal")
+
+	expected = "\\begin{tabular}{rr}\n"
+	expected += "1 & 2.5 \\\\\n"
+	expected += "3 & 4.25 \\\\\n"
+	expected += "\\end{tabular}\n"
+	assert.Equal(t, expected, m.ToLaTeX(TableOptions{}), "should be equal")
+
+	s = Newf64(1, 1).ToLaTeX(TableOptions{ColLabels: []string{"a_b%"}})
+	assert.Equal(t, "\\begin{tabular}{r}\na\\_b\\% \\\\\n\\hline\n0 \\\\\n\\end{tabular}\n", s, "should be escaped")
+}
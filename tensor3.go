@@ -0,0 +1,269 @@
+package matrix
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/*
+Tensor3f64 is a 3D array of float64s, with a depth, a number of rows and a
+number of columns. It can be thought of as a stack of Matf64s of the same
+shape, such as the frames of a video, the panels of a set of time series, or
+the samples of a mini-batch. As with Matf64, the elements are stored in a
+single flat slice, where the slice at depth k occupies a contiguous block of
+rows*cols elements.
+
+The fields of this struct are not directly accessible, and they may only
+change by the use of the various methods in this library.
+*/
+type Tensor3f64 struct {
+	d, r, c int
+	vals    []float64
+}
+
+/*
+NewTensor3f64 returns a d by r by c Tensor3f64 whose elements are all zero.
+*/
+func NewTensor3f64(d, r, c int) *Tensor3f64 {
+	if d < 0 || r < 0 || c < 0 {
+		s := "\nIn matrix.%s, the dimensions cannot be negative, however\n"
+		s += "%d, %d and %d were received."
+		s = fmt.Sprintf(s, "NewTensor3f64()", d, r, c)
+		printErr(ErrArgument, s)
+	}
+	return &Tensor3f64{d, r, c, make([]float64, d*r*c)}
+}
+
+/*
+Tensor3f64FromMats stacks the passed Matf64s into a Tensor3f64, where the
+slice at depth k is a copy of the k-th Matf64. All of the passed Matf64s must
+have the same shape, and at least one must be passed.
+*/
+func Tensor3f64FromMats(mats ...*Matf64) *Tensor3f64 {
+	if len(mats) == 0 {
+		s := "\nIn matrix.%s, at least one Matf64 must be passed."
+		s = fmt.Sprintf(s, "Tensor3f64FromMats()")
+		printErr(ErrArgument, s)
+	}
+	t := NewTensor3f64(len(mats), mats[0].r, mats[0].c)
+	for k, m := range mats {
+		if m.r != t.r || m.c != t.c {
+			s := "\nIn matrix.%s, Matf64 %d is %d by %d, while the first Matf64\n"
+			s += "is %d by %d. They must all have the same shape."
+			s = fmt.Sprintf(s, "Tensor3f64FromMats()", k, m.r, m.c, t.r, t.c)
+			printErr(ErrShape, s)
+		}
+		copy(t.vals[k*t.r*t.c:], m.vals[:m.r*m.c])
+	}
+	return t
+}
+
+/*
+Shape returns the depth, number of rows, and number of columns of a
+Tensor3f64.
+*/
+func (t *Tensor3f64) Shape() (int, int, int) {
+	return t.d, t.r, t.c
+}
+
+/*
+Get returns the element of a Tensor3f64 at the given depth, row and column.
+*/
+func (t *Tensor3f64) Get(k, r, c int) float64 {
+	return t.vals[(k*t.r+r)*t.c+c]
+}
+
+/*
+Set sets the element of a Tensor3f64 at the given depth, row and column.
+*/
+func (t *Tensor3f64) Set(k, r, c int, val float64) *Tensor3f64 {
+	t.vals[(k*t.r+r)*t.c+c] = val
+	return t
+}
+
+func (t *Tensor3f64) checkDepth(fn string, k int) {
+	if k < 0 || k >= t.d {
+		s := "\nIn %s, depth %d is outside of the bounds [0, %d)\n"
+		s = fmt.Sprintf(s, fn, k, t.d)
+		printHelperErr(ErrBounds, s)
+	}
+}
+
+/*
+Slice returns the Matf64 at the passed depth of a Tensor3f64. The returned
+Matf64 shares its data with the Tensor3f64, so that changing the elements of
+one changes the other:
+
+	t := matrix.NewTensor3f64(10, 28, 28)
+	t.Slice(3).SetAll(1.0) // sets all elements at depth 3 of t to 1.0
+
+Methods which change the shape of the returned Matf64, such as AppendRow,
+allocate new storage for it, after which it no longer shares its data with
+the Tensor3f64.
+*/
+func (t *Tensor3f64) Slice(k int) *Matf64 {
+	t.checkDepth("Slice()", k)
+	n := t.r * t.c
+	return &Matf64{r: t.r, c: t.c, vals: t.vals[k*n : (k+1)*n : (k+1)*n]}
+}
+
+/*
+SetSlice copies the elements of the passed Matf64 to the passed depth of a
+Tensor3f64. The Matf64 must have the same number of rows and columns as the
+Tensor3f64.
+*/
+func (t *Tensor3f64) SetSlice(k int, m *Matf64) *Tensor3f64 {
+	t.checkDepth("SetSlice()", k)
+	if m.r != t.r || m.c != t.c {
+		s := "\nIn %s, the passed Matf64 is %d by %d, while the slices of the\n"
+		s += "receiver are %d by %d. They must have the same shape."
+		s = fmt.Sprintf(s, "SetSlice()", m.r, m.c, t.r, t.c)
+		printErr(ErrShape, s)
+	}
+	copy(t.vals[k*t.r*t.c:], m.vals[:m.r*m.c])
+	return t
+}
+
+/*
+Copy returns a duplicate of a Tensor3f64, which shares no data with the
+original.
+*/
+func (t *Tensor3f64) Copy() *Tensor3f64 {
+	n := NewTensor3f64(t.d, t.r, t.c)
+	copy(n.vals, t.vals)
+	return n
+}
+
+/*
+Equals checks if two Tensor3f64s have the same shape and the same elements.
+*/
+func (t *Tensor3f64) Equals(n *Tensor3f64) bool {
+	if t.d != n.d || t.r != n.r || t.c != n.c {
+		return false
+	}
+	for i := range t.vals {
+		if t.vals[i] != n.vals[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Map applies the passed function to each element of a Tensor3f64, in the same
+manner as the Map method of Matf64.
+*/
+func (t *Tensor3f64) Map(f func(*float64)) *Tensor3f64 {
+	for i := range t.vals {
+		f(&t.vals[i])
+	}
+	return t
+}
+
+// elementwise applies f to each element of the receiver, and the passed
+// float64 or the corresponding element of the passed Tensor3f64.
+func (t *Tensor3f64) elementwise(fn string, float64OrTensor3f64 interface{}, f func(a *float64, b float64)) *Tensor3f64 {
+	switch v := float64OrTensor3f64.(type) {
+	case float64:
+		for i := range t.vals {
+			f(&t.vals[i], v)
+		}
+	case *Tensor3f64:
+		if v.d != t.d || v.r != t.r || v.c != t.c {
+			s := "\nIn %s, the receiver is %d by %d by %d, while the passed\n"
+			s += "Tensor3f64 is %d by %d by %d. They must have the same shape.\n"
+			s = fmt.Sprintf(s, fn, t.d, t.r, t.c, v.d, v.r, v.c)
+			printHelperErr(ErrShape, s)
+		}
+		for i := range t.vals {
+			f(&t.vals[i], v.vals[i])
+		}
+	default:
+		s := "\nIn %s, the passed value must be a float64 or *Tensor3f64.\n"
+		s += "However, value of type  \"%v\" was received.\n"
+		s = fmt.Sprintf(s, fn, reflect.TypeOf(v))
+		printHelperErr(ErrArgument, s)
+	}
+	return t
+}
+
+/*
+Add adds the passed float64 to each element of the receiver, or adds each
+element of the passed Tensor3f64 to the corresponding element of the receiver.
+The passed Tensor3f64 must have the same shape as the receiver.
+*/
+func (t *Tensor3f64) Add(float64OrTensor3f64 interface{}) *Tensor3f64 {
+	return t.elementwise("Add()", float64OrTensor3f64, func(a *float64, b float64) { *a += b })
+}
+
+/*
+Sub subtracts the passed float64 from each element of the receiver, or
+subtracts each element of the passed Tensor3f64 from the corresponding element
+of the receiver. The passed Tensor3f64 must have the same shape as the
+receiver.
+*/
+func (t *Tensor3f64) Sub(float64OrTensor3f64 interface{}) *Tensor3f64 {
+	return t.elementwise("Sub()", float64OrTensor3f64, func(a *float64, b float64) { *a -= b })
+}
+
+/*
+Mul multiplies each element of the receiver by the passed float64, or by the
+corresponding element of the passed Tensor3f64. The passed Tensor3f64 must
+have the same shape as the receiver.
+*/
+func (t *Tensor3f64) Mul(float64OrTensor3f64 interface{}) *Tensor3f64 {
+	return t.elementwise("Mul()", float64OrTensor3f64, func(a *float64, b float64) { *a *= b })
+}
+
+/*
+Div divides each element of the receiver by the passed float64, or by the
+corresponding element of the passed Tensor3f64. The passed Tensor3f64 must
+have the same shape as the receiver.
+*/
+func (t *Tensor3f64) Div(float64OrTensor3f64 interface{}) *Tensor3f64 {
+	return t.elementwise("Div()", float64OrTensor3f64, func(a *float64, b float64) { *a /= b })
+}
+
+/*
+Dot is the batched matrix multiplication of two Tensor3f64s, such that the
+slice at depth k of the result is the Dot of the slices at depth k of the
+receiver and the passed Tensor3f64:
+
+	a := matrix.NewTensor3f64(32, 5, 6)
+	b := matrix.NewTensor3f64(32, 6, 10)
+	c := a.Dot(b) // c is 32 by 5 by 10
+
+The two Tensor3f64s must have the same depth, and the number of columns of
+the receiver must be equal to the number of rows of the passed Tensor3f64.
+*/
+func (t *Tensor3f64) Dot(n *Tensor3f64) *Tensor3f64 {
+	if t.d != n.d {
+		s := "\nIn %s, the depth of the receiver is %d, while the depth of\n"
+		s += "the passed Tensor3f64 is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Dot()", t.d, n.d)
+		printErr(ErrShape, s)
+	}
+	if t.c != n.r {
+		s := "\nIn %s the number of columns of the first Tensor3f64 is %d\n"
+		s += "which is not equal to the number of rows of the second, which\n"
+		s += "is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Dot()", t.c, n.r)
+		printErr(ErrShape, s)
+	}
+	o := NewTensor3f64(t.d, t.r, n.c)
+	for k := 0; k < t.d; k++ {
+		a := t.vals[k*t.r*t.c:]
+		b := n.vals[k*n.r*n.c:]
+		out := o.vals[k*o.r*o.c:]
+		for i := 0; i < t.r; i++ {
+			for j := 0; j < n.c; j++ {
+				sum := 0.0
+				for l := 0; l < t.c; l++ {
+					sum += a[i*t.c+l] * b[l*n.c+j]
+				}
+				out[i*o.c+j] = sum
+			}
+		}
+	}
+	return o
+}
@@ -0,0 +1,92 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTensor3f64(t *testing.T) {
+	t.Helper()
+	x := NewTensor3f64(2, 3, 4)
+	d, r, c := x.Shape()
+	assert.Equal(t, 2, d, "should be equal")
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+	assert.Equal(t, 24, len(x.vals), "should be equal")
+}
+
+func TestTensor3f64FromMats(t *testing.T) {
+	t.Helper()
+	a := RandMatf64(3, 2)
+	b := RandMatf64(3, 2)
+	x := Tensor3f64FromMats(a, b)
+	assert.True(t, x.Slice(0).Equals(a), "should be equal")
+	assert.True(t, x.Slice(1).Equals(b), "should be equal")
+	a.Set(0, 0, 100.0)
+	assert.NotEqual(t, 100.0, x.Get(0, 0, 0), "should be a copy")
+}
+
+func TestTensor3GetSetf64(t *testing.T) {
+	t.Helper()
+	x := NewTensor3f64(2, 3, 4)
+	x.Set(1, 2, 3, 5.0)
+	assert.Equal(t, 5.0, x.Get(1, 2, 3), "should be equal")
+	assert.Equal(t, 5.0, x.vals[23], "should be the last element")
+}
+
+func TestTensor3Slicef64(t *testing.T) {
+	t.Helper()
+	x := NewTensor3f64(3, 2, 2)
+	s := x.Slice(1)
+	s.SetAll(2.0)
+	assert.Equal(t, 2.0, x.Get(1, 1, 1), "should share data")
+	assert.Equal(t, 0.0, x.Get(2, 0, 0), "should not leak into other slices")
+	s.AppendRow([]float64{3.0, 3.0})
+	assert.Equal(t, 0.0, x.Get(2, 0, 0), "appending should not overwrite")
+
+	x.SetSlice(2, Newf64(2, 2).SetAll(4.0))
+	assert.Equal(t, []float64{0, 0, 0, 0, 2, 2, 2, 2, 4, 4, 4, 4}, x.vals, "should be equal")
+}
+
+func TestTensor3Copyf64(t *testing.T) {
+	t.Helper()
+	x := NewTensor3f64(2, 2, 2).Map(func(v *float64) { *v = 1.0 })
+	y := x.Copy()
+	assert.True(t, y.Equals(x), "should be equal")
+	y.Set(0, 0, 0, 2.0)
+	assert.False(t, y.Equals(x), "should be a deep copy")
+	assert.False(t, x.Equals(NewTensor3f64(1, 2, 4)), "shapes differ")
+}
+
+func TestTensor3Elementwisef64(t *testing.T) {
+	t.Helper()
+	x := NewTensor3f64(2, 2, 3).Map(func(v *float64) { *v = 6.0 })
+	y := NewTensor3f64(2, 2, 3).Map(func(v *float64) { *v = 2.0 })
+	assert.Equal(t, 8.0, x.Copy().Add(y).Get(1, 1, 2), "should be equal")
+	assert.Equal(t, 4.0, x.Copy().Sub(y).Get(1, 1, 2), "should be equal")
+	assert.Equal(t, 12.0, x.Copy().Mul(y).Get(1, 1, 2), "should be equal")
+	assert.Equal(t, 3.0, x.Copy().Div(y).Get(1, 1, 2), "should be equal")
+	assert.Equal(t, 7.0, x.Copy().Add(1.0).Get(0, 0, 0), "should be equal")
+	assert.Equal(t, 5.0, x.Copy().Sub(1.0).Get(0, 0, 0), "should be equal")
+	assert.Equal(t, 12.0, x.Copy().Mul(2.0).Get(0, 0, 0), "should be equal")
+	assert.Equal(t, 3.0, x.Copy().Div(2.0).Get(0, 0, 0), "should be equal")
+}
+
+func TestTensor3Dotf64(t *testing.T) {
+	t.Helper()
+	a := []*Matf64{RandMatf64(3, 4), RandMatf64(3, 4)}
+	b := []*Matf64{RandMatf64(4, 2), RandMatf64(4, 2)}
+	x := Tensor3f64FromMats(a...).Dot(Tensor3f64FromMats(b...))
+	d, r, c := x.Shape()
+	assert.Equal(t, 2, d, "should be equal")
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	for k := range a {
+		expected := a[k].Dot(b[k])
+		s := x.Slice(k)
+		for i := range expected.vals {
+			assert.InDelta(t, expected.vals[i], s.vals[i], 1e-12, "should be equal")
+		}
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Package tensorconv converts between the Matf64 of package matrix and the
+tensors of gorgonia, so that a Matf64 can be used to prepare data for neural
+networks built with gorgonia without copying it:
+
+	m := matrix.RandMatf64(100, 10)
+	t := tensorconv.ToTensor(m) // t.Shape() is (100, 10)
+
+It is a separate package so that package matrix itself does not depend on
+gorgonia, which is not managed by the Gopkg files of this repository, and
+must be installed by the users of this package.
+*/
+package tensorconv
+
+import (
+	"fmt"
+
+	"github.com/NDari/matrix"
+	"gorgonia.org/tensor"
+)
+
+/*
+ToTensor returns a gorgonia *tensor.Dense with the same shape as the passed
+Matf64, which is backed by the same []float64 as the Matf64. Since no copy is
+made, changes to the elements of the returned tensor are reflected in the
+Matf64, and vice versa. Note that methods that change the shape of the
+Matf64, such as AppendRow or Concat, may reallocate its underlying slice,
+after which the two no longer share their data.
+
+An empty Matf64 results in a tensor of the same shape, with no elements.
+*/
+func ToTensor(m *matrix.Matf64) *tensor.Dense {
+	r, c := m.Shape()
+	data := m.RawRowMajor()
+	if data == nil {
+		// tensor.New of some releases of gorgonia panics on a nil backing.
+		data = []float64{}
+	}
+	return tensor.New(
+		tensor.WithShape(r, c),
+		tensor.WithBacking(data),
+	)
+}
+
+/*
+Matf64FromTensor creates a Matf64 from a gorgonia *tensor.Dense. The passed
+tensor must have a float64 data type, and be either 1 or 2 dimensional. A 1D
+tensor results in a row vector, while a 2D tensor results in a Matf64 of the
+same shape. Any other tensor results in an error, which matches
+matrix.ErrArgument or matrix.ErrShape with errors.Is.
+
+When the passed tensor is laid out in row major order, the returned Matf64
+shares its underlying data with the tensor, and no copy is made. If the tensor
+is a view of another tensor (for example after calling its T() method), the
+view is materialized, and the returned Matf64 holds a copy of the data.
+*/
+func Matf64FromTensor(t *tensor.Dense) (*matrix.Matf64, error) {
+	if t.Dtype() != tensor.Float64 {
+		return nil, fmt.Errorf("tensorconv: expected a tensor of type float64, however a tensor of type %v was received: %w", t.Dtype(), matrix.ErrArgument)
+	}
+	shape := t.Shape()
+	var r, c int
+	switch len(shape) {
+	case 1:
+		r, c = 1, shape[0]
+	case 2:
+		r, c = shape[0], shape[1]
+	default:
+		return nil, fmt.Errorf("tensorconv: expected a 1D or 2D tensor, however the received tensor has %d dimensions: %w", len(shape), matrix.ErrShape)
+	}
+	if t.IsMaterializable() {
+		data := make([]float64, r*c)
+		copy(data, t.Materialize().Data().([]float64))
+		return matrix.Matf64FromRowMajor(data, r, c), nil
+	}
+	return matrix.Matf64FromRowMajor(t.Data().([]float64)[:r*c], r, c), nil
+}
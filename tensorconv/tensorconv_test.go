@@ -0,0 +1,80 @@
+package tensorconv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NDari/matrix"
+	"github.com/stretchr/testify/assert"
+	"gorgonia.org/tensor"
+)
+
+func TestToTensor(t *testing.T) {
+	t.Helper()
+	rows, cols := 5, 4
+	vals := make([]float64, rows*cols)
+	for i := range vals {
+		vals[i] = float64(i)
+	}
+	m := matrix.Matf64FromData(vals, rows, cols)
+	d := ToTensor(m)
+	assert.Equal(t, tensor.Shape{rows, cols}, d.Shape(), "should be equal")
+	assert.Equal(t, tensor.Float64, d.Dtype(), "should be equal")
+	data := d.Data().([]float64)
+	assert.Equal(t, vals, data, "should be equal")
+	data[3] = 100.0
+	assert.Equal(t, 100.0, m.Get(0, 3), "changing the tensor should change mat")
+}
+
+func TestToTensorEmpty(t *testing.T) {
+	t.Helper()
+	for _, m := range []*matrix.Matf64{
+		matrix.Newf64(),
+		matrix.Newf64(0, 0),
+		matrix.Matf64FromRowMajor(nil, 0, 0),
+	} {
+		var d *tensor.Dense
+		assert.NotPanics(t, func() { d = ToTensor(m) }, "should not panic")
+		assert.Equal(t, tensor.Shape{0, 0}, d.Shape(), "should be equal")
+		assert.Equal(t, tensor.Float64, d.Dtype(), "should be equal")
+	}
+}
+
+func TestMatf64FromTensor(t *testing.T) {
+	t.Helper()
+	backing := []float64{1, 2, 3, 4, 5, 6}
+	d := tensor.New(tensor.WithShape(2, 3), tensor.WithBacking(backing))
+	m, err := Matf64FromTensor(d)
+	assert.NoError(t, err, "should not fail")
+	r, c := m.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	assert.Equal(t, backing, m.ToSlice1D(), "should be equal")
+	m.Set(0, 0, 10.0)
+	assert.Equal(t, 10.0, backing[0], "changing mat should change the tensor")
+
+	v, err := Matf64FromTensor(tensor.New(tensor.WithShape(6), tensor.WithBacking(backing)))
+	assert.NoError(t, err, "should not fail")
+	r, c = v.Shape()
+	assert.Equal(t, 1, r, "should be a row vector")
+	assert.Equal(t, 6, c, "should be equal")
+
+	d.T()
+	n, err := Matf64FromTensor(d)
+	assert.NoError(t, err, "should not fail")
+	r, c = n.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	assert.True(t, n.Equals(m.T()), "should be equal")
+}
+
+func TestMatf64FromTensorErrors(t *testing.T) {
+	t.Helper()
+	f32 := tensor.New(tensor.WithShape(2), tensor.WithBacking([]float32{1, 2}))
+	_, err := Matf64FromTensor(f32)
+	assert.True(t, errors.Is(err, matrix.ErrArgument), "should be an argument error")
+
+	cube := tensor.New(tensor.WithShape(1, 1, 2), tensor.WithBacking([]float64{1, 2}))
+	_, err = Matf64FromTensor(cube)
+	assert.True(t, errors.Is(err, matrix.ErrShape), "should be a shape error")
+}
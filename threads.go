@@ -0,0 +1,73 @@
+package matrix
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// maxThreads is the number of goroutines that parallel kernels may use, or 0
+// for runtime.GOMAXPROCS(0).
+var maxThreads int64
+
+// parallelWork is the number of multiplications below which a kernel is
+// not split between goroutines, as the overhead would dominate.
+const parallelWork = 1 << 15
+
+/*
+SetMaxThreads sets the number of goroutines that the parallel kernels of the
+package, such as Dot, may use, and returns the previous setting. A value of
+1 makes every operation run on the calling goroutine only, and a value of 0
+or less restores the default, which is runtime.GOMAXPROCS(0) at the time of
+each call. For example, a service under a CPU quota of 2 can use:
+
+	matrix.SetMaxThreads(2)
+
+The results do not depend on the number of goroutines: each element is
+always computed by a single goroutine, in the same order. It is safe to call
+concurrently with other functions of the package.
+*/
+func SetMaxThreads(n int) int {
+	if n < 0 {
+		n = 0
+	}
+	return int(atomic.SwapInt64(&maxThreads, int64(n)))
+}
+
+/*
+MaxThreads returns the number of goroutines that the parallel kernels of the
+package may use, as set by SetMaxThreads.
+*/
+func MaxThreads() int {
+	if n := atomic.LoadInt64(&maxThreads); n > 0 {
+		return int(n)
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// parallelRows calls f on consecutive ranges of [0, rows), using at most
+// threads goroutines, or only the calling goroutine if work is too small to
+// be worth splitting. It returns when all calls have returned.
+func parallelRows(rows, work, threads int, f func(lo, hi int)) {
+	if threads > rows {
+		threads = rows
+	}
+	if threads <= 1 || work < parallelWork {
+		f(0, rows)
+		return
+	}
+	var wg sync.WaitGroup
+	chunk := (rows + threads - 1) / threads
+	for lo := 0; lo < rows; lo += chunk {
+		hi := lo + chunk
+		if hi > rows {
+			hi = rows
+		}
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			f(lo, hi)
+		}(lo, hi)
+	}
+	wg.Wait()
+}
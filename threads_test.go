@@ -0,0 +1,32 @@
+package matrix
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMaxThreadsf64(t *testing.T) {
+	t.Helper()
+	prev := SetMaxThreads(3)
+	defer SetMaxThreads(prev)
+	assert.Equal(t, 3, MaxThreads(), "should be equal")
+	assert.Equal(t, 3, SetMaxThreads(0), "should return the previous setting")
+	assert.Equal(t, runtime.GOMAXPROCS(0), MaxThreads(), "should be the default")
+}
+
+func TestDotThreadsf64(t *testing.T) {
+	t.Helper()
+	a := RandMatf64(67, 45)
+	b := RandMatf64(45, 53)
+	want := a.DotThreads(b, 1)
+	for _, threads := range []int{2, 3, 8, 100} {
+		assert.Equal(t, want.ToSlice1D(), a.DotThreads(b, threads).ToSlice1D(), "should not depend on the threads")
+	}
+	prev := SetMaxThreads(4)
+	defer SetMaxThreads(prev)
+	assert.Equal(t, want.ToSlice1D(), a.Dot(b).ToSlice1D(), "should be equal")
+	assert.Equal(t, want.ToSlice1D(), DotTf64(a, b, false, false).ToSlice1D(), "should be equal")
+	assert.Equal(t, want.ToSlice1D(), DotTf64(a, b.Copy().T(), false, true).ToSlice1D(), "should be equal")
+}
@@ -0,0 +1,156 @@
+package matrix
+
+// The functions below are closed form kernels for the products, determinants
+// and inverses of 2 by 2, 3 by 3 and 4 by 4 matrices, stored in row major
+// order. They are used by Dot, Det and Inv for matrices of these sizes, which
+// are very common in graphics and robotics, and do not allocate.
+
+// tinySize reports whether m is square and small enough for the kernels.
+func (m *Matf64) tinySize() bool {
+	return m.r == m.c && m.r >= 2 && m.r <= 4
+}
+
+// dotTiny sets o to the product of the n by n matrices a and b.
+func dotTiny(n int, o, a, b []float64) {
+	switch n {
+	case 2:
+		dot2(o, a, b)
+	case 3:
+		dot3(o, a, b)
+	case 4:
+		dot4(o, a, b)
+	}
+}
+
+func dot2(o, a, b []float64) {
+	_, _, _ = o[3], a[3], b[3]
+	o[0] = a[0]*b[0] + a[1]*b[2]
+	o[1] = a[0]*b[1] + a[1]*b[3]
+	o[2] = a[2]*b[0] + a[3]*b[2]
+	o[3] = a[2]*b[1] + a[3]*b[3]
+}
+
+func dot3(o, a, b []float64) {
+	_, _, _ = o[8], a[8], b[8]
+	o[0] = a[0]*b[0] + a[1]*b[3] + a[2]*b[6]
+	o[1] = a[0]*b[1] + a[1]*b[4] + a[2]*b[7]
+	o[2] = a[0]*b[2] + a[1]*b[5] + a[2]*b[8]
+	o[3] = a[3]*b[0] + a[4]*b[3] + a[5]*b[6]
+	o[4] = a[3]*b[1] + a[4]*b[4] + a[5]*b[7]
+	o[5] = a[3]*b[2] + a[4]*b[5] + a[5]*b[8]
+	o[6] = a[6]*b[0] + a[7]*b[3] + a[8]*b[6]
+	o[7] = a[6]*b[1] + a[7]*b[4] + a[8]*b[7]
+	o[8] = a[6]*b[2] + a[7]*b[5] + a[8]*b[8]
+}
+
+func dot4(o, a, b []float64) {
+	_, _, _ = o[15], a[15], b[15]
+	o[0] = a[0]*b[0] + a[1]*b[4] + a[2]*b[8] + a[3]*b[12]
+	o[1] = a[0]*b[1] + a[1]*b[5] + a[2]*b[9] + a[3]*b[13]
+	o[2] = a[0]*b[2] + a[1]*b[6] + a[2]*b[10] + a[3]*b[14]
+	o[3] = a[0]*b[3] + a[1]*b[7] + a[2]*b[11] + a[3]*b[15]
+	o[4] = a[4]*b[0] + a[5]*b[4] + a[6]*b[8] + a[7]*b[12]
+	o[5] = a[4]*b[1] + a[5]*b[5] + a[6]*b[9] + a[7]*b[13]
+	o[6] = a[4]*b[2] + a[5]*b[6] + a[6]*b[10] + a[7]*b[14]
+	o[7] = a[4]*b[3] + a[5]*b[7] + a[6]*b[11] + a[7]*b[15]
+	o[8] = a[8]*b[0] + a[9]*b[4] + a[10]*b[8] + a[11]*b[12]
+	o[9] = a[8]*b[1] + a[9]*b[5] + a[10]*b[9] + a[11]*b[13]
+	o[10] = a[8]*b[2] + a[9]*b[6] + a[10]*b[10] + a[11]*b[14]
+	o[11] = a[8]*b[3] + a[9]*b[7] + a[10]*b[11] + a[11]*b[15]
+	o[12] = a[12]*b[0] + a[13]*b[4] + a[14]*b[8] + a[15]*b[12]
+	o[13] = a[12]*b[1] + a[13]*b[5] + a[14]*b[9] + a[15]*b[13]
+	o[14] = a[12]*b[2] + a[13]*b[6] + a[14]*b[10] + a[15]*b[14]
+	o[15] = a[12]*b[3] + a[13]*b[7] + a[14]*b[11] + a[15]*b[15]
+}
+
+// detTiny returns the determinant of the n by n matrix a.
+func detTiny(n int, a []float64) float64 {
+	switch n {
+	case 2:
+		return a[0]*a[3] - a[1]*a[2]
+	case 3:
+		return a[0]*(a[4]*a[8]-a[5]*a[7]) -
+			a[1]*(a[3]*a[8]-a[5]*a[6]) +
+			a[2]*(a[3]*a[7]-a[4]*a[6])
+	}
+	s, c := minors4(a)
+	return s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+}
+
+// minors4 returns the 2 by 2 minors of the top two rows, and of the bottom
+// two rows, of the 4 by 4 matrix a, from which both its determinant and its
+// inverse are computed.
+func minors4(a []float64) (s, c [6]float64) {
+	_ = a[15]
+	s[0] = a[0]*a[5] - a[4]*a[1]
+	s[1] = a[0]*a[6] - a[4]*a[2]
+	s[2] = a[0]*a[7] - a[4]*a[3]
+	s[3] = a[1]*a[6] - a[5]*a[2]
+	s[4] = a[1]*a[7] - a[5]*a[3]
+	s[5] = a[2]*a[7] - a[6]*a[3]
+	c[0] = a[8]*a[13] - a[12]*a[9]
+	c[1] = a[8]*a[14] - a[12]*a[10]
+	c[2] = a[8]*a[15] - a[12]*a[11]
+	c[3] = a[9]*a[14] - a[13]*a[10]
+	c[4] = a[9]*a[15] - a[13]*a[11]
+	c[5] = a[10]*a[15] - a[14]*a[11]
+	return s, c
+}
+
+// invTiny sets o to the inverse of the n by n matrix a, and returns the
+// determinant of a. If the determinant is zero, o is not changed.
+func invTiny(n int, o, a []float64) float64 {
+	switch n {
+	case 2:
+		det := a[0]*a[3] - a[1]*a[2]
+		if det == 0.0 {
+			return det
+		}
+		o[0], o[1], o[2], o[3] = a[3]/det, -a[1]/det, -a[2]/det, a[0]/det
+		return det
+	case 3:
+		_, _ = o[8], a[8]
+		c0 := a[4]*a[8] - a[5]*a[7]
+		c1 := a[5]*a[6] - a[3]*a[8]
+		c2 := a[3]*a[7] - a[4]*a[6]
+		det := a[0]*c0 + a[1]*c1 + a[2]*c2
+		if det == 0.0 {
+			return det
+		}
+		inv := 1.0 / det
+		o[0] = c0 * inv
+		o[1] = (a[2]*a[7] - a[1]*a[8]) * inv
+		o[2] = (a[1]*a[5] - a[2]*a[4]) * inv
+		o[3] = c1 * inv
+		o[4] = (a[0]*a[8] - a[2]*a[6]) * inv
+		o[5] = (a[2]*a[3] - a[0]*a[5]) * inv
+		o[6] = c2 * inv
+		o[7] = (a[1]*a[6] - a[0]*a[7]) * inv
+		o[8] = (a[0]*a[4] - a[1]*a[3]) * inv
+		return det
+	}
+	_, _ = o[15], a[15]
+	s, c := minors4(a)
+	det := s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+	if det == 0.0 {
+		return det
+	}
+	inv := 1.0 / det
+	o[0] = (a[5]*c[5] - a[6]*c[4] + a[7]*c[3]) * inv
+	o[1] = (-a[1]*c[5] + a[2]*c[4] - a[3]*c[3]) * inv
+	o[2] = (a[13]*s[5] - a[14]*s[4] + a[15]*s[3]) * inv
+	o[3] = (-a[9]*s[5] + a[10]*s[4] - a[11]*s[3]) * inv
+	o[4] = (-a[4]*c[5] + a[6]*c[2] - a[7]*c[1]) * inv
+	o[5] = (a[0]*c[5] - a[2]*c[2] + a[3]*c[1]) * inv
+	o[6] = (-a[12]*s[5] + a[14]*s[2] - a[15]*s[1]) * inv
+	o[7] = (a[8]*s[5] - a[10]*s[2] + a[11]*s[1]) * inv
+	o[8] = (a[4]*c[4] - a[5]*c[2] + a[7]*c[0]) * inv
+	o[9] = (-a[0]*c[4] + a[1]*c[2] - a[3]*c[0]) * inv
+	o[10] = (a[12]*s[4] - a[13]*s[2] + a[15]*s[0]) * inv
+	o[11] = (-a[8]*s[4] + a[9]*s[2] - a[11]*s[0]) * inv
+	o[12] = (-a[4]*c[3] + a[5]*c[1] - a[6]*c[0]) * inv
+	o[13] = (a[0]*c[3] - a[1]*c[1] + a[2]*c[0]) * inv
+	o[14] = (-a[12]*s[3] + a[13]*s[1] - a[14]*s[0]) * inv
+	o[15] = (a[8]*s[3] - a[9]*s[1] + a[10]*s[0]) * inv
+	return det
+}
@@ -0,0 +1,103 @@
+package matrix
+
+import "fmt"
+
+/*
+Toeplitzf64 returns the Toeplitz matrix with the passed first column and
+first row, whose elements are constant along each diagonal:
+
+	matrix.Toeplitzf64([]float64{1, 2, 3}, []float64{1, 4, 5})
+	// [[1, 4, 5],
+	//  [2, 1, 4],
+	//  [3, 2, 1]]
+
+It is len(col) by len(row). The first element of row is ignored, as the
+corner is the first element of col. If row is nil, the symmetric Toeplitz
+matrix with col as its first row and column is returned, such as the
+autocorrelation matrix of a signal from its autocorrelations. The product of
+a Toeplitz matrix with a vector is a convolution.
+*/
+func Toeplitzf64(col, row []float64) *Matf64 {
+	checkDefining("matrix.Toeplitzf64()", "column", col)
+	if row == nil {
+		row = col
+	}
+	checkDefining("matrix.Toeplitzf64()", "row", row)
+	m := Newf64(len(col), len(row))
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if i >= j {
+				m.vals[i*m.c+j] = col[i-j]
+			} else {
+				m.vals[i*m.c+j] = row[j-i]
+			}
+		}
+	}
+	return m
+}
+
+/*
+Hankelf64 returns the Hankel matrix with the passed first column and last
+row, whose elements are constant along each anti-diagonal:
+
+	matrix.Hankelf64([]float64{1, 2, 3}, []float64{3, 4, 5})
+	// [[1, 2, 3],
+	//  [2, 3, 4],
+	//  [3, 4, 5]]
+
+It is len(col) by len(row). The first element of row is ignored, as the
+corner is the last element of col. If row is nil, the elements below the
+anti-diagonal which starts at the last element of col are zero, and the
+matrix is square.
+*/
+func Hankelf64(col, row []float64) *Matf64 {
+	checkDefining("matrix.Hankelf64()", "column", col)
+	if row == nil {
+		row = make([]float64, len(col))
+	}
+	checkDefining("matrix.Hankelf64()", "row", row)
+	m := Newf64(len(col), len(row))
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if k := i + j; k < len(col) {
+				m.vals[i*m.c+j] = col[k]
+			} else {
+				m.vals[i*m.c+j] = row[k-len(col)+1]
+			}
+		}
+	}
+	return m
+}
+
+/*
+Circulantf64 returns the n by n circulant matrix with the passed first
+column, of length n, each of whose columns is the previous one rotated down
+by one:
+
+	matrix.Circulantf64([]float64{1, 2, 3})
+	// [[1, 3, 2],
+	//  [2, 1, 3],
+	//  [3, 2, 1]]
+
+so that its product with a column vector x is the circular convolution of c
+and x.
+*/
+func Circulantf64(c []float64) *Matf64 {
+	checkDefining("matrix.Circulantf64()", "column", c)
+	n := len(c)
+	m := Newf64(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			m.vals[i*n+j] = c[(i-j+n)%n]
+		}
+	}
+	return m
+}
+
+func checkDefining(fn, name string, v []float64) {
+	if len(v) == 0 {
+		s := "\nIn %s, the %s must have at least one element."
+		s = fmt.Sprintf(s, fn, name)
+		printHelperErr(ErrArgument, s)
+	}
+}
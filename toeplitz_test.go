@@ -0,0 +1,55 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToeplitzf64(t *testing.T) {
+	t.Helper()
+	m := Toeplitzf64([]float64{1, 2, 3}, []float64{9, 4, 5, 6})
+	assert.Equal(t, []float64{1, 4, 5, 6, 2, 1, 4, 5, 3, 2, 1, 4}, m.vals, "should be equal")
+	r, c := m.Shape()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+
+	s := Toeplitzf64([]float64{1, 2, 3}, nil)
+	assert.Equal(t, []float64{1, 2, 3, 2, 1, 2, 3, 2, 1}, s.vals, "should be equal")
+	assert.True(t, s.IsSymmetric(0), "should be symmetric")
+
+	// The product with a vector is the convolution of [1, 2, 3] and x.
+	x := Matf64FromData([]float64{1, 1, 1}, 3, 1)
+	conv := Toeplitzf64([]float64{1, 2, 3, 0, 0}, []float64{1, 0, 0})
+	assert.Equal(t, []float64{1, 3, 6, 5, 3}, conv.Dot(x).vals, "should be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { Toeplitzf64(nil, nil) }, "should panic")
+	assert.Panics(t, func() { Toeplitzf64([]float64{1}, []float64{}) }, "should panic")
+}
+
+func TestHankelf64(t *testing.T) {
+	t.Helper()
+	m := Hankelf64([]float64{1, 2, 3}, []float64{9, 4, 5})
+	assert.Equal(t, []float64{1, 2, 3, 2, 3, 4, 3, 4, 5}, m.vals, "should be equal")
+	assert.Equal(t, []float64{1, 2, 3, 2, 3, 0, 3, 0, 0}, Hankelf64([]float64{1, 2, 3}, nil).vals, "should be equal")
+	assert.Equal(t, []float64{1, 2, 2, 7}, Hankelf64([]float64{1, 2}, []float64{0, 7}).vals, "should be equal")
+	assert.Equal(t, []float64{1, 2, 8, 2, 8, 9}, Hankelf64([]float64{1, 2}, []float64{0, 8, 9}).vals, "should be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { Hankelf64([]float64{}, nil) }, "should panic")
+}
+
+func TestCirculantf64(t *testing.T) {
+	t.Helper()
+	m := Circulantf64([]float64{1, 2, 3})
+	assert.Equal(t, []float64{1, 3, 2, 2, 1, 3, 3, 2, 1}, m.vals, "should be equal")
+	assert.True(t, m.Equals(Toeplitzf64([]float64{1, 2, 3}, []float64{1, 3, 2})), "should be a Toeplitz matrix")
+
+	// The product with a vector is the circular convolution.
+	x := Matf64FromData([]float64{1, 0, 2}, 3, 1)
+	assert.Equal(t, []float64{5, 8, 5}, m.Dot(x).vals, "should be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { Circulantf64(nil) }, "should panic")
+}
@@ -0,0 +1,44 @@
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+ToStrings returns the elements of a Matf64 formatted as strings, as a
+[][]string with one slice per row, for table writers, terminal interfaces and
+templates. The format is that of the fmt package, with a single verb for a
+float64, and any text around it:
+
+	m.ToStrings("%.2f")   // [["1.00" "2.50"] ["3.00" "4.25"]]
+	m.ToStrings("%8.3e")  // padded, in scientific notation
+	m.ToStrings("$%.2f")  // with a prefix
+
+An empty format writes each element with the smallest number of digits
+necessary to represent it exactly.
+*/
+func (m *Matf64) ToStrings(format string) [][]string {
+	if format != "" {
+		if s := fmt.Sprintf(format, 1.0); strings.Contains(s, "%!") {
+			s := "\nIn %s, the format \"%s\" must have a single verb for a\n"
+			s += "float64, such as %%.3f."
+			s = fmt.Sprintf(s, "ToStrings()", format)
+			printErr(ErrArgument, s)
+		}
+	}
+	out := make([][]string, m.r)
+	for i := range out {
+		row := make([]string, m.c)
+		for j, v := range m.vals[i*m.c : (i+1)*m.c] {
+			if format == "" {
+				row[j] = strconv.FormatFloat(v, 'g', -1, 64)
+			} else {
+				row[j] = fmt.Sprintf(format, v)
+			}
+		}
+		out[i] = row
+	}
+	return out
+}
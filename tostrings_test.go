@@ -0,0 +1,22 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToStringsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2.5}, {3, 4.25}})
+	assert.Equal(t, [][]string{{"1.00", "2.50"}, {"3.00", "4.25"}}, m.ToStrings("%.2f"), "should be equal")
+	assert.Equal(t, [][]string{{"1", "2.5"}, {"3", "4.25"}}, m.ToStrings(""), "should be equal")
+	assert.Equal(t, [][]string{{"$ 1.0", "$ 2.5"}, {"$ 3.0", "$ 4.2"}}, m.ToStrings("$%4.1f"), "should be equal")
+	assert.Equal(t, "4.250e+00", m.ToStrings("%.3e")[1][1], "should be equal")
+	assert.Equal(t, 0, len(Newf64(0, 3).ToStrings("%f")), "should be empty")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { m.ToStrings("%d") }, "should panic")
+	assert.Panics(t, func() { m.ToStrings("%f %f") }, "should panic")
+	assert.Panics(t, func() { m.ToStrings("no verb") }, "should panic")
+}
@@ -0,0 +1,139 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Translatef64 returns the homogeneous transform which translates points by the
+passed offsets. Two offsets give a 3 by 3 transform for 2D points, and three
+give a 4 by 4 transform for 3D points:
+
+	t := matrix.Translatef64(1, 2, 3)
+*/
+func Translatef64(offsets ...float64) *Matf64 {
+	checkTransformDims("Translatef64()", len(offsets))
+	d := len(offsets)
+	h := homogeneousIdentity(d)
+	for i, v := range offsets {
+		h.vals[i*(d+1)+d] = v
+	}
+	return h
+}
+
+/*
+Scalef64 returns the homogeneous transform which scales each coordinate of
+points by the corresponding passed factor, in the same manner as Translatef64.
+*/
+func Scalef64(factors ...float64) *Matf64 {
+	checkTransformDims("Scalef64()", len(factors))
+	d := len(factors)
+	h := homogeneousIdentity(d)
+	for i, v := range factors {
+		h.vals[i*(d+1)+i] = v
+	}
+	return h
+}
+
+/*
+Rotatef64 returns the homogeneous transform of the passed 2 by 2 or 3 by 3
+rotation, such as those returned by Rot2Df64 and RotZf64. It is the same as
+Homogeneousf64 with no translation.
+*/
+func Rotatef64(rot *Matf64) *Matf64 {
+	checkTransformDims("Rotatef64()", rot.r)
+	return Homogeneousf64(rot, make([]float64, rot.r))
+}
+
+/*
+LookAtf64 returns the 4 by 4 view transform of a camera at eye, looking at
+target, with the passed up direction, in the convention of OpenGL: the camera
+looks down its negative z axis, with its y axis up. All three must have 3
+elements, and up must not be parallel to the direction of view.
+*/
+func LookAtf64(eye, target, up []float64) *Matf64 {
+	if len(eye) != 3 || len(target) != 3 || len(up) != 3 {
+		s := "\nIn matrix.%s, eye, target and up must have 3 elements, however\n"
+		s += "they have %d, %d and %d."
+		s = fmt.Sprintf(s, "LookAtf64()", len(eye), len(target), len(up))
+		printErr(ErrShape, s)
+	}
+	f := normalize3([3]float64{target[0] - eye[0], target[1] - eye[1], target[2] - eye[2]})
+	side := normalize3(cross3(f, [3]float64{up[0], up[1], up[2]}))
+	if math.IsNaN(f[0]) || math.IsNaN(side[0]) {
+		s := "\nIn matrix.%s, eye and target must differ, and up must not be\n"
+		s += "parallel to the direction from eye to target."
+		s = fmt.Sprintf(s, "LookAtf64()")
+		printErr(ErrArgument, s)
+	}
+	u := cross3(side, f)
+	h := homogeneousIdentity(3)
+	for j := 0; j < 3; j++ {
+		h.vals[j] = side[j]
+		h.vals[4+j] = u[j]
+		h.vals[8+j] = -f[j]
+		h.vals[3] -= side[j] * eye[j]
+		h.vals[7] -= u[j] * eye[j]
+		h.vals[11] += f[j] * eye[j]
+	}
+	return h
+}
+
+/*
+Perspectivef64 returns the 4 by 4 perspective projection with the passed
+vertical field of view, in radians, aspect ratio (width over height), and
+distances to the near and far clipping planes, in the convention of OpenGL.
+Points between the clipping planes are mapped to z in [-1, 1] by
+TransformPointsf64, which divides by the homogeneous coordinate.
+*/
+func Perspectivef64(fovy, aspect, near, far float64) *Matf64 {
+	if !(fovy > 0 && fovy < math.Pi) || !(aspect > 0) || !(near > 0) || !(far > near) {
+		s := "\nIn matrix.%s, the field of view must be in (0, Pi), the aspect\n"
+		s += "ratio and near must be positive, and far must be larger than\n"
+		s += "near, however %v, %v, %v and %v were received."
+		s = fmt.Sprintf(s, "Perspectivef64()", fovy, aspect, near, far)
+		printErr(ErrArgument, s)
+	}
+	f := 1.0 / math.Tan(fovy/2.0)
+	h := Newf64(4, 4)
+	h.vals[0] = f / aspect
+	h.vals[5] = f
+	h.vals[10] = (far + near) / (near - far)
+	h.vals[11] = 2.0 * far * near / (near - far)
+	h.vals[14] = -1.0
+	return h
+}
+
+func checkTransformDims(fn string, d int) {
+	if d != 2 && d != 3 {
+		s := "\nIn matrix.%s, transforms are only supported in 2 or 3\n"
+		s += "dimensions, however %d were received."
+		s = fmt.Sprintf(s, fn, d)
+		printHelperErr(ErrArgument, s)
+	}
+}
+
+func homogeneousIdentity(d int) *Matf64 {
+	h := Newf64(d+1, d+1)
+	for i := 0; i <= d; i++ {
+		h.vals[i*(d+1)+i] = 1.0
+	}
+	return h
+}
+
+func cross3(a, b [3]float64) [3]float64 {
+	return [3]float64{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func normalize3(a [3]float64) [3]float64 {
+	n := math.Sqrt(a[0]*a[0] + a[1]*a[1] + a[2]*a[2])
+	if n == 0.0 {
+		return [3]float64{math.NaN(), math.NaN(), math.NaN()}
+	}
+	return [3]float64{a[0] / n, a[1] / n, a[2] / n}
+}
@@ -0,0 +1,55 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTranslateScalef64(t *testing.T) {
+	t.Helper()
+	pts := Matf64FromData([]float64{
+		1, 2, 3,
+		0, 0, 0,
+	}, 2, 3)
+	tr := TransformPointsf64(Translatef64(1, -1, 2), pts)
+	assertMatInDelta(t, Matf64FromData([]float64{2, 1, 5, 1, -1, 2}, 2, 3), tr, 1e-15)
+	sc := TransformPointsf64(Scalef64(2, 3), Matf64FromData([]float64{1, 1}, 1, 2))
+	assertMatInDelta(t, Matf64FromData([]float64{2, 3}, 1, 2), sc, 1e-15)
+	// Scale first, then translate.
+	c := Translatef64(1, 0, 0).Dot(Scalef64(2, 2, 2))
+	assertMatInDelta(t, Matf64FromData([]float64{3, 4, 6, 1, 0, 0}, 2, 3), TransformPointsf64(c, pts), 1e-15)
+}
+
+func TestRotatef64(t *testing.T) {
+	t.Helper()
+	r := Rotatef64(RotZf64(math.Pi / 2))
+	got := TransformPointsf64(r, Matf64FromData([]float64{1, 0, 0, 1}, 1, 4))
+	assertMatInDelta(t, Matf64FromData([]float64{0, 1, 0, 1}, 1, 4), got, 1e-15)
+}
+
+func TestLookAtf64(t *testing.T) {
+	t.Helper()
+	v := LookAtf64([]float64{0, 0, 5}, []float64{0, 0, 0}, []float64{0, 1, 0})
+	assertMatInDelta(t, Translatef64(0, 0, -5), v, 1e-15)
+	v = LookAtf64([]float64{1, 2, 3}, []float64{4, 0, 1}, []float64{0, 0, 1})
+	// The target is straight ahead of the camera, down its negative z axis.
+	p := TransformPointsf64(v, Matf64FromData([]float64{4, 0, 1}, 1, 3))
+	d := math.Sqrt(9 + 4 + 4)
+	assertMatInDelta(t, Matf64FromData([]float64{0, 0, -d}, 1, 3), p, 1e-12)
+}
+
+func TestPerspectivef64(t *testing.T) {
+	t.Helper()
+	p := Perspectivef64(math.Pi/2, 1, 1, 10)
+	pts := Matf64FromData([]float64{
+		0, 0, -1,
+		0, 0, -10,
+		1, 1, -1,
+	}, 3, 3)
+	want := Matf64FromData([]float64{
+		0, 0, -1,
+		0, 0, 1,
+		1, 1, -1,
+	}, 3, 3)
+	assertMatInDelta(t, want, TransformPointsf64(p, pts), 1e-12)
+}
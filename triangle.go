@@ -0,0 +1,93 @@
+package matrix
+
+/*
+Triuf64 returns an r by c Matf64 whose elements on and above the main
+diagonal are one, and zero elsewhere. An optional offset moves the diagonal
+from which the ones start, above the main one if it is positive, and below
+if negative, as in Diag:
+
+	matrix.Triuf64(3, 3)    // ones on and above the diagonal
+	matrix.Triuf64(3, 3, 1) // ones strictly above the diagonal
+
+It is useful as a mask, such as with Mul, or as the start of a triangular
+matrix to be filled with Set. For packed storage of a square triangular
+matrix, see TriMatf64.
+*/
+func Triuf64(r, c int, offset ...int) *Matf64 {
+	k := diagOffset("matrix.Triuf64()", offset)
+	return Newf64(r, c).SetAll(1.0).triangle(k, true)
+}
+
+/*
+Trilf64 returns an r by c Matf64 whose elements on and below the main
+diagonal are one, and zero elsewhere, with an optional offset of the
+diagonal as in Triuf64:
+
+	matrix.Trilf64(3, 3)     // ones on and below the diagonal
+	matrix.Trilf64(3, 3, -1) // ones strictly below the diagonal
+*/
+func Trilf64(r, c int, offset ...int) *Matf64 {
+	k := diagOffset("matrix.Trilf64()", offset)
+	return Newf64(r, c).SetAll(1.0).triangle(k, false)
+}
+
+/*
+Triu sets the elements of a Matf64 below the main diagonal to zero, leaving
+its upper triangle. An optional offset selects another diagonal, above the
+main one if it is positive, and below if negative, which is kept along with
+the elements above it:
+
+	m.Triu()   // the upper triangle, with the diagonal
+	m.Triu(1)  // the upper triangle, without the diagonal
+	m.Triu(-1) // also keeps the diagonal below the main one
+
+As with the other methods, the receiver is changed, so to keep the original
+use m.Copy().Triu(). The Matf64 need not be square.
+*/
+func (m *Matf64) Triu(offset ...int) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Triu(offset...) })
+	}
+	return m.triangle(diagOffset("Triu()", offset), true)
+}
+
+/*
+Tril sets the elements of a Matf64 above the main diagonal to zero, leaving
+its lower triangle, with an optional offset of the diagonal as in Triu:
+
+	m.Tril()   // the lower triangle, with the diagonal
+	m.Tril(-1) // the lower triangle, without the diagonal
+
+Together, m.Copy().Tril(-1) and m.Copy().Triu() split m into a strictly lower
+and an upper triangle, which add back to m.
+*/
+func (m *Matf64) Tril(offset ...int) *Matf64 {
+	if m.deferErr {
+		return m.deferred(func() *Matf64 { return m.Tril(offset...) })
+	}
+	return m.triangle(diagOffset("Tril()", offset), false)
+}
+
+// triangle sets the elements of m outside of the triangle on and above, if
+// upper, or on and below, the diagonal with offset k to zero.
+func (m *Matf64) triangle(k int, upper bool) *Matf64 {
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		// d is the column of the diagonal in row i.
+		d := i + k
+		if upper {
+			for j := 0; j < d && j < m.c; j++ {
+				row[j] = 0.0
+			}
+		} else {
+			j := d + 1
+			if j < 0 {
+				j = 0
+			}
+			for ; j < m.c; j++ {
+				row[j] = 0.0
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,45 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriuf64(t *testing.T) {
+	t.Helper()
+	assert.Equal(t, []float64{1, 1, 1, 0, 1, 1}, Triuf64(2, 3).vals, "should be equal")
+	assert.Equal(t, []float64{0, 1, 1, 0, 0, 1}, Triuf64(2, 3, 1).vals, "should be equal")
+	assert.Equal(t, []float64{1, 1, 1, 1, 0, 1}, Triuf64(3, 2, -1).vals, "should be equal")
+	assert.Equal(t, []float64{0, 0, 0, 0}, Triuf64(2, 2, 2).vals, "should be equal")
+
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	assert.Equal(t, []float64{1, 2, 3, 0, 5, 6, 0, 0, 9}, m.Copy().Triu().vals, "should be equal")
+	assert.Equal(t, []float64{0, 2, 3, 0, 0, 6, 0, 0, 0}, m.Copy().Triu(1).vals, "should be equal")
+	assert.Equal(t, []float64{1, 2, 3, 4, 5, 6, 0, 8, 9}, m.Copy().Triu(-1).vals, "should be equal")
+	assert.Equal(t, m.vals, m.Copy().Triu(-5).vals, "should be equal")
+	assert.True(t, m.Copy().Triu().Equals(m.Copy().Mul(Triuf64(3, 3))), "should be equal")
+
+	o := m.Copy()
+	assert.True(t, o.Triu() == o, "should return the receiver")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { m.Triu(0, 1) }, "should panic")
+}
+
+func TestTrilf64(t *testing.T) {
+	t.Helper()
+	assert.Equal(t, []float64{1, 0, 0, 1, 1, 0}, Trilf64(2, 3).vals, "should be equal")
+	assert.Equal(t, []float64{0, 0, 1, 0, 1, 1}, Trilf64(3, 2, -1).vals, "should be equal")
+	assert.Equal(t, []float64{1, 1, 0, 1, 1, 1}, Trilf64(2, 3, 1).vals, "should be equal")
+
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	assert.Equal(t, []float64{1, 0, 0, 4, 5, 0, 7, 8, 9}, m.Copy().Tril().vals, "should be equal")
+	assert.Equal(t, []float64{0, 0, 0, 4, 0, 0, 7, 8, 0}, m.Copy().Tril(-1).vals, "should be equal")
+	assert.Equal(t, m.vals, m.Copy().Tril(2).vals, "should be equal")
+	assert.True(t, m.Copy().Tril(-1).Add(m.Copy().Triu()).Equals(m), "should add back to m")
+	assert.True(t, m.Copy().T().Tril().Equals(m.Copy().Triu().T()), "should be equal")
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { Trilf64(2, 2, 0, 1) }, "should panic")
+}
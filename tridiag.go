@@ -0,0 +1,59 @@
+package matrix
+
+import "fmt"
+
+/*
+SolveTridiagf64 returns the Matf64 x such that A.Dot(x) is equal to b, where
+A is the tridiagonal matrix with the passed lower, main and upper diagonals,
+by the algorithm of Thomas, in O(n) time and without building A:
+
+	// A = [[2, 1, 0], [1, 2, 1], [0, 1, 2]]
+	x := matrix.SolveTridiagf64([]float64{1, 1}, []float64{2, 2, 2}, []float64{1, 1}, b)
+
+For n rows, diag has n elements, and lower and upper have n-1, where
+lower[i] is at row i+1 and column i, and upper[i] is at row i and column i+1.
+b must have n rows, and each of its columns is solved for separately. No
+pivoting is done, which is stable for the diagonally dominant or symmetric
+positive definite systems of splines and diffusion problems.
+*/
+func SolveTridiagf64(lower, diag, upper []float64, b *Matf64) *Matf64 {
+	n := len(diag)
+	if len(lower) != n-1 || len(upper) != n-1 || b.r != n || n == 0 {
+		s := "\nIn matrix.%s, the main diagonal has %d elements, so the lower\n"
+		s += "and upper diagonals must have %d, and b must have %d rows, however\n"
+		s += "they have %d, %d and %d."
+		s = fmt.Sprintf(s, "SolveTridiagf64()", n, n-1, n, len(lower), len(upper), b.r)
+		printErr(ErrShape, s)
+	}
+	x := b.Copy()
+	c := make([]float64, n)
+	k := x.c
+	for i := 0; i < n; i++ {
+		d := diag[i]
+		if i > 0 {
+			d -= lower[i-1] * c[i-1]
+		}
+		if d == 0.0 {
+			s := "\nIn matrix.%s, the pivot of row %d is zero. The system is\n"
+			s += "singular, or needs pivoting, and should be solved with Solve."
+			s = fmt.Sprintf(s, "SolveTridiagf64()", i)
+			printErr(&ErrSingular{"SolveTridiagf64()", i}, s)
+		}
+		if i < n-1 {
+			c[i] = upper[i] / d
+		}
+		xi := x.vals[i*k : (i+1)*k]
+		for j := range xi {
+			if i > 0 {
+				xi[j] -= lower[i-1] * x.vals[(i-1)*k+j]
+			}
+			xi[j] /= d
+		}
+	}
+	for i := n - 2; i >= 0; i-- {
+		for j := 0; j < k; j++ {
+			x.vals[i*k+j] -= c[i] * x.vals[(i+1)*k+j]
+		}
+	}
+	return x
+}
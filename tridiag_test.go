@@ -0,0 +1,28 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestSolveTridiagf64(t *testing.T) {
+	t.Helper()
+	lower := []float64{1, -1, 2}
+	diag := []float64{4, 5, 6, 7}
+	upper := []float64{2, 1, -1}
+	a := Matf64FromData([]float64{
+		4, 2, 0, 0,
+		1, 5, 1, 0,
+		0, -1, 6, -1,
+		0, 0, 2, 7,
+	}, 4, 4)
+	x := Matf64FromData([]float64{
+		1, 0,
+		2, 1,
+		-1, 1,
+		3, 2,
+	}, 4, 2)
+	b := a.Dot(x)
+	assertMatInDelta(t, x, SolveTridiagf64(lower, diag, upper, b), 1e-12)
+	one := SolveTridiagf64(nil, []float64{2}, nil, Matf64FromData([]float64{6}, 1, 1))
+	assertMatInDelta(t, Matf64FromData([]float64{3}, 1, 1), one, 1e-15)
+}
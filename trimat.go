@@ -0,0 +1,263 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+Triangle specifies which triangle of a TriMatf64 holds its elements.
+*/
+type Triangle int
+
+const (
+	// LowerTri is a matrix whose elements above the diagonal are zero.
+	LowerTri Triangle = iota
+	// UpperTri is a matrix whose elements below the diagonal are zero.
+	UpperTri
+)
+
+/*
+String returns the name of a Triangle.
+*/
+func (t Triangle) String() string {
+	switch t {
+	case LowerTri:
+		return "LowerTri"
+	case UpperTri:
+		return "UpperTri"
+	}
+	return fmt.Sprintf("Triangle(%d)", int(t))
+}
+
+/*
+TriMatf64 is an n by n triangular matrix of float64s, such as the factors of
+the Cholesky or LU decompositions. Only the n(n+1)/2 elements of the lower or
+upper triangle are stored. A lower triangle is packed row by row, and an upper
+triangle column by column, so that the transpose of a TriMatf64 has the same
+packed storage as the original.
+
+As with Matf64, the fields of this struct are not directly accessible.
+*/
+type TriMatf64 struct {
+	n    int
+	kind Triangle
+	vals []float64
+}
+
+func checkTriangle(fn string, kind Triangle) {
+	if kind != LowerTri && kind != UpperTri {
+		s := "\nIn %s, the triangle must be LowerTri or UpperTri, however\n"
+		s += "%v was received."
+		s = fmt.Sprintf(s, fn, kind)
+		printHelperErr(ErrArgument, s)
+	}
+}
+
+/*
+NewTriMatf64 returns an n by n TriMatf64 of the passed kind, whose elements
+are all zero.
+*/
+func NewTriMatf64(n int, kind Triangle) *TriMatf64 {
+	checkTriangle("matrix.NewTriMatf64()", kind)
+	if n < 0 {
+		s := "\nIn matrix.%s, the size cannot be negative, however %d was\n"
+		s += "received."
+		s = fmt.Sprintf(s, "NewTriMatf64()", n)
+		printErr(ErrArgument, s)
+	}
+	return &TriMatf64{n, kind, make([]float64, n*(n+1)/2)}
+}
+
+/*
+TriMatf64FromMatf64 returns a TriMatf64 holding the passed triangle of the
+passed Matf64, which must be square. The elements of the other triangle are
+ignored.
+*/
+func TriMatf64FromMatf64(m *Matf64, kind Triangle) *TriMatf64 {
+	if m.r != m.c {
+		s := "\nIn matrix.%s, the passed Matf64 is %d by %d. It must be square."
+		s = fmt.Sprintf(s, "TriMatf64FromMatf64()", m.r, m.c)
+		printErr(ErrShape, s)
+	}
+	t := NewTriMatf64(m.r, kind)
+	for i := 0; i < t.n; i++ {
+		for j := 0; j < t.n; j++ {
+			if t.inTriangle(i, j) {
+				t.vals[t.idx(i, j)] = m.vals[i*m.c+j]
+			}
+		}
+	}
+	return t
+}
+
+func (t *TriMatf64) inTriangle(i, j int) bool {
+	if t.kind == LowerTri {
+		return j <= i
+	}
+	return i <= j
+}
+
+// idx returns the index of element (i, j), which must be in the stored
+// triangle, in the packed storage.
+func (t *TriMatf64) idx(i, j int) int {
+	if t.kind == LowerTri {
+		return i*(i+1)/2 + j
+	}
+	return i + j*(j+1)/2
+}
+
+/*
+Size returns the number of rows, which is also the number of columns, of a
+TriMatf64.
+*/
+func (t *TriMatf64) Size() int {
+	return t.n
+}
+
+/*
+Kind returns the triangle in which the elements of a TriMatf64 are stored.
+*/
+func (t *TriMatf64) Kind() Triangle {
+	return t.kind
+}
+
+/*
+Get returns the element of a TriMatf64 at the given row and column, which is
+zero outside of its triangle.
+*/
+func (t *TriMatf64) Get(r, c int) float64 {
+	if !t.inTriangle(r, c) {
+		return 0.0
+	}
+	return t.vals[t.idx(r, c)]
+}
+
+/*
+Set sets the element of a TriMatf64 at the given row and column, which must be
+in its triangle.
+*/
+func (t *TriMatf64) Set(r, c int, val float64) *TriMatf64 {
+	if !t.inTriangle(r, c) {
+		s := "\nIn %s, row %d and column %d are outside of the %v triangle."
+		s = fmt.Sprintf(s, "Set()", r, c, t.kind)
+		printErr(ErrBounds, s)
+	}
+	t.vals[t.idx(r, c)] = val
+	return t
+}
+
+/*
+ToMatf64 returns a Matf64 holding all the elements of a TriMatf64, including
+the zeros of the other triangle.
+*/
+func (t *TriMatf64) ToMatf64() *Matf64 {
+	m := Newf64(t.n, t.n)
+	for i := 0; i < t.n; i++ {
+		for j := 0; j < t.n; j++ {
+			if t.inTriangle(i, j) {
+				m.vals[i*t.n+j] = t.vals[t.idx(i, j)]
+			}
+		}
+	}
+	return m
+}
+
+/*
+Copy returns a duplicate of a TriMatf64, which shares no data with the
+original.
+*/
+func (t *TriMatf64) Copy() *TriMatf64 {
+	n := NewTriMatf64(t.n, t.kind)
+	copy(n.vals, t.vals)
+	return n
+}
+
+/*
+T returns the transpose of a TriMatf64, which is a TriMatf64 of the other
+kind. Due to the packing of the elements, this is a plain copy.
+*/
+func (t *TriMatf64) T() *TriMatf64 {
+	n := t.Copy()
+	n.kind = LowerTri
+	if t.kind == LowerTri {
+		n.kind = UpperTri
+	}
+	return n
+}
+
+/*
+Dot returns the product of a TriMatf64 and the passed Matf64, which must have
+as many rows as the TriMatf64 has columns. Only the elements of the stored
+triangle are multiplied.
+*/
+func (t *TriMatf64) Dot(m *Matf64) *Matf64 {
+	if t.n != m.r {
+		s := "\nIn %s the number of columns of the TriMatf64 is %d\n"
+		s += "which is not equal to the number of rows of the Matf64, which\n"
+		s += "is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Dot()", t.n, m.r)
+		printErr(&ErrDimMismatch{"Dot()", t.n, t.n, m.r, m.c}, s)
+	}
+	o := Newf64(t.n, m.c)
+	for i := 0; i < t.n; i++ {
+		lo, hi := 0, i+1
+		if t.kind == UpperTri {
+			lo, hi = i, t.n
+		}
+		oi := o.vals[i*o.c : (i+1)*o.c]
+		for j := lo; j < hi; j++ {
+			v := t.vals[t.idx(i, j)]
+			mj := m.vals[j*m.c : (j+1)*m.c]
+			for k := range oi {
+				oi[k] += v * mj[k]
+			}
+		}
+	}
+	return o
+}
+
+/*
+Solve returns the Matf64 x such that t.Dot(x) is equal to the passed Matf64,
+by forward substitution for a lower triangle, or back substitution for an
+upper triangle. Each column of the passed Matf64 is solved for separately, and
+it must have as many rows as the TriMatf64. All diagonal elements of the
+TriMatf64 must be non-zero.
+*/
+func (t *TriMatf64) Solve(b *Matf64) *Matf64 {
+	if t.n != b.r {
+		s := "\nIn %s the size of the TriMatf64 is %d, while the number of\n"
+		s += "rows of the passed Matf64 is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Solve()", t.n, b.r)
+		printErr(&ErrDimMismatch{"Solve()", t.n, t.n, b.r, b.c}, s)
+	}
+	x := b.Copy()
+	for step := 0; step < t.n; step++ {
+		i := step
+		if t.kind == UpperTri {
+			i = t.n - 1 - step
+		}
+		d := t.vals[t.idx(i, i)]
+		if d == 0.0 {
+			s := "\nIn %s, the diagonal element of row %d is zero, so the\n"
+			s += "system has no unique solution."
+			s = fmt.Sprintf(s, "Solve()", i)
+			printErr(&ErrSingular{"Solve()", i}, s)
+		}
+		lo, hi := 0, i
+		if t.kind == UpperTri {
+			lo, hi = i+1, t.n
+		}
+		xi := x.vals[i*x.c : (i+1)*x.c]
+		for j := lo; j < hi; j++ {
+			v := t.vals[t.idx(i, j)]
+			xj := x.vals[j*x.c : (j+1)*x.c]
+			for k := range xi {
+				xi[k] -= v * xj[k]
+			}
+		}
+		for k := range xi {
+			xi[k] /= d
+		}
+	}
+	return x
+}
@@ -0,0 +1,61 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTriMatf64(t *testing.T) {
+	t.Helper()
+	a := NewTriMatf64(4, UpperTri)
+	assert.Equal(t, 4, a.Size(), "should be equal")
+	assert.Equal(t, UpperTri, a.Kind(), "should be equal")
+	assert.Equal(t, 10, len(a.vals), "should store only one triangle")
+	assert.Equal(t, "LowerTri", LowerTri.String(), "should be equal")
+}
+
+func TestTriMatf64GetSet(t *testing.T) {
+	t.Helper()
+	a := NewTriMatf64(3, LowerTri)
+	a.Set(2, 1, 5.0)
+	assert.Equal(t, 5.0, a.Get(2, 1), "should be equal")
+	assert.Equal(t, 0.0, a.Get(1, 2), "should be zero outside the triangle")
+}
+
+func TestTriMatf64FromMatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	l := TriMatf64FromMatf64(m, LowerTri)
+	assert.Equal(t, []float64{1, 0, 0, 4, 5, 0, 7, 8, 9}, l.ToMatf64().ToSlice1D(), "should be equal")
+	u := TriMatf64FromMatf64(m, UpperTri)
+	assert.Equal(t, []float64{1, 2, 3, 0, 5, 6, 0, 0, 9}, u.ToMatf64().ToSlice1D(), "should be equal")
+	assert.True(t, u.T().ToMatf64().Equals(u.ToMatf64().T()), "should be equal")
+	assert.Equal(t, LowerTri, u.T().Kind(), "should be equal")
+	c := l.Copy()
+	c.Set(0, 0, 10.0)
+	assert.Equal(t, 1.0, l.Get(0, 0), "should be a copy")
+}
+
+func TestTriMatf64Dot(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	n := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 3, 2)
+	for _, kind := range []Triangle{LowerTri, UpperTri} {
+		a := TriMatf64FromMatf64(m, kind)
+		assert.True(t, a.Dot(n).Equals(a.ToMatf64().Dot(n)), "should be equal")
+	}
+}
+
+func TestTriMatf64Solve(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{2, 1, -1, 3, 4, 2, 1, 5, 3}, 3, 3)
+	b := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 3, 2)
+	for _, kind := range []Triangle{LowerTri, UpperTri} {
+		a := TriMatf64FromMatf64(m, kind)
+		got := a.Dot(a.Solve(b)).ToSlice1D()
+		for k, v := range b.ToSlice1D() {
+			assert.InDelta(t, v, got[k], 1e-12, "should solve the system")
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package matrix
+
+// The methods below are the variants of the fallible operations of Matf64
+// which return an error, instead of exiting the program on failure, for use
+// by libraries which need to pass the error on to their callers. Each does
+// the same as the method without the Try prefix, and the error is an *Error,
+// whose Kind is the same typed error, such as *ErrDimMismatch, as is returned
+// by Chainf64 for the same failure.
+// The constructors and the input and output methods have the E variants in
+// matf64e.go for the same purpose.
+
+/*
+TryReshape does the same as Reshape, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryReshape(rows, cols int) (*Matf64, error) {
+	return m, Catch(func() { m.Reshape(rows, cols) })
+}
+
+/*
+TrySetCol does the same as SetCol, but returns an error instead of exiting.
+*/
+func (m *Matf64) TrySetCol(col int, floatOrSlice interface{}) (*Matf64, error) {
+	return m, Catch(func() { m.SetCol(col, floatOrSlice) })
+}
+
+/*
+TrySetRow does the same as SetRow, but returns an error instead of exiting.
+*/
+func (m *Matf64) TrySetRow(row int, floatOrSlice interface{}) (*Matf64, error) {
+	return m, Catch(func() { m.SetRow(row, floatOrSlice) })
+}
+
+/*
+TryCol does the same as Col, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryCol(x int) (v *Matf64, err error) {
+	err = Catch(func() { v = m.Col(x) })
+	return v, err
+}
+
+/*
+TryRow does the same as Row, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryRow(x int) (v *Matf64, err error) {
+	err = Catch(func() { v = m.Row(x) })
+	return v, err
+}
+
+/*
+TryMin does the same as Min, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryMin(args ...int) (index int, minVal float64, err error) {
+	err = Catch(func() { index, minVal = m.Min(args...) })
+	return index, minVal, err
+}
+
+/*
+TryMax does the same as Max, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryMax(args ...int) (index int, maxVal float64, err error) {
+	err = Catch(func() { index, maxVal = m.Max(args...) })
+	return index, maxVal, err
+}
+
+/*
+TryMul does the same as Mul, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryMul(float64OrMatf64 interface{}) (*Matf64, error) {
+	return m, Catch(func() { m.Mul(float64OrMatf64) })
+}
+
+/*
+TryAdd does the same as Add, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryAdd(float64OrMatf64 interface{}) (*Matf64, error) {
+	return m, Catch(func() { m.Add(float64OrMatf64) })
+}
+
+/*
+TrySub does the same as Sub, but returns an error instead of exiting.
+*/
+func (m *Matf64) TrySub(float64OrMatf64 interface{}) (*Matf64, error) {
+	return m, Catch(func() { m.Sub(float64OrMatf64) })
+}
+
+/*
+TryDiv does the same as Div, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryDiv(float64OrMatf64 interface{}) (*Matf64, error) {
+	return m, Catch(func() { m.Div(float64OrMatf64) })
+}
+
+/*
+TrySum does the same as Sum, but returns an error instead of exiting.
+*/
+func (m *Matf64) TrySum(args ...int) (sum float64, err error) {
+	err = Catch(func() { sum = m.Sum(args...) })
+	return sum, err
+}
+
+/*
+TryAvg does the same as Avg, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryAvg(args ...int) (avg float64, err error) {
+	err = Catch(func() { avg = m.Avg(args...) })
+	return avg, err
+}
+
+/*
+TryPrd does the same as Prd, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryPrd(args ...int) (prd float64, err error) {
+	err = Catch(func() { prd = m.Prd(args...) })
+	return prd, err
+}
+
+/*
+TryStd does the same as Std, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryStd(args ...int) (std float64, err error) {
+	err = Catch(func() { std = m.Std(args...) })
+	return std, err
+}
+
+/*
+TryDot does the same as Dot, but returns an error instead of exiting, for
+example when the number of columns of the receiver is not equal to the
+number of rows of the passed Matf64.
+*/
+func (m *Matf64) TryDot(n *Matf64) (o *Matf64, err error) {
+	err = Catch(func() { o = m.Dot(n) })
+	return o, err
+}
+
+/*
+TryAppendCol does the same as AppendCol, but returns an error instead of
+exiting.
+*/
+func (m *Matf64) TryAppendCol(v []float64) (*Matf64, error) {
+	return m, Catch(func() { m.AppendCol(v) })
+}
+
+/*
+TryAppendRow does the same as AppendRow, but returns an error instead of
+exiting.
+*/
+func (m *Matf64) TryAppendRow(v []float64) (*Matf64, error) {
+	return m, Catch(func() { m.AppendRow(v) })
+}
+
+/*
+TryConcat does the same as Concat, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryConcat(n *Matf64) (*Matf64, error) {
+	return m, Catch(func() { m.Concat(n) })
+}
+
+/*
+TryAppend does the same as Append, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryAppend(n *Matf64) (*Matf64, error) {
+	return m, Catch(func() { m.Append(n) })
+}
+
+/*
+TryDet does the same as Det, but returns an error instead of exiting.
+*/
+func (m *Matf64) TryDet() (det float64, err error) {
+	err = Catch(func() { det = m.Det() })
+	return det, err
+}
+
+/*
+TryInv does the same as Inv, but returns an error instead of exiting, for
+example when the receiver is singular.
+*/
+func (m *Matf64) TryInv() (inv *Matf64, err error) {
+	err = Catch(func() { inv = m.Inv() })
+	return inv, err
+}
+
+/*
+TrySolve does the same as Solve, but returns an error instead of exiting.
+*/
+func (m *Matf64) TrySolve(b *Matf64) (x *Matf64, err error) {
+	err = Catch(func() { x = m.Solve(b) })
+	return x, err
+}
@@ -0,0 +1,93 @@
+//go:build !matnocheck
+// +build !matnocheck
+
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+
+	o, err := m.TryDot(Newf64(2, 2))
+	assert.Nil(t, o, "should be nil")
+	var e *Error
+	assert.True(t, errors.As(err, &e), "should be an *Error")
+	assert.Equal(t, "Dot()", e.Op, "should be equal")
+
+	o, err = m.TryDot(Newf64(3, 1).SetAll(1))
+	assert.NoError(t, err, "should not fail")
+	assert.Equal(t, []float64{6, 15}, o.vals, "should be equal")
+
+	_, err = m.TryReshape(4, 4)
+	assert.Error(t, err, "should fail")
+	o, err = m.TryReshape(3, 2)
+	assert.NoError(t, err, "should not fail")
+	assert.True(t, m == o, "should return the receiver")
+
+	_, err = m.TryAdd(Newf64(2, 2))
+	assert.Error(t, err, "should fail")
+	_, err = m.TryRow(5)
+	assert.Error(t, err, "should fail")
+	_, err = m.TryAppendRow([]float64{1})
+	assert.Error(t, err, "should fail")
+
+	sum, err := m.TrySum()
+	assert.NoError(t, err, "should not fail")
+	assert.Equal(t, 21.0, sum, "should be equal")
+
+	_, err = Matf64FromData([]float64{1, 2, 2, 4}, 2, 2).TryInv()
+	assert.Error(t, err, "should fail")
+	inv, err := Matf64FromData([]float64{2, 0, 0, 4}, 2, 2).TryInv()
+	assert.NoError(t, err, "should not fail")
+	assert.Equal(t, []float64{0.5, 0, 0, 0.25}, inv.vals, "should be equal")
+}
+
+func TestTryTypedErrorsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	sing := Matf64FromData([][]float64{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}})
+	cases := []struct {
+		try   func() error
+		chain *Chainf64
+	}{
+		{func() error { _, err := m.TryDot(m); return err }, m.Chain().Dot(m)},
+		{func() error { _, err := m.TryAdd(Newf64(3, 2)); return err }, m.Chain().Add(Newf64(3, 2))},
+		{func() error { _, err := m.TryDiv(Newf64(2, 2)); return err }, m.Chain().Div(Newf64(2, 2))},
+		{func() error { _, err := m.TryReshape(4, 4); return err }, m.Chain().Reshape(4, 4)},
+		{func() error { _, err := m.TryRow(2); return err }, m.Chain().Row(2)},
+		{func() error { _, err := m.TryCol(-4); return err }, m.Chain().Col(-4)},
+		{func() error { _, err := m.TryAppendRow([]float64{1}); return err }, m.Chain().AppendRow([]float64{1})},
+		{func() error { _, err := m.TryAppendCol([]float64{1}); return err }, m.Chain().AppendCol([]float64{1})},
+		{func() error { _, err := m.TryConcat(Newf64(3, 1)); return err }, m.Chain().Concat(Newf64(3, 1))},
+		{func() error { _, err := m.TryInv(); return err }, m.Chain().Inv()},
+		{func() error { _, err := m.TrySolve(Newf64(2, 1)); return err }, m.Chain().Solve(Newf64(2, 1))},
+		{func() error { _, err := sing.TryInv(); return err }, sing.Chain().Inv()},
+		{func() error { _, err := sing.TrySolve(Newf64(3, 1)); return err }, sing.Chain().Solve(Newf64(3, 1))},
+	}
+	for i, c := range cases {
+		err := c.try()
+		var e *Error
+		assert.True(t, errors.As(err, &e), "should be an *Error")
+		want := c.chain.Err()
+		assert.Error(t, want, "should fail")
+		assert.Equal(t, want, e.Kind, "case %d should wrap the error of Chainf64", i)
+	}
+
+	var oob *ErrIndexOOB
+	_, err := m.TrySum(1, 3)
+	assert.True(t, errors.As(err, &oob), "should wrap an *ErrIndexOOB")
+	assert.Equal(t, ErrIndexOOB{"Sum()", 1, 3, 3}, *oob, "should be equal")
+	_, _, err = m.TryMax(0, -1)
+	assert.True(t, errors.As(err, &oob), "should wrap an *ErrIndexOOB")
+	assert.Equal(t, ErrIndexOOB{"Max()", 0, -1, 2}, *oob, "should be equal")
+	var dim *ErrDimMismatch
+	_, err = m.TrySetCol(0, []float64{1, 2, 3})
+	assert.True(t, errors.As(err, &dim), "should wrap an *ErrDimMismatch")
+	assert.Equal(t, ErrDimMismatch{"SetCol()", 2, 3, 3, 1}, *dim, "should be equal")
+}
@@ -0,0 +1,82 @@
+package matrix
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// urlTimeout is the time limit of each request of Matf64FromURL.
+var urlTimeout = int64(time.Minute)
+
+/*
+SetURLTimeout sets the time limit of each request made by Matf64FromURL,
+including reading the response body, and returns the previous setting. The
+default is one minute. A value of 0 or less removes the limit, which is only
+advisable for trusted servers:
+
+	defer matrix.SetURLTimeout(matrix.SetURLTimeout(5 * time.Second))
+
+It is safe to call concurrently with other functions of the package.
+*/
+func SetURLTimeout(d time.Duration) time.Duration {
+	if d < 0 {
+		d = 0
+	}
+	return time.Duration(atomic.SwapInt64(&urlTimeout, int64(d)))
+}
+
+/*
+Matf64FromURL creates a Matf64 from a file served over http or https. The
+response body is decoded as it is received, so no temporary file is needed
+and the body is never held in memory in its entirety:
+
+	m := matrix.Matf64FromURL("https://example.com/data/weights.csv")
+
+Files in the binary format of Save are recognized by their first bytes, and
+read in the same manner as Loadf64. Any other file is interpreted in the
+same manner as Matf64FromCSV. The server must respond with a status of 200
+OK within the time set by SetURLTimeout. Only the http and https schemes are
+supported.
+*/
+func Matf64FromURL(rawURL string) *Matf64 {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot parse %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromURL()", rawURL, err)
+		printErr(ErrArgument, s)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		s := "\nIn matrix.%s, the scheme of %s is \"%s\". Only http and\n"
+		s += "https are supported."
+		s = fmt.Sprintf(s, "Matf64FromURL()", rawURL, u.Scheme)
+		printErr(ErrArgument, s)
+	}
+	client := &http.Client{Timeout: time.Duration(atomic.LoadInt64(&urlTimeout))}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot get %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromURL()", rawURL, err)
+		printErr(ErrIO, s)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		s := "\nIn matrix.%s, the request for %s failed with status \"%s\".\n"
+		s = fmt.Sprintf(s, "Matf64FromURL()", rawURL, resp.Status)
+		printErr(ErrIO, s)
+	}
+	body := bufio.NewReader(resp.Body)
+	if magic, _ := body.Peek(len(binaryMagic)); string(magic) != binaryMagic {
+		return matf64FromCSVReaderHelper(body, "Matf64FromURL()", rawURL)
+	}
+	m := Newf64()
+	if _, err := m.ReadFrom(body); err != nil {
+		s := "\nIn matrix.%s, cannot load %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromURL()", rawURL, err)
+		printErr(ErrIO, s)
+	}
+	return m
+}
@@ -0,0 +1,52 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64FromURL(t *testing.T) {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "1.0,1.0,2.0\n3.0,5.0,8.0")
+	}))
+	defer ts.Close()
+
+	m := Matf64FromURL(ts.URL + "/m.csv")
+	assert.Equal(t, 2, m.r, "should be equal")
+	assert.Equal(t, 3, m.c, "should be equal")
+	assert.Equal(t, []float64{1, 1, 2, 3, 5, 8}, m.vals, "should be equal")
+}
+
+func TestMatf64FromURLBinaryf64(t *testing.T) {
+	t.Helper()
+	want := RandMatf64(4, 3)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(want.encodeBinary())
+	}))
+	defer ts.Close()
+
+	m := Matf64FromURL(ts.URL + "/m.bin")
+	assert.True(t, m.Equals(want), "should be equal")
+}
+
+func TestSetURLTimeoutf64(t *testing.T) {
+	t.Helper()
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	defer SetURLTimeout(SetURLTimeout(50 * time.Millisecond))
+	assert.Equal(t, 50*time.Millisecond, SetURLTimeout(50*time.Millisecond), "should be equal")
+	err := Catch(func() { Matf64FromURL(ts.URL + "/m.csv") })
+	assert.True(t, errors.Is(err, ErrIO), "should time out")
+}
@@ -0,0 +1,37 @@
+package matrix
+
+import "fmt"
+
+/*
+Vandermondef64 returns the Vandermonde matrix of the passed points, which has
+a row for each point and degree+1 columns holding its powers, highest first,
+as in numpy:
+
+	v := matrix.Vandermondef64([]float64{1, 2, 3}, 2)
+	// [[1, 1, 1],
+	//  [4, 2, 1],
+	//  [9, 3, 1]]
+
+It is the design matrix of polynomial regression, so that v.Dot(p.T())
+evaluates the polynomial with the coefficients in the row vector p, as
+returned by Polyfitf64, at each point, and v.Solve(y) interpolates n points
+with a polynomial of degree n-1. The degree must not be negative.
+*/
+func Vandermondef64(x []float64, degree int) *Matf64 {
+	if degree < 0 {
+		s := "\nIn matrix.%s, the degree must not be negative, however %d\n"
+		s += "was received."
+		s = fmt.Sprintf(s, "Vandermondef64()", degree)
+		printErr(ErrArgument, s)
+	}
+	d := degree + 1
+	v := Newf64(len(x), d)
+	for i, xi := range x {
+		p := 1.0
+		for j := d - 1; j >= 0; j-- {
+			v.vals[i*d+j] = p
+			p *= xi
+		}
+	}
+	return v
+}
@@ -0,0 +1,28 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVandermondef64(t *testing.T) {
+	t.Helper()
+	v := Vandermondef64([]float64{1, 2, 3}, 2)
+	assert.Equal(t, []float64{1, 1, 1, 4, 2, 1, 9, 3, 1}, v.vals, "should be equal")
+	assert.Equal(t, []float64{1, 1}, Vandermondef64([]float64{5, -2}, 0).vals, "should be equal")
+	r, c := Vandermondef64(nil, 3).Shape()
+	assert.Equal(t, 0, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+
+	p := Matf64FromData([]float64{2, -1, 3}, 1, 3)
+	x := Matf64FromData([]float64{-1, 0, 0.5, 4}, 1, 4)
+	assertMatInDelta(t, Polyvalf64(p, x).T(), Vandermondef64(x.vals, 2).Dot(p.T()), 1e-12)
+
+	// Interpolating three points with a quadratic recovers its coefficients.
+	y := Polyvalf64(p, Matf64FromData([]float64{1, 2, 3}, 3, 1))
+	assertMatInDelta(t, p.T(), v.Solve(y), 1e-12)
+
+	defer SetPanicMode(SetPanicMode(true))
+	assert.Panics(t, func() { Vandermondef64([]float64{1}, -1) }, "should panic")
+}
@@ -0,0 +1,138 @@
+package matrix
+
+import "fmt"
+
+/*
+VecViewf64 is a row or a column of a Matf64, which refers to the elements of
+the Matf64 instead of copying them. Setting an element of a VecViewf64 sets
+the element of the Matf64, and the other way around:
+
+	for i := 0; i < r; i++ {
+		m.RowView(i).Map(func(v *float64) { *v /= norms[i] })
+	}
+
+updates each row of m in place, without the allocations of Row and SetRow.
+A VecViewf64 must not be used after the shape of its Matf64 has changed, for
+example by Reshape or AppendCol.
+*/
+type VecViewf64 struct {
+	vals   []float64
+	n      int
+	stride int
+	// readOnly is whether the Matf64 of the view is read-only.
+	readOnly bool
+}
+
+/*
+RowView returns a VecViewf64 of a row of the receiver. Negative indexing is
+supported, as in Row.
+*/
+func (m *Matf64) RowView(x int) *VecViewf64 {
+	if (x >= m.r) || (x < -m.r) {
+		s := "\nIn %s, row %d is outside of the bounds [-%d, %d)\n"
+		s = fmt.Sprintf(s, "RowView()", x, m.r, m.r)
+		printErr(&ErrIndexOOB{"RowView()", 0, x, m.r}, s)
+	}
+	if x < 0 {
+		x += m.r
+	}
+	return &VecViewf64{m.vals[x*m.c : (x+1)*m.c], m.c, 1, m.readOnly}
+}
+
+/*
+ColView returns a VecViewf64 of a column of the receiver. Negative indexing
+is supported, as in Col.
+*/
+func (m *Matf64) ColView(x int) *VecViewf64 {
+	if (x >= m.c) || (x < -m.c) {
+		s := "\nIn %s the requested column %d is outside of bounds [-%d, %d)\n"
+		s = fmt.Sprintf(s, "ColView()", x, m.c, m.c)
+		printErr(&ErrIndexOOB{"ColView()", 1, x, m.c}, s)
+	}
+	if x < 0 {
+		x += m.c
+	}
+	if m.r == 0 {
+		return &VecViewf64{nil, 0, m.c, m.readOnly}
+	}
+	return &VecViewf64{m.vals[x : (m.r-1)*m.c+x+1], m.r, m.c, m.readOnly}
+}
+
+/*
+Len returns the number of elements of a VecViewf64.
+*/
+func (v *VecViewf64) Len() int {
+	return v.n
+}
+
+/*
+Get returns the element at the passed index of a VecViewf64.
+*/
+func (v *VecViewf64) Get(i int) float64 {
+	return v.vals[i*v.stride]
+}
+
+/*
+Set sets the element at the passed index of a VecViewf64, and so of its
+Matf64, to the passed value.
+*/
+func (v *VecViewf64) Set(i int, val float64) *VecViewf64 {
+	v.checkWritable("Set()")
+	v.vals[i*v.stride] = val
+	return v
+}
+
+/*
+SetAll sets all elements of a VecViewf64 to the passed value.
+*/
+func (v *VecViewf64) SetAll(val float64) *VecViewf64 {
+	v.checkWritable("SetAll()")
+	for i := 0; i < v.n; i++ {
+		v.vals[i*v.stride] = val
+	}
+	return v
+}
+
+/*
+Map calls the passed function on a pointer to each element of a VecViewf64,
+in the same manner as the Map method of Matf64.
+*/
+func (v *VecViewf64) Map(f func(*float64)) *VecViewf64 {
+	v.checkWritable("Map()")
+	for i := 0; i < v.n; i++ {
+		f(&v.vals[i*v.stride])
+	}
+	return v
+}
+
+/*
+Sum returns the sum of the elements of a VecViewf64.
+*/
+func (v *VecViewf64) Sum() float64 {
+	sum := 0.0
+	for i := 0; i < v.n; i++ {
+		sum += v.vals[i*v.stride]
+	}
+	return sum
+}
+
+/*
+ToSlice1D returns a copy of the elements of a VecViewf64.
+*/
+func (v *VecViewf64) ToSlice1D() []float64 {
+	s := make([]float64, v.n)
+	for i := range s {
+		s[i] = v.vals[i*v.stride]
+	}
+	return s
+}
+
+// checkWritable reports an error if the Matf64 of v is read-only.
+func (v *VecViewf64) checkWritable(fn string) {
+	if v.readOnly {
+		s := "\nIn %s, the view is of a read-only Matf64, such as one of\n"
+		s += "ZerosSharedf64, EyeSharedf64 or the Mat method of Labeledf64."
+		s = fmt.Sprintf(s, fn)
+		printHelperErr(ErrReadOnly, s)
+	}
+}
@@ -0,0 +1,33 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowViewf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	v := m.RowView(1)
+	assert.Equal(t, 3, v.Len(), "should be equal")
+	assert.Equal(t, []float64{4, 5, 6}, v.ToSlice1D(), "should be equal")
+	assert.Equal(t, 15.0, v.Sum(), "should be equal")
+	v.Set(0, 10)
+	assert.Equal(t, 10.0, m.Get(1, 0), "should change m")
+	m.Set(1, 2, 20)
+	assert.Equal(t, 20.0, v.Get(2), "should see the change to m")
+	m.RowView(-2).Map(func(x *float64) { *x *= 2 })
+	assert.Equal(t, []float64{2, 4, 6, 10, 5, 20}, m.ToSlice1D(), "should be equal")
+}
+
+func TestColViewf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	v := m.ColView(-1)
+	assert.Equal(t, 2, v.Len(), "should be equal")
+	assert.Equal(t, []float64{3, 6}, v.ToSlice1D(), "should be equal")
+	m.ColView(0).SetAll(0)
+	assert.Equal(t, []float64{0, 2, 3, 0, 5, 6}, m.ToSlice1D(), "should be equal")
+	assert.Equal(t, 7.0, m.ColView(1).Sum(), "should be equal")
+}
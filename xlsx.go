@@ -0,0 +1,421 @@
+package matrix
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// The largest sheet that Excel supports has xlsxMaxRows rows and xlsxMaxCols
+// columns. Matf64FromXLSX reads at most xlsxMaxElements elements, so that a
+// few cells far apart cannot exhaust the memory.
+const (
+	xlsxMaxRows     = 1 << 20
+	xlsxMaxCols     = 1 << 14
+	xlsxMaxElements = 1 << 26
+)
+
+/*
+Matf64FromXLSX creates a Matf64 from a sheet of an Excel (.xlsx) file. The
+Matf64 covers the dimension of the sheet, which is the range of cells in use
+as recorded by the program that saved the file, starting at its top left
+cell:
+
+	m := matrix.Matf64FromXLSX("sales.xlsx", "Q1")
+
+If the file records no dimension, the Matf64 covers the smallest rectangular
+range containing all non-empty cells of the sheet instead. A sheet with no
+non-empty cells results in an empty Matf64.
+
+Every non-empty cell in that range must contain a number, or a string which
+can be converted to a float64. Empty cells inside the range are stored as
+NaN, and the range may hold at most 2^26 elements. Only the values of the
+cells are read, so a formula is read as the value which was last calculated
+by the program that saved the file.
+*/
+func Matf64FromXLSX(filename, sheet string) *Matf64 {
+	zr, err := zip.OpenReader(filename)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromXLSX()", filename, err)
+		printErr(ErrIO, s)
+	}
+	defer zr.Close()
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	var wb xlsxWorkbook
+	var rels xlsxRelationships
+	var sst xlsxSST
+	err = xlsxDecode(files, "xl/workbook.xml", &wb, true)
+	if err == nil {
+		err = xlsxDecode(files, "xl/_rels/workbook.xml.rels", &rels, true)
+	}
+	if err == nil {
+		err = xlsxDecode(files, "xl/sharedStrings.xml", &sst, false)
+	}
+	if err != nil {
+		s := "\nIn matrix.%s, cannot read %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromXLSX()", filename, err)
+		printErr(ErrIO, s)
+	}
+	id := ""
+	for _, sh := range wb.Sheets {
+		if sh.Name == sheet {
+			id = sh.ID
+		}
+	}
+	if id == "" {
+		s := "\nIn matrix.%s, %s does not contain a sheet named \"%s\".\n"
+		s = fmt.Sprintf(s, "Matf64FromXLSX()", filename, sheet)
+		printErr(ErrArgument, s)
+	}
+	target := ""
+	for _, rel := range rels.Rels {
+		if rel.ID == id {
+			target = rel.Target
+		}
+	}
+	if strings.HasPrefix(target, "/") {
+		target = target[1:]
+	} else {
+		target = path.Join("xl", target)
+	}
+	var ws xlsxWorksheet
+	if err := xlsxDecode(files, target, &ws, true); err != nil {
+		s := "\nIn matrix.%s, cannot read sheet \"%s\" of %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromXLSX()", sheet, filename, err)
+		printErr(ErrIO, s)
+	}
+
+	type cell struct {
+		r, c int
+		v    float64
+	}
+	var cells []cell
+	minR, minC, maxR, maxC := math.MaxInt32, math.MaxInt32, -1, -1
+	// The r attributes of rows and cells are optional, in which case the
+	// position follows from the previous row or cell.
+	r := -1
+	for _, row := range ws.Rows {
+		r++
+		if row.R != "" {
+			n, err := strconv.Atoi(row.R)
+			if err != nil || n < 1 || n > xlsxMaxRows {
+				s := "\nIn matrix.%s, \"%s\" in sheet \"%s\" is not a valid row\n"
+				s += "number."
+				s = fmt.Sprintf(s, "Matf64FromXLSX()", row.R, sheet)
+				printErr(ErrParse, s)
+			}
+			r = n - 1
+		}
+		col := -1
+		for _, c := range row.Cells {
+			cr := r
+			col++
+			if c.R != "" {
+				var ok bool
+				cr, col, ok = parseCellRef(c.R)
+				if !ok {
+					s := "\nIn matrix.%s, \"%s\" in sheet \"%s\" is not a valid cell\n"
+					s += "reference."
+					s = fmt.Sprintf(s, "Matf64FromXLSX()", c.R, sheet)
+					printErr(ErrParse, s)
+				}
+			} else if col >= xlsxMaxCols {
+				s := "\nIn matrix.%s, row %d of sheet \"%s\" has more than %d cells.\n"
+				s = fmt.Sprintf(s, "Matf64FromXLSX()", r+1, sheet, xlsxMaxCols)
+				printErr(ErrParse, s)
+			}
+			ref := colName(col) + strconv.Itoa(cr+1)
+			text := c.V
+			switch c.T {
+			case "s":
+				i, err := strconv.Atoi(c.V)
+				if err != nil || i < 0 || i >= len(sst.Items) {
+					s := "\nIn matrix.%s, cell %s of sheet \"%s\" refers to a\n"
+					s += "missing shared string."
+					s = fmt.Sprintf(s, "Matf64FromXLSX()", ref, sheet)
+					printErr(ErrParse, s)
+				}
+				text = sst.Items[i].text()
+			case "inlineStr":
+				text = c.IS.text()
+			}
+			if text == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+			if err != nil {
+				s := "\nIn matrix.%s, cell %s of sheet \"%s\" is %s, which cannot\n"
+				s += "be converted to a float64 due to: %v"
+				s = fmt.Sprintf(s, "Matf64FromXLSX()", ref, sheet, text, err)
+				printErr(ErrParse, s)
+			}
+			cells = append(cells, cell{cr, col, v})
+			minR, maxR = minInt(minR, cr), maxInt(maxR, cr)
+			minC, maxC = minInt(minC, col), maxInt(maxC, col)
+		}
+	}
+	if len(cells) == 0 {
+		return Newf64()
+	}
+	if ws.Dimension.Ref != "" {
+		r0, c0, r1, c1, ok := parseRangeRef(ws.Dimension.Ref)
+		if !ok {
+			s := "\nIn matrix.%s, \"%s\" in sheet \"%s\" is not a valid range\n"
+			s += "reference."
+			s = fmt.Sprintf(s, "Matf64FromXLSX()", ws.Dimension.Ref, sheet)
+			printErr(ErrParse, s)
+		}
+		minR, maxR = minInt(minR, r0), maxInt(maxR, r1)
+		minC, maxC = minInt(minC, c0), maxInt(maxC, c1)
+	}
+	if rows, cols := maxR-minR+1, maxC-minC+1; rows*cols > xlsxMaxElements {
+		s := "\nIn matrix.%s, the cells of sheet \"%s\" span %d rows and %d\n"
+		s += "columns, which is more than the %d elements that can be read."
+		s = fmt.Sprintf(s, "Matf64FromXLSX()", sheet, rows, cols, xlsxMaxElements)
+		printErr(ErrShape, s)
+	}
+	m := Newf64(maxR-minR+1, maxC-minC+1)
+	for i := range m.vals {
+		m.vals[i] = math.NaN()
+	}
+	for _, c := range cells {
+		m.vals[(c.r-minR)*m.c+(c.c-minC)] = c.v
+	}
+	return m
+}
+
+/*
+ToXLSX creates an Excel (.xlsx) file with the passed name, containing a single
+sheet with the passed name, and writes the content of the receiver to it,
+starting at cell A1:
+
+	m.ToXLSX("results.xlsx", "run 1")
+
+NaN elements are written as empty cells. The dimension of the sheet is set to
+the whole of the receiver, so that rows and columns of NaN at its edges are
+kept when the file is read back with Matf64FromXLSX. Since Excel cannot
+represent infinite values, the receiver must not contain any.
+*/
+func (m *Matf64) ToXLSX(filename, sheet string) {
+	for i := range m.vals {
+		if math.IsInf(m.vals[i], 0) {
+			s := "\nIn %s, the element at row %d and column %d is %v, which\n"
+			s += "cannot be stored in an xlsx file."
+			s = fmt.Sprintf(s, "ToXLSX()", i/m.c, i%m.c, m.vals[i])
+			printErr(ErrArgument, s)
+		}
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		s := "\nIn %s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "ToXLSX()", filename, err)
+		printErr(ErrIO, s)
+	}
+	zw := zip.NewWriter(f)
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if m.r > 0 && m.c > 0 {
+		fmt.Fprintf(&b, `<dimension ref="A1:%s%d"/>`, colName(m.c-1), m.r)
+	}
+	b.WriteString(`<sheetData>`)
+	for i := 0; i < m.r; i++ {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j := 0; j < m.c; j++ {
+			v := m.vals[i*m.c+j]
+			if math.IsNaN(v) {
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s%d"><v>%s</v></c>`, colName(j), i+1,
+				strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	var name strings.Builder
+	xml.EscapeText(&name, []byte(sheet))
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbookTmpl, name.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/worksheets/sheet1.xml", b.String()},
+	}
+	for _, p := range parts {
+		var w io.Writer
+		w, err = zw.Create(p.name)
+		if err == nil {
+			_, err = io.WriteString(w, p.body)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = zw.Close()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		s := "\nIn %s, cannot write to %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "ToXLSX()", filename, err)
+		printErr(ErrIO, s)
+	}
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbookTmpl = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Rels []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is a string item, which is either a plain <t> element or a list of
+// rich text runs, each of which holds a <t> element.
+type xlsxText struct {
+	T    string   `xml:"t"`
+	Runs []string `xml:"r>t"`
+}
+
+func (x xlsxText) text() string {
+	return x.T + strings.Join(x.Runs, "")
+}
+
+type xlsxSST struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Dimension struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"dimension"`
+	Rows []struct {
+		R     string `xml:"r,attr"`
+		Cells []struct {
+			R  string   `xml:"r,attr"`
+			T  string   `xml:"t,attr"`
+			V  string   `xml:"v"`
+			IS xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// xlsxDecode decodes the xml file with the passed name from the files of an
+// xlsx archive into v. If the file does not exist, an error is returned only
+// if it is required.
+func xlsxDecode(files map[string]*zip.File, name string, v interface{}, required bool) error {
+	f, ok := files[name]
+	if !ok {
+		if required {
+			return fmt.Errorf("missing %s", name)
+		}
+		return nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// parseCellRef converts a cell reference such as "B3" to zero based row and
+// column indices.
+func parseCellRef(ref string) (row, col int, ok bool) {
+	i := 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		col = col*26 + int(ref[i]-'A') + 1
+		i++
+		if col > xlsxMaxCols {
+			return 0, 0, false
+		}
+	}
+	if i == 0 {
+		return 0, 0, false
+	}
+	r, err := strconv.Atoi(ref[i:])
+	if err != nil || r < 1 || r > xlsxMaxRows {
+		return 0, 0, false
+	}
+	return r - 1, col - 1, true
+}
+
+// parseRangeRef converts a range reference such as "B2:D5", or a single cell
+// reference such as "A1", to the zero based indices of its top left and
+// bottom right cells.
+func parseRangeRef(ref string) (r0, c0, r1, c1 int, ok bool) {
+	first, last := ref, ref
+	if i := strings.IndexByte(ref, ':'); i >= 0 {
+		first, last = ref[:i], ref[i+1:]
+	}
+	r0, c0, ok = parseCellRef(first)
+	if !ok {
+		return 0, 0, 0, 0, false
+	}
+	r1, c1, ok = parseCellRef(last)
+	if !ok || r1 < r0 || c1 < c0 {
+		return 0, 0, 0, 0, false
+	}
+	return r0, c0, r1, c1, true
+}
+
+// colName converts a zero based column index to its spreadsheet name, such as
+// "A", "Z" or "AA".
+func colName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
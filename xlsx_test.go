@@ -0,0 +1,212 @@
+package matrix
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64FromXLSX(t *testing.T) {
+	t.Helper()
+	filename := "fromxlsx_test.xlsx"
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` +
+			`<sheet name="other" sheetId="1" r:id="rId1"/><sheet name="data" sheetId="2" r:id="rId2"/>` +
+			`</sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>2.5</t></si><si><r><t>1</t></r><r><t>0</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData/></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="2"><c r="B2"><v>1</v></c><c r="C2" t="s"><v>0</v></c></row>` +
+			`<row r="3"><c r="C3" t="inlineStr"><is><t>-4</t></is></c></row>` +
+			`<row r="4"><c r="B4" t="s"><v>1</v></c><c r="C4"/></row>` +
+			`</sheetData></worksheet>`,
+	}
+	writeTestXLSX(filename, parts)
+	defer os.Remove(filename)
+
+	m := Matf64FromXLSX(filename, "data")
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 2, m.c, "should be equal")
+	assert.Equal(t, 1.0, m.Get(0, 0), "should be equal")
+	assert.Equal(t, 2.5, m.Get(0, 1), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(1, 0)), "empty cells should be NaN")
+	assert.Equal(t, -4.0, m.Get(1, 1), "should be equal")
+	assert.Equal(t, 10.0, m.Get(2, 0), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(2, 1)), "empty cells should be NaN")
+
+	m = Matf64FromXLSX(filename, "other")
+	assert.Equal(t, 0, m.r, "should be empty")
+	assert.Equal(t, 0, m.c, "should be empty")
+}
+
+func TestMatf64FromXLSXPositionsf64(t *testing.T) {
+	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
+	filename := "fromxlsx_positions_test.xlsx"
+	defer os.Remove(filename)
+	write := func(sheetData string) {
+		writeTestXLSX(filename, map[string]string{
+			"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+				`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` +
+				`<sheet name="data" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+			"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+				`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+			"xl/worksheets/sheet1.xml": `<worksheet><sheetData>` + sheetData + `</sheetData></worksheet>`,
+		})
+	}
+
+	// Without r attributes, cells follow each other in the row, and rows
+	// follow the previous row.
+	write(`<row><c><v>1</v></c><c/><c><v>3</v></c></row>` +
+		`<row r="3"><c><v>4</v></c><c r="C3"><v>6</v></c></row>` +
+		`<row><c><v>7</v></c><c><v>8</v></c></row>`)
+	m := Matf64FromXLSX(filename, "data")
+	assert.Equal(t, 4, m.r, "should be equal")
+	assert.Equal(t, 3, m.c, "should be equal")
+	assert.Equal(t, 1.0, m.Get(0, 0), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(0, 1)), "empty cells should be NaN")
+	assert.Equal(t, 3.0, m.Get(0, 2), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(1, 0)), "missing rows should be NaN")
+	assert.Equal(t, 4.0, m.Get(2, 0), "should be equal")
+	assert.Equal(t, 6.0, m.Get(2, 2), "should be equal")
+	assert.Equal(t, 7.0, m.Get(3, 0), "should be equal")
+	assert.Equal(t, 8.0, m.Get(3, 1), "should be equal")
+
+	// Two cells far apart would need about 17e9 elements.
+	write(`<row r="1"><c r="A1"><v>1</v></c></row>` +
+		`<row r="1048576"><c r="XFD1048576"><v>2</v></c></row>`)
+	err := Catch(func() { Matf64FromXLSX(filename, "data") })
+	assert.True(t, errors.Is(err, ErrShape), "should refuse to allocate")
+
+	write(`<row r="1"><c r="XFE1"><v>1</v></c></row>`)
+	err = Catch(func() { Matf64FromXLSX(filename, "data") })
+	assert.True(t, errors.Is(err, ErrParse), "should be out of the sheet")
+}
+
+func TestMatf64FromXLSXDimensionf64(t *testing.T) {
+	t.Helper()
+	defer SetPanicMode(SetPanicMode(true))
+	filename := "fromxlsx_dimension_test.xlsx"
+	defer os.Remove(filename)
+	write := func(worksheet string) {
+		writeTestXLSX(filename, map[string]string{
+			"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+				`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` +
+				`<sheet name="data" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+			"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+				`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+			"xl/worksheets/sheet1.xml": worksheet,
+		})
+	}
+
+	// The dimension covers more than the non-empty cells.
+	write(`<worksheet><dimension ref="B2:D4"/><sheetData>` +
+		`<row r="3"><c r="C3"><v>5</v></c></row></sheetData></worksheet>`)
+	m := Matf64FromXLSX(filename, "data")
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 3, m.c, "should be equal")
+	assert.Equal(t, 5.0, m.Get(1, 1), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(0, 0)), "empty cells should be NaN")
+	assert.True(t, math.IsNaN(m.Get(2, 2)), "empty cells should be NaN")
+
+	// Cells outside of the dimension are still read.
+	write(`<worksheet><dimension ref="A1"/><sheetData>` +
+		`<row r="2"><c r="B2"><v>5</v></c></row></sheetData></worksheet>`)
+	m = Matf64FromXLSX(filename, "data")
+	assert.Equal(t, 2, m.r, "should be equal")
+	assert.Equal(t, 2, m.c, "should be equal")
+	assert.Equal(t, 5.0, m.Get(1, 1), "should be equal")
+
+	write(`<worksheet><dimension ref="C3:A1"/><sheetData>` +
+		`<row r="1"><c r="A1"><v>5</v></c></row></sheetData></worksheet>`)
+	err := Catch(func() { Matf64FromXLSX(filename, "data") })
+	assert.True(t, errors.Is(err, ErrParse), "should be an invalid range")
+}
+
+func TestToXLSXf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(11, 30)
+	for i := range m.vals {
+		m.vals[i] = float64(i) / 7.0
+	}
+	filename := "toxlsx_test.xlsx"
+	m.ToXLSX(filename, "a & b")
+	defer os.Remove(filename)
+	n := Matf64FromXLSX(filename, "a & b")
+	assert.True(t, n.Equals(m), "should round trip")
+}
+
+func TestToXLSXNaNEdgesf64(t *testing.T) {
+	t.Helper()
+	nan := math.NaN()
+	m := Matf64FromData([]float64{
+		nan, nan, nan, nan,
+		nan, 1, 2, nan,
+		nan, nan, nan, nan,
+	}, 3, 4)
+	filename := "toxlsx_nan_test.xlsx"
+	m.ToXLSX(filename, "data")
+	defer os.Remove(filename)
+	n := Matf64FromXLSX(filename, "data")
+	assert.Equal(t, 3, n.r, "should keep the rows of NaN")
+	assert.Equal(t, 4, n.c, "should keep the columns of NaN")
+	for i := range m.vals {
+		if math.IsNaN(m.vals[i]) {
+			assert.True(t, math.IsNaN(n.vals[i]), "should be NaN")
+		} else {
+			assert.Equal(t, m.vals[i], n.vals[i], "should be equal")
+		}
+	}
+}
+
+func TestColNamef64(t *testing.T) {
+	t.Helper()
+	for i, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, name, colName(i), "should be equal")
+		r, c, ok := parseCellRef(name + "7")
+		assert.True(t, ok, "should be valid")
+		assert.Equal(t, 6, r, "should be equal")
+		assert.Equal(t, i, c, "should be equal")
+	}
+	_, _, ok := parseCellRef("12")
+	assert.False(t, ok, "should be invalid")
+	_, _, ok = parseCellRef("A1048577")
+	assert.False(t, ok, "should be invalid")
+	_, _, ok = parseCellRef("ZZZZZZZZZZZZZZ1")
+	assert.False(t, ok, "should be invalid")
+}
+
+// writeTestXLSX writes an xlsx archive holding the passed parts.
+func writeTestXLSX(filename string, parts map[string]string) {
+	f, err := os.Create(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, body := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err = io.WriteString(w, body); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err = zw.Close(); err != nil {
+		log.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}